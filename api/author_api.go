@@ -23,7 +23,7 @@ func (h *authorHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	author, err := h.authorService.Find(r.Context(), id)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -35,13 +35,13 @@ func (h *authorHandler) Post(w http.ResponseWriter, r *http.Request) {
 
 	author := &payload.AuthorRequest{}
 	if err := decodeBody(r, author); err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
 	err := h.authorService.Store(r.Context(), author)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -55,13 +55,13 @@ func (h *authorHandler) Put(w http.ResponseWriter, r *http.Request) {
 
 	author := &payload.AuthorRequest{}
 	if err := decodeBody(r, author); err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
 	err := h.authorService.Update(r.Context(), id, author)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -74,7 +74,7 @@ func (h *authorHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	err := h.authorService.Delete(r.Context(), id)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -82,12 +82,21 @@ func (h *authorHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		Message: "Deleted author successfully!",
 	})
 }
+
+// GetAll lists the authors, or searches them by name or alias with the q
+// query parameter.
 func (h *authorHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	authors, err := h.authorService.FindAll(r.Context())
+	var authors []*payload.AuthorResponse
+	var err error
+	if r.URL.Query().Has("q") {
+		authors, err = h.authorService.Search(r.Context(), r.URL.Query().Get("q"))
+	} else {
+		authors, err = h.authorService.FindAll(r.Context())
+	}
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -2,8 +2,10 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"bookstore.com/domain/service"
+	portError "bookstore.com/port/error"
 	"bookstore.com/port/payload"
 	"github.com/go-chi/chi"
 )
@@ -23,25 +25,49 @@ func (h *bookHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	book, err := h.authorService.Find(r.Context(), id)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
 	responseJSON(w, http.StatusOK, book)
 }
 
+func (h *bookHandler) GetByISBN(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	isbn := chi.URLParam(r, "isbn")
+	book, err := h.authorService.FindByISBN(r.Context(), isbn)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, book)
+}
+
+func (h *bookHandler) GetTranslations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+	books, err := h.authorService.FindTranslations(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, books)
+}
+
 func (h *bookHandler) Post(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	book := &payload.BookRequest{}
 	if err := decodeBody(r, book); err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
 	err := h.authorService.Store(r.Context(), book)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -55,13 +81,13 @@ func (h *bookHandler) Put(w http.ResponseWriter, r *http.Request) {
 
 	book := &payload.BookRequest{}
 	if err := decodeBody(r, book); err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
 	err := h.authorService.Update(r.Context(), id, book)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -74,7 +100,7 @@ func (h *bookHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	err := h.authorService.Delete(r.Context(), id)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -82,14 +108,78 @@ func (h *bookHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		Message: "Deleted book successfully!",
 	})
 }
+
+// GetAll lists the books, or the books with any of the comma separated tags
+// of the tags query parameter, or with all of them with match=all.
 func (h *bookHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	authors, err := h.authorService.FindAll(r.Context())
+	var books []*payload.BookResponse
+	var err error
+	if query := r.URL.Query(); query.Has("tags") {
+		var all bool
+		switch query.Get("match") {
+		case "", "any":
+		case "all":
+			all = true
+		default:
+			responseErr(w, r, portError.NewBadRequestError("match: invalid, want one of any, all", nil))
+			return
+		}
+		books, err = h.authorService.FindByTags(r.Context(), strings.Split(query.Get("tags"), ","), all)
+	} else {
+		books, err = h.authorService.FindAll(r.Context())
+	}
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, books)
+}
+
+func (h *bookHandler) PostTags(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	req := &payload.TagsRequest{}
+	if err := decodeBody(r, req); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	book, err := h.authorService.AddTags(r.Context(), id, req)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, book)
+}
+
+func (h *bookHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+	tag := chi.URLParam(r, "tag")
+
+	book, err := h.authorService.RemoveTag(r.Context(), id, tag)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, book)
+}
+
+// GetTags lists the tags with their number of books, most used first.
+func (h *bookHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	tags, err := h.authorService.CountTags(r.Context())
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
-	responseJSON(w, http.StatusOK, authors)
+	responseJSON(w, http.StatusOK, tags)
 }
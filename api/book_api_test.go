@@ -6,9 +6,11 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"bookstore.com/domain/service"
+	portError "bookstore.com/port/error"
 	"bookstore.com/port/payload"
 	"bookstore.com/test"
 	"go.uber.org/mock/gomock"
@@ -65,6 +67,33 @@ func Test_bookHandler_GetAll(t *testing.T) {
 			expected:       string(expectedBooksJson),
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name: "success to retrieve books with all tags",
+			bookService: func() service.BookService {
+				bookService := service.NewMockBookService(ctrl)
+				bookService.EXPECT().FindByTags(gomock.Any(), []string{"award", "staff-pick"}, true).Return(books, nil)
+
+				return bookService
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/api/v1/books?tags=award,staff-pick&match=all", nil),
+			},
+			expected:       string(expectedBooksJson),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "failed to retrieve books with an invalid tag match",
+			bookService: func() service.BookService {
+				return service.NewMockBookService(ctrl)
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/api/v1/books?tags=award&match=some", nil),
+			},
+			expected:       string(`{"message":"match: invalid, want one of any, all"}`),
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "failed to retrieve books",
 			bookService: func() service.BookService {
@@ -383,3 +412,127 @@ func Test_bookHandler_Delete(t *testing.T) {
 	}
 
 }
+
+func Test_bookHandler_PostTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	book := &payload.BookResponse{Id: test.BookId1, Tags: []string{"award", "staff-pick"}}
+	expectedBookJson, _ := json.Marshal(book)
+
+	tests := []struct {
+		name           string
+		bookService    func() service.BookService
+		body           string
+		expected       string
+		expectedStatus int
+	}{
+		{
+			name: "success to add tags",
+			bookService: func() service.BookService {
+				bookService := service.NewMockBookService(ctrl)
+				bookService.EXPECT().AddTags(gomock.Any(), test.BookId1, &payload.TagsRequest{Tags: []string{"staff-pick"}}).Return(book, nil)
+
+				return bookService
+			},
+			body:           `{"tags":["staff-pick"]}`,
+			expected:       string(expectedBookJson),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "failed to add tags with an invalid body",
+			bookService: func() service.BookService {
+				return service.NewMockBookService(ctrl)
+			},
+			body:           `{"tags":`,
+			expected:       string(`{"message":"unexpected EOF"}`),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h := NewBookHandler(tt.bookService())
+			r := httptest.NewRequest("POST", "/api/v1/books/"+test.BookId1+"/tags", strings.NewReader(tt.body))
+			h.PostTags(w, withURLParams(r, "id", test.BookId1))
+
+			if w.Body.String() != tt.expected {
+				t.Errorf("Expected json response %s, got %s", tt.expected, w.Body.String())
+			}
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_bookHandler_DeleteTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	book := &payload.BookResponse{Id: test.BookId1, Tags: []string{"award"}}
+	expectedBookJson, _ := json.Marshal(book)
+
+	tests := []struct {
+		name           string
+		bookService    func() service.BookService
+		tag            string
+		expected       string
+		expectedStatus int
+	}{
+		{
+			name: "success to remove tag",
+			bookService: func() service.BookService {
+				bookService := service.NewMockBookService(ctrl)
+				bookService.EXPECT().RemoveTag(gomock.Any(), test.BookId1, "staff-pick").Return(book, nil)
+
+				return bookService
+			},
+			tag:            "staff-pick",
+			expected:       string(expectedBookJson),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "failed to remove an invalid tag",
+			bookService: func() service.BookService {
+				bookService := service.NewMockBookService(ctrl)
+				bookService.EXPECT().RemoveTag(gomock.Any(), test.BookId1, "staff pick").
+					Return(nil, portError.NewBadRequestError("tags[0]: invalid, want lower case letters and digits separated by hyphens", nil))
+
+				return bookService
+			},
+			tag:            "staff pick",
+			expected:       string(`{"message":"tags[0]: invalid, want lower case letters and digits separated by hyphens"}`),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h := NewBookHandler(tt.bookService())
+			r := httptest.NewRequest("DELETE", "/api/v1/books/"+test.BookId1+"/tags/tag", nil)
+			h.DeleteTag(w, withURLParams(r, "id", test.BookId1, "tag", tt.tag))
+
+			if w.Body.String() != tt.expected {
+				t.Errorf("Expected json response %s, got %s", tt.expected, w.Body.String())
+			}
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_bookHandler_GetTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookService := service.NewMockBookService(ctrl)
+	bookService.EXPECT().CountTags(gomock.Any()).Return([]*payload.TagCountResponse{{Tag: "award", Count: 2}}, nil)
+
+	w := httptest.NewRecorder()
+	NewBookHandler(bookService).GetTags(w, httptest.NewRequest("GET", "/api/v1/tags", nil))
+
+	if expected := `[{"tag":"award","count":2}]`; w.Body.String() != expected {
+		t.Errorf("Expected json response %s, got %s", expected, w.Body.String())
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
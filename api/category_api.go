@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+
+	"bookstore.com/domain/service"
+	"bookstore.com/port/payload"
+	"github.com/go-chi/chi"
+)
+
+type categoryHandler struct {
+	categoryService service.CategoryService
+}
+
+func NewCategoryHandler(categoryService service.CategoryService) CategoryHandler {
+	return &categoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+func (h *categoryHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+	category, err := h.categoryService.Find(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, category)
+}
+
+func (h *categoryHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	req := &payload.CategoryRequest{}
+	if err := decodeBody(r, req); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	category, err := h.categoryService.Store(r.Context(), req)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, category)
+}
+
+func (h *categoryHandler) Put(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id := chi.URLParam(r, "id")
+
+	req := &payload.CategoryRequest{}
+	if err := decodeBody(r, req); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	err := h.categoryService.Update(r.Context(), id, req)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	category, err := h.categoryService.Find(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, category)
+}
+
+func (h *categoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	err := h.categoryService.Delete(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, payload.MessageResponse{
+		Message: "Deleted category successfully!",
+	})
+}
+
+func (h *categoryHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	categories, err := h.categoryService.FindAll(r.Context())
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, categories)
+}
+
+func (h *categoryHandler) GetBooks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	books, err := h.categoryService.FindBooks(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, books)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"bookstore.com/domain/service"
+	"github.com/go-chi/chi"
+)
+
+// coverField is the multipart form field holding the uploaded cover.
+const coverField = "cover"
+
+type coverHandler struct {
+	coverService service.CoverService
+}
+
+func NewCoverHandler(coverService service.CoverService) CoverHandler {
+	return &coverHandler{
+		coverService: coverService,
+	}
+}
+
+func (h *coverHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	file, err := h.coverService.Find(r.Context(), id, r.URL.Query().Get("size"))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		responseErr(w, r, err)
+		return
+	}
+
+	serveImage(w, r, file)
+}
+
+// Post uploads the cover of the book from the cover field of a multipart form.
+func (h *coverHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	req, err := readImage(r, coverField)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	res, err := h.coverService.Upload(r.Context(), id, req)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, res)
+}
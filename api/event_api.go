@@ -0,0 +1,237 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/tools/logger"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	headerLastEventId = "Last-Event-ID"
+	defaultHeartbeat  = 15 * time.Second
+)
+
+type eventHandler struct {
+	broker    *event.Broker
+	heartbeat time.Duration
+	upgrader  websocket.Upgrader
+}
+
+// NewEventHandler streams the events published to broker. A heartbeat is
+// sent every heartbeat interval to keep idle connections open. WebSocket
+// handshakes from another origin are only accepted when listed in
+// allowedOrigins, "*" allowing any.
+func NewEventHandler(broker *event.Broker, heartbeat time.Duration, allowedOrigins []string) EventHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+
+	return &eventHandler{
+		broker:    broker,
+		heartbeat: heartbeat,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: checkOrigin(allowedOrigins),
+		},
+	}
+}
+
+// Stream sends the events as Server-Sent Events.
+func (h *eventHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	filter, err := eventFilter(r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		responseErr(w, r, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		responseErr(w, r, fmt.Errorf("streaming unsupported by %T", w))
+		return
+	}
+
+	sub, missed := h.broker.Subscribe(filter, lastEventId(r))
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, e := range missed {
+		if err := writeSSE(w, e); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if err := writeSSE(w, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeSSE(w http.ResponseWriter, e *event.Event) error {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.Id, e.Type, raw)
+	return err
+}
+
+// WebSocket sends the events as JSON text messages over a WebSocket.
+func (h *eventHandler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	filter, err := eventFilter(r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		responseErr(w, r, err)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied with an error.
+		logger.FromContext(r.Context()).Warn("websocket upgrade failed", slog.Any("error", err))
+		return
+	}
+	defer conn.Close()
+
+	sub, missed := h.broker.Subscribe(filter, lastEventId(r))
+	defer sub.Close()
+
+	// The client is not expected to send anything, reading only handles the
+	// control frames and notices when the connection is gone.
+	closed := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, e := range missed {
+		if err := h.writeWebSocket(conn, e); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber lagged behind"),
+					time.Now().Add(h.heartbeat))
+				return
+			}
+			if err := h.writeWebSocket(conn, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.heartbeat)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *eventHandler) writeWebSocket(conn *websocket.Conn, e *event.Event) error {
+	conn.SetWriteDeadline(time.Now().Add(h.heartbeat))
+	return conn.WriteJSON(e)
+}
+
+// eventFilter reads the filter from the repeatable or comma separated type
+// and entityId query parameters.
+func eventFilter(r *http.Request) (event.Filter, error) {
+	filter := event.Filter{}
+
+	for _, t := range queryList(r, "type") {
+		if !event.Type(t).Valid() {
+			return filter, portError.NewBadRequestError(fmt.Sprintf("Unknown event type %q.", t), nil)
+		}
+		filter.Types = append(filter.Types, event.Type(t))
+	}
+	filter.EntityIds = queryList(r, "entityId")
+
+	return filter, nil
+}
+
+func queryList(r *http.Request, key string) []string {
+	var list []string
+	for _, value := range r.URL.Query()[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
+}
+
+// lastEventId returns the ID of the last event the client received. Browsers
+// cannot set headers on a WebSocket handshake, so the lastEventId query
+// parameter is accepted too.
+func lastEventId(r *http.Request) string {
+	if id := r.Header.Get(headerLastEventId); id != "" {
+		return id
+	}
+
+	return r.URL.Query().Get("lastEventId")
+}
+
+func checkOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || origin == "http://"+r.Host || origin == "https://"+r.Host {
+			return true
+		}
+
+		for _, allowed := range allowedOrigins {
+			if allowed == "*" || strings.EqualFold(allowed, origin) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bookstore.com/domain/event"
+	"github.com/gorilla/websocket"
+)
+
+func Test_eventHandler_Stream(t *testing.T) {
+	broker := event.NewBroker(10, 10)
+	broker.Publish(context.Background(), &event.Event{Id: "1", Type: event.BookCreated, EntityId: "book-1"})
+	broker.Publish(context.Background(), &event.Event{Id: "2", Type: event.AuthorCreated, EntityId: "author-1"})
+	broker.Publish(context.Background(), &event.Event{Id: "3", Type: event.BookUpdated, EntityId: "book-1"})
+
+	h := NewEventHandler(broker, 20*time.Millisecond, nil)
+	srv := httptest.NewServer(http.HandlerFunc(h.Stream))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL+"?type=book.created,book.updated", nil)
+	req.Header.Set("Last-Event-ID", "0")
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	go broker.Publish(context.Background(), &event.Event{Id: "4", Type: event.BookDeleted, EntityId: "book-1"})
+
+	want := []string{"id: 1", "event: book.created", "id: 3", "event: book.updated", ": heartbeat"}
+	scanner := bufio.NewScanner(res.Body)
+	for len(want) > 0 && scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "id: 4") {
+			t.Fatalf("filtered event was sent")
+		}
+		if line == want[0] {
+			want = want[1:]
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("missing lines %v", want)
+	}
+}
+
+func Test_eventHandler_Stream_badType(t *testing.T) {
+	h := NewEventHandler(event.NewBroker(10, 10), time.Second, nil)
+
+	w := httptest.NewRecorder()
+	h.Stream(w, httptest.NewRequest("GET", "/api/v1/events?type=book.read", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func Test_eventHandler_WebSocket(t *testing.T) {
+	broker := event.NewBroker(10, 10)
+	broker.Publish(context.Background(), &event.Event{Id: "1", Type: event.BookCreated, EntityId: "book-1"})
+	broker.Publish(context.Background(), &event.Event{Id: "2", Type: event.BookCreated, EntityId: "book-2"})
+
+	h := NewEventHandler(broker, time.Second, nil)
+	srv := httptest.NewServer(http.HandlerFunc(h.WebSocket))
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "?entityId=book-2&lastEventId=1"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	go broker.Publish(context.Background(), &event.Event{Id: "3", Type: event.BookUpdated, EntityId: "book-2"})
+
+	for _, want := range []string{"2", "3"} {
+		e := &event.Event{}
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		if err := conn.ReadJSON(e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Id != want {
+			t.Errorf("event id = %q, want %q", e.Id, want)
+		}
+	}
+}
+
+func Test_checkOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origin  string
+		allowed []string
+		want    bool
+	}{
+		{name: "no origin", want: true},
+		{name: "same host", origin: "http://example.com", want: true},
+		{name: "allowed origin", origin: "https://app.test", allowed: []string{"https://app.test"}, want: true},
+		{name: "any origin", origin: "https://app.test", allowed: []string{"*"}, want: true},
+		{name: "foreign origin", origin: "https://evil.test", allowed: []string{"https://app.test"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/events/ws", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+
+			if got := checkOrigin(tt.allowed)(r); got != tt.want {
+				t.Errorf("checkOrigin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
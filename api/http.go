@@ -14,11 +14,51 @@ type AuthorHandler interface {
 	RestfulHandler
 }
 
+type PublisherHandler interface {
+	RestfulHandler
+}
+
+type SeriesHandler interface {
+	RestfulHandler
+	GetBooks(http.ResponseWriter, *http.Request)
+}
+
 type BookHandler interface {
 	RestfulHandler
+	GetByISBN(http.ResponseWriter, *http.Request)
+	GetTranslations(http.ResponseWriter, *http.Request)
+	PostTags(http.ResponseWriter, *http.Request)
+	DeleteTag(http.ResponseWriter, *http.Request)
+	GetTags(http.ResponseWriter, *http.Request)
+}
+
+type CoverHandler interface {
+	Get(http.ResponseWriter, *http.Request)
+	Post(http.ResponseWriter, *http.Request)
+}
+
+type PhotoHandler interface {
+	Get(http.ResponseWriter, *http.Request)
+	Post(http.ResponseWriter, *http.Request)
+}
+
+type CategoryHandler interface {
+	RestfulHandler
+	GetBooks(http.ResponseWriter, *http.Request)
 }
 
 type UserHandler interface {
 	Register(http.ResponseWriter, *http.Request)
 	Login(http.ResponseWriter, *http.Request)
 }
+
+type WebhookHandler interface {
+	RestfulHandler
+	GetDeliveries(http.ResponseWriter, *http.Request)
+	Redeliver(http.ResponseWriter, *http.Request)
+}
+
+type EventHandler interface {
+	Stream(http.ResponseWriter, *http.Request)
+	WebSocket(http.ResponseWriter, *http.Request)
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"bookstore.com/domain/service"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+)
+
+// serveImage serves a stored image. The response to a URL naming the current
+// version can be cached for good, any other is revalidated with its ETag.
+func serveImage(w http.ResponseWriter, r *http.Request, file *payload.ImageFile) {
+	size := r.URL.Query().Get("size")
+	if size == "" {
+		size = service.ImageOriginal
+	}
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("ETag", fmt.Sprintf(`"%s-%s"`, file.Version, size))
+	if r.URL.Query().Get("v") == file.Version {
+		w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "private, no-cache")
+	}
+
+	http.ServeContent(w, r, "", file.UpdatedAt, bytes.NewReader(file.Data))
+}
+
+// readImage reads the image uploaded in the field of a multipart form.
+func readImage(r *http.Request, field string) (*payload.ImageRequest, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, portError.NewBadRequestError("The image must be uploaded as multipart/form-data.", err)
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, portError.NewBadRequestError(field+": field required", nil)
+		}
+		if err != nil {
+			return nil, multipartErr(err)
+		}
+
+		if part.FormName() != field {
+			part.Close()
+			continue
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, multipartErr(err)
+		}
+
+		return &payload.ImageRequest{ContentType: part.Header.Get("Content-Type"), Data: data}, nil
+	}
+}
+
+func multipartErr(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return portError.NewRequestEntityTooLargeError("", err)
+	}
+
+	return portError.NewBadRequestError("Invalid multipart form.", err)
+}
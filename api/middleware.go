@@ -0,0 +1,369 @@
+package api
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/tools/locale"
+	"bookstore.com/tools/logger"
+	"bookstore.com/tools/ratelimit"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/jwtauth"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("bookstore.com/api")
+
+// Tracing starts a server span for every request, continuing the trace found
+// in the W3C traceparent header if any. The span is named after the matched
+// chi route once routing is done.
+func Tracing(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("client.address", r.RemoteAddr),
+			),
+		)
+		defer span.End()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		if route := routePattern(r); route != "" {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// RequestLogger puts a request scoped logger carrying the request ID and the
+// route pattern into the request context and writes one access log line per
+// request.
+func RequestLogger(l *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			attrs := []any{
+				slog.String("requestId", middleware.GetReqID(r.Context())),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("remoteAddr", r.RemoteAddr),
+			}
+			if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+				attrs = append(attrs, slog.String("traceId", sc.TraceID().String()))
+			}
+			if route := matchRoute(r); route != "" {
+				attrs = append(attrs, slog.String("route", route))
+			}
+			ctx := logger.NewContext(r.Context(), l.With(attrs...))
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+
+			logger.FromContext(ctx).LogAttrs(ctx, level, "request completed",
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("duration", time.Since(start)),
+			)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// UserLogger adds the authenticated username to the request scoped logger.
+// It must be mounted after jwtauth.Verifier.
+func UserLogger(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+			if username, ok := claims["username"].(string); ok {
+				logger.With(r.Context(), slog.String("user", username))
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// Actor records the authenticated username as the actor of the domain events
+// raised while handling the request. It must be mounted after
+// jwtauth.Verifier.
+func Actor(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+			if username, ok := claims["username"].(string); ok {
+				r = r.WithContext(event.ContextWithActor(r.Context(), username))
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// RequireRole lets through the requests of the users with role only, the
+// others fail with a forbidden error. It must be mounted after
+// jwtauth.Verifier.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+				if claimed, ok := claims["role"].(string); ok && claimed == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			responseErr(w, r, portError.NewForbiddenError("", nil))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// Language negotiates the language of the localized content. The comma
+// separated lang query parameter takes precedence over the Accept-Language
+// header, an invalid one fails the request while an invalid header is
+// ignored.
+func Language(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		var tags []string
+		if lang := r.URL.Query().Get("lang"); lang != "" {
+			for _, s := range strings.Split(lang, ",") {
+				tag, err := locale.Normalize(strings.TrimSpace(s))
+				if err != nil {
+					w.Header().Set("Content-Type", "application/json")
+					responseErr(w, r, portError.NewBadRequestError("lang: invalid language "+strconv.Quote(s), err))
+					return
+				}
+				tags = append(tags, tag)
+			}
+		} else {
+			tags = locale.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		}
+
+		if len(tags) > 0 {
+			r = r.WithContext(locale.NewContext(r.Context(), locale.Chain(tags)))
+		}
+
+		next.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// RateLimit limits the requests of a route group with a token bucket per
+// client. Authenticated clients are keyed on their username and limited by
+// their role, other clients are keyed on their IP address as resolved by
+// middleware.RealIP. The store failing lets the request through.
+func RateLimit(store ratelimit.Store, group string, policy ratelimit.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key, role := rateLimitKey(r)
+			limit := policy.For(role)
+			if limit.Unlimited() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			res, err := store.Take(r.Context(), group+":"+key, limit)
+			if err != nil {
+				logger.FromContext(r.Context()).Warn("rate limit store failed", slog.Any("error", err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(int(res.Reset.Seconds())))
+			if !res.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(res.RetryAfter.Seconds())))
+				w.Header().Set("Content-Type", "application/json")
+				responseErr(w, r, portError.NewTooManyRequestsError("", nil))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func rateLimitKey(r *http.Request) (string, string) {
+	if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+		if username, ok := claims["username"].(string); ok && username != "" {
+			role, _ := claims["role"].(string)
+			if role == "" {
+				role = entity.RoleUser
+			}
+			return "user:" + username, role
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host, ratelimit.RoleAnonymous
+}
+
+// SecureHeaders sets the security headers suited to a JSON API. The
+// Strict-Transport-Security header is only sent when hstsMaxAge is positive.
+func SecureHeaders(hstsMaxAge int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			if hstsMaxAge > 0 {
+				h.Set("Strict-Transport-Security", "max-age="+strconv.Itoa(hstsMaxAge)+"; includeSubDomains")
+			}
+			h.Set("X-Content-Type-Options", "nosniff")
+			h.Set("X-Frame-Options", "DENY")
+			h.Set("Content-Security-Policy", "default-src 'none'; frame-ancestors 'none'")
+			h.Set("Referrer-Policy", "no-referrer")
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+type originalBodyKey struct{}
+
+// tooLargeBody fails every read of a body whose Content-Length is over the
+// limit, without reading it.
+type tooLargeBody struct {
+	io.ReadCloser
+	limit int64
+}
+
+func (b tooLargeBody) Read(p []byte) (int, error) {
+	return 0, &http.MaxBytesError{Limit: b.limit}
+}
+
+// MaxBodySize limits request bodies to n bytes. Reading a larger body fails
+// with an *http.MaxBytesError, which decodeBody turns into a request entity
+// too large error. A body announced larger by its Content-Length fails on the
+// first read. A MaxBodySize on a route replaces the one of the router, so a
+// route can accept larger bodies than the default.
+func MaxBodySize(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if body, ok := r.Context().Value(originalBodyKey{}).(io.ReadCloser); ok {
+				r.Body = body
+			} else {
+				r = r.WithContext(context.WithValue(r.Context(), originalBodyKey{}, r.Body))
+			}
+
+			switch {
+			case n <= 0:
+			case r.ContentLength > n:
+				r.Body = tooLargeBody{ReadCloser: r.Body, limit: n}
+			default:
+				r.Body = http.MaxBytesReader(w, r.Body, n)
+			}
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// RedirectHTTPS redirects every request to the same URL over HTTPS on
+// httpsPort (e.g. ":8443").
+func RedirectHTTPS(httpsPort string) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+
+		if _, port, err := net.SplitHostPort(httpsPort); err == nil && port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// matchRoute returns the pattern of the route r will be routed to, or an
+// empty string when there is none. Unlike routePattern it does not wait for
+// the routing to resolve it, so middlewares mounted before the subrouters
+// know the full pattern.
+func matchRoute(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return ""
+	}
+
+	path := r.URL.RawPath
+	if path == "" {
+		path = r.URL.Path
+	}
+
+	match := chi.NewRouteContext()
+	if !rctx.Routes.Match(match, r.Method, path) {
+		return ""
+	}
+
+	return match.RoutePattern()
+}
+
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return ""
+	}
+
+	return rctx.RoutePattern()
+}
@@ -0,0 +1,329 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/port/payload"
+	"bookstore.com/tools/locale"
+	"bookstore.com/tools/logger"
+	"bookstore.com/tools/ratelimit"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/jwtauth"
+)
+
+func TestRequestLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := logger.New(buf, "info", "json")
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(RequestLogger(l))
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Route("/books", func(r chi.Router) {
+			r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+				responseErr(w, r, errors.New("error occur"))
+			})
+		})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/books/1", nil))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+
+	for _, raw := range lines {
+		line := map[string]any{}
+		if err := json.Unmarshal(raw, &line); err != nil {
+			t.Fatalf("Invalid json log line %s", raw)
+		}
+		if line["requestId"] == "" || line["requestId"] == nil {
+			t.Errorf("Expected requestId in log line %s", raw)
+		}
+		if line["level"] != "ERROR" {
+			t.Errorf("Expected level ERROR, got %v", line["level"])
+		}
+		if line["route"] != "/api/v1/books/{id}" {
+			t.Errorf("Expected route /api/v1/books/{id} in log line %s", raw)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	policy := ratelimit.Policy{
+		Default: ratelimit.Limit{Requests: 1, Period: time.Minute, Burst: 1},
+	}
+	h := RateLimit(ratelimit.NewMemoryStore(), "books", policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		remoteAddr     string
+		expectedStatus int
+		expectedRemain string
+	}{
+		{name: "first request allowed", remoteAddr: "10.0.0.1:1234", expectedStatus: http.StatusOK, expectedRemain: "0"},
+		{name: "second request limited", remoteAddr: "10.0.0.1:4321", expectedStatus: http.StatusTooManyRequests, expectedRemain: "0"},
+		{name: "other client allowed", remoteAddr: "10.0.0.2:1234", expectedStatus: http.StatusOK, expectedRemain: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/v1/books", nil)
+			r.RemoteAddr = tt.remoteAddr
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if w.Header().Get("RateLimit-Remaining") != tt.expectedRemain {
+				t.Errorf("Expected RateLimit-Remaining %s, got %s", tt.expectedRemain, w.Header().Get("RateLimit-Remaining"))
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+				t.Errorf("Expected Retry-After header")
+			}
+		})
+	}
+}
+
+func TestSecureHeaders(t *testing.T) {
+	h := SecureHeaders(3600)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/books", nil))
+
+	expected := map[string]string{
+		"Strict-Transport-Security": "max-age=3600; includeSubDomains",
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+	}
+	for header, value := range expected {
+		if got := w.Header().Get(header); got != value {
+			t.Errorf("Expected %s header %q, got %q", header, value, got)
+		}
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	token := func(claims map[string]interface{}) string {
+		_, s, _ := tokenAuth.Encode(claims)
+		return s
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{
+			name:       "admin",
+			token:      token(map[string]interface{}{"username": "alice", "role": entity.RoleAdmin}),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "user",
+			token:      token(map[string]interface{}{"username": "bob", "role": entity.RoleUser}),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no role",
+			token:      token(map[string]interface{}{"username": "carol"}),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no token",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(RequireRole(entity.RoleAdmin))
+			r.Get("/api/v1/webhooks", func(w http.ResponseWriter, r *http.Request) {})
+
+			req := httptest.NewRequest("GET", "/api/v1/webhooks", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		header     string
+		wantChain  []string
+		wantStatus int
+	}{
+		{
+			name:       "accept language header",
+			target:     "/api/v1/books",
+			header:     "fr-CA, en;q=0.5",
+			wantChain:  []string{"fr-CA", "fr", "en"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "lang parameter over the header",
+			target:     "/api/v1/books?lang=pt_br,es",
+			header:     "fr",
+			wantChain:  []string{"pt-BR", "pt", "es"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid header ignored",
+			target:     "/api/v1/books",
+			header:     "!!!",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid lang parameter",
+			target:     "/api/v1/books?lang=klingon",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chain []string
+			h := Language(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				chain = locale.FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if !reflect.DeepEqual(chain, tt.wantChain) {
+				t.Errorf("Expected chain %v, got %v", tt.wantChain, chain)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept-Language" {
+				t.Errorf("Expected Vary header %q, got %q", "Accept-Language", got)
+			}
+		})
+	}
+}
+
+func TestMaxBodySize(t *testing.T) {
+	h := MaxBodySize(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		book := &payload.BookRequest{}
+		if err := decodeBody(r, book); err != nil {
+			responseErr(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		body           string
+		unknownLength  bool
+		expectedStatus int
+	}{
+		{name: "small body", body: `{}`, expectedStatus: http.StatusOK},
+		{name: "large body", body: `{"name":"book name 1"}`, expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "large body without length", body: `{"name":"book name 1"}`, unknownLength: true, expectedStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/v1/books", bytes.NewReader([]byte(tt.body)))
+			if tt.unknownLength {
+				r.ContentLength = -1
+			}
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestMaxBodySize_override(t *testing.T) {
+	inner := MaxBodySize(32)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		book := &payload.BookRequest{}
+		if err := decodeBody(r, book); err != nil {
+			responseErr(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	h := MaxBodySize(8)(inner)
+
+	tests := []struct {
+		name           string
+		body           string
+		unknownLength  bool
+		expectedStatus int
+	}{
+		{name: "body within route limit", body: `{"name":"book name 1"}`, expectedStatus: http.StatusOK},
+		{name: "body within route limit without length", body: `{"name":"book name 1"}`, unknownLength: true, expectedStatus: http.StatusOK},
+		{name: "body over route limit", body: `{"name":"book name 1","description":"description"}`, expectedStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/v1/books", bytes.NewReader([]byte(tt.body)))
+			if tt.unknownLength {
+				r.ContentLength = -1
+			}
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestRedirectHTTPS(t *testing.T) {
+	tests := []struct {
+		name      string
+		httpsPort string
+		target    string
+		expected  string
+	}{
+		{name: "custom port", httpsPort: ":8443", target: "http://example.com:8080/api/v1/books?id=1", expected: "https://example.com:8443/api/v1/books?id=1"},
+		{name: "default port", httpsPort: ":443", target: "http://example.com/login", expected: "https://example.com/login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			RedirectHTTPS(tt.httpsPort).ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+
+			if w.Code != http.StatusPermanentRedirect {
+				t.Errorf("Expected status code %d, got %d", http.StatusPermanentRedirect, w.Code)
+			}
+			if got := w.Header().Get("Location"); got != tt.expected {
+				t.Errorf("Expected location %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"bookstore.com/domain/service"
+	"github.com/go-chi/chi"
+)
+
+// photoField is the multipart form field holding the uploaded photo.
+const photoField = "photo"
+
+type photoHandler struct {
+	photoService service.PhotoService
+}
+
+func NewPhotoHandler(photoService service.PhotoService) PhotoHandler {
+	return &photoHandler{
+		photoService: photoService,
+	}
+}
+
+func (h *photoHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	file, err := h.photoService.Find(r.Context(), id, r.URL.Query().Get("size"))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		responseErr(w, r, err)
+		return
+	}
+
+	serveImage(w, r, file)
+}
+
+// Post uploads the photo of the author from the photo field of a multipart form.
+func (h *photoHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	req, err := readImage(r, photoField)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	res, err := h.photoService.Upload(r.Context(), id, req)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, res)
+}
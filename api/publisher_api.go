@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+
+	"bookstore.com/domain/service"
+	"bookstore.com/port/payload"
+	"github.com/go-chi/chi"
+)
+
+type publisherHandler struct {
+	publisherService service.PublisherService
+}
+
+func NewPublisherHandler(publisherService service.PublisherService) PublisherHandler {
+	return &publisherHandler{
+		publisherService: publisherService,
+	}
+}
+
+func (h *publisherHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+	publisher, err := h.publisherService.Find(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, publisher)
+}
+
+func (h *publisherHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	publisher := &payload.PublisherRequest{}
+	if err := decodeBody(r, publisher); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	err := h.publisherService.Store(r.Context(), publisher)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, publisher)
+}
+
+func (h *publisherHandler) Put(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id := chi.URLParam(r, "id")
+
+	publisher := &payload.PublisherRequest{}
+	if err := decodeBody(r, publisher); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	err := h.publisherService.Update(r.Context(), id, publisher)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, publisher)
+}
+
+func (h *publisherHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	err := h.publisherService.Delete(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, payload.MessageResponse{
+		Message: "Deleted publisher successfully!",
+	})
+}
+func (h *publisherHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	publishers, err := h.publisherService.FindAll(r.Context())
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, publishers)
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+
+	"bookstore.com/domain/service"
+	"bookstore.com/port/payload"
+	"github.com/go-chi/chi"
+)
+
+type seriesHandler struct {
+	seriesService service.SeriesService
+}
+
+func NewSeriesHandler(seriesService service.SeriesService) SeriesHandler {
+	return &seriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+func (h *seriesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+	series, err := h.seriesService.Find(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, series)
+}
+
+func (h *seriesHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	series := &payload.SeriesRequest{}
+	if err := decodeBody(r, series); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	err := h.seriesService.Store(r.Context(), series)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, series)
+}
+
+func (h *seriesHandler) Put(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id := chi.URLParam(r, "id")
+
+	series := &payload.SeriesRequest{}
+	if err := decodeBody(r, series); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	err := h.seriesService.Update(r.Context(), id, series)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, series)
+}
+
+func (h *seriesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	err := h.seriesService.Delete(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, payload.MessageResponse{
+		Message: "Deleted series successfully!",
+	})
+}
+func (h *seriesHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	list, err := h.seriesService.FindAll(r.Context())
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, list)
+}
+
+func (h *seriesHandler) GetBooks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	books, err := h.seriesService.FindBooks(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, books)
+}
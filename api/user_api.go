@@ -1,7 +1,6 @@
 package api
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"bookstore.com/domain/service"
@@ -22,15 +21,14 @@ func (h *userHandler) Register(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	user := &payload.RegisterRequest{}
-	err := json.NewDecoder(r.Body).Decode(&user)
-	if err != nil {
-		responseErr(w, err)
+	if err := decodeBody(r, user); err != nil {
+		responseErr(w, r, err)
 		return
 	}
 
-	err = h.userService.Register(r.Context(), user)
+	err := h.userService.Register(r.Context(), user)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 	response(w, http.StatusOK)
@@ -40,15 +38,14 @@ func (h *userHandler) Login(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	user := &payload.LoginRequest{}
-	err := json.NewDecoder(r.Body).Decode(&user)
-	if err != nil {
-		responseErr(w, err)
+	if err := decodeBody(r, user); err != nil {
+		responseErr(w, r, err)
 		return
 	}
 
 	token, err := h.userService.Login(r.Context(), user)
 	if err != nil {
-		responseErr(w, err)
+		responseErr(w, r, err)
 		return
 	}
 
@@ -4,16 +4,21 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 
 	portError "bookstore.com/port/error"
 	"bookstore.com/port/payload"
+	"bookstore.com/tools/logger"
 )
 
 func decodeBody(r *http.Request, v interface{}) error {
 	err := json.NewDecoder(r.Body).Decode(&v)
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return portError.NewRequestEntityTooLargeError("", err)
+		}
 		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
 			return portError.NewBadRequestError(err.Error(), nil)
 		}
@@ -23,17 +28,29 @@ func decodeBody(r *http.Request, v interface{}) error {
 	return nil
 }
 
-func responseErr(w http.ResponseWriter, err error) {
+func responseErr(w http.ResponseWriter, r *http.Request, err error) {
+	l := logger.FromContext(r.Context())
 	apiErr, ok := err.(*portError.ApiError)
 	if ok {
-		log.Println(err)
+		level := slog.LevelWarn
+		if apiErr.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		l.LogAttrs(r.Context(), level, "request failed",
+			slog.Int("status", apiErr.Status),
+			slog.String("error", apiErr.Message),
+			slog.Any("cause", apiErr.Cause),
+		)
 		responseJSON(w, apiErr.Status, &payload.MessageResponse{
 			Message: apiErr.Message,
 		})
 		return
 	}
 
-	log.Println(err)
+	l.LogAttrs(r.Context(), slog.LevelError, "request failed",
+		slog.Int("status", http.StatusInternalServerError),
+		slog.String("error", err.Error()),
+	)
 	responseJSON(w, http.StatusInternalServerError, &payload.MessageResponse{
 		Message: "Something went wrong, please try again.",
 	})
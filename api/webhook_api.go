@@ -0,0 +1,129 @@
+package api
+
+import (
+	"net/http"
+
+	"bookstore.com/domain/service"
+	"bookstore.com/port/payload"
+	"github.com/go-chi/chi"
+)
+
+type webhookHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) WebhookHandler {
+	return &webhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h *webhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+	webhook, err := h.webhookService.Find(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, webhook)
+}
+
+func (h *webhookHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	req := &payload.WebhookRequest{}
+	if err := decodeBody(r, req); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	webhook, err := h.webhookService.Store(r.Context(), req)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, webhook)
+}
+
+func (h *webhookHandler) Put(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id := chi.URLParam(r, "id")
+
+	req := &payload.WebhookRequest{}
+	if err := decodeBody(r, req); err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	err := h.webhookService.Update(r.Context(), id, req)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	webhook, err := h.webhookService.Find(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, webhook)
+}
+
+func (h *webhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	err := h.webhookService.Delete(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, payload.MessageResponse{
+		Message: "Deleted webhook successfully!",
+	})
+}
+
+func (h *webhookHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	webhooks, err := h.webhookService.FindAll(r.Context())
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, webhooks)
+}
+
+func (h *webhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+
+	deliveries, err := h.webhookService.FindDeliveries(r.Context(), id)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, deliveries)
+}
+
+func (h *webhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id := chi.URLParam(r, "id")
+	deliveryId := chi.URLParam(r, "deliveryId")
+
+	delivery, err := h.webhookService.Redeliver(r.Context(), id, deliveryId)
+	if err != nil {
+		responseErr(w, r, err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, delivery)
+}
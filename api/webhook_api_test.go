@@ -0,0 +1,266 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bookstore.com/domain/service"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"github.com/go-chi/chi"
+	"go.uber.org/mock/gomock"
+)
+
+const webhookId1 = "64fbf00fc3a88d3a02b96901"
+
+// withURLParams returns r as routed by chi with the key/value pairs of params.
+func withURLParams(r *http.Request, params ...string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func Test_webhookHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	webhook := &payload.WebhookResponse{Id: webhookId1, URL: "https://hooks.example.com", EventTypes: []string{"book.created"}}
+	expectedJson, _ := json.Marshal(webhook)
+
+	tests := []struct {
+		name           string
+		webhookService func() service.WebhookService
+		expected       string
+		expectedStatus int
+	}{
+		{
+			name: "success to get webhook",
+			webhookService: func() service.WebhookService {
+				webhookService := service.NewMockWebhookService(ctrl)
+				webhookService.EXPECT().Find(gomock.Any(), webhookId1).Return(webhook, nil)
+
+				return webhookService
+			},
+			expected:       string(expectedJson),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "webhook not found",
+			webhookService: func() service.WebhookService {
+				webhookService := service.NewMockWebhookService(ctrl)
+				webhookService.EXPECT().Find(gomock.Any(), webhookId1).Return(nil, portError.NewNotFoundError("Webhook not found.", nil))
+
+				return webhookService
+			},
+			expected:       `{"message":"Webhook not found."}`,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h := NewWebhookHandler(tt.webhookService())
+			h.Get(w, withURLParams(httptest.NewRequest("GET", "/api/v1/webhooks/"+webhookId1, nil), "id", webhookId1))
+
+			if w.Body.String() != tt.expected {
+				t.Errorf("Expected json response %s, got %s", tt.expected, w.Body.String())
+			}
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_webhookHandler_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	webhooks := []*payload.WebhookResponse{{Id: webhookId1, URL: "https://hooks.example.com", EventTypes: []string{"book.created"}}}
+	expectedJson, _ := json.Marshal(webhooks)
+
+	webhookService := service.NewMockWebhookService(ctrl)
+	webhookService.EXPECT().FindAll(gomock.Any()).Return(webhooks, nil)
+
+	w := httptest.NewRecorder()
+	NewWebhookHandler(webhookService).GetAll(w, httptest.NewRequest("GET", "/api/v1/webhooks", nil))
+
+	if w.Body.String() != string(expectedJson) {
+		t.Errorf("Expected json response %s, got %s", expectedJson, w.Body.String())
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func Test_webhookHandler_Post(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	req := &payload.WebhookRequest{URL: "https://hooks.example.com", EventTypes: []string{"book.created"}, Secret: "0123456789abcdef"}
+	body, _ := json.Marshal(req)
+	webhook := &payload.WebhookResponse{Id: webhookId1, URL: req.URL, EventTypes: req.EventTypes}
+	expectedJson, _ := json.Marshal(webhook)
+
+	tests := []struct {
+		name           string
+		webhookService func() service.WebhookService
+		body           string
+		expected       string
+		expectedStatus int
+	}{
+		{
+			name: "success to store webhook",
+			webhookService: func() service.WebhookService {
+				webhookService := service.NewMockWebhookService(ctrl)
+				webhookService.EXPECT().Store(gomock.Any(), req).Return(webhook, nil)
+
+				return webhookService
+			},
+			body:           string(body),
+			expected:       string(expectedJson),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "failed to store webhook with an internal url",
+			webhookService: func() service.WebhookService {
+				webhookService := service.NewMockWebhookService(ctrl)
+				webhookService.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil, portError.NewBadRequestError("url: host not allowed, want a public address", nil))
+
+				return webhookService
+			},
+			body:           `{"url":"http://127.0.0.1","eventTypes":["book.created"],"secret":"0123456789abcdef"}`,
+			expected:       `{"message":"url: host not allowed, want a public address"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "failed to store webhook with an invalid body",
+			webhookService: func() service.WebhookService {
+				return service.NewMockWebhookService(ctrl)
+			},
+			body:           `{"url":`,
+			expected:       `{"message":"unexpected EOF"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h := NewWebhookHandler(tt.webhookService())
+			h.Post(w, httptest.NewRequest("POST", "/api/v1/webhooks", strings.NewReader(tt.body)))
+
+			if w.Body.String() != tt.expected {
+				t.Errorf("Expected json response %s, got %s", tt.expected, w.Body.String())
+			}
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_webhookHandler_Put(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	req := &payload.WebhookRequest{URL: "https://hooks.example.com", EventTypes: []string{"book.created"}, Secret: "0123456789abcdef"}
+	body, _ := json.Marshal(req)
+	webhook := &payload.WebhookResponse{Id: webhookId1, URL: req.URL, EventTypes: req.EventTypes}
+	expectedJson, _ := json.Marshal(webhook)
+
+	tests := []struct {
+		name           string
+		webhookService func() service.WebhookService
+		expected       string
+		expectedStatus int
+	}{
+		{
+			name: "success to update webhook",
+			webhookService: func() service.WebhookService {
+				webhookService := service.NewMockWebhookService(ctrl)
+				webhookService.EXPECT().Update(gomock.Any(), webhookId1, req).Return(nil)
+				webhookService.EXPECT().Find(gomock.Any(), webhookId1).Return(webhook, nil)
+
+				return webhookService
+			},
+			expected:       string(expectedJson),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "webhook not found",
+			webhookService: func() service.WebhookService {
+				webhookService := service.NewMockWebhookService(ctrl)
+				webhookService.EXPECT().Update(gomock.Any(), webhookId1, req).Return(portError.NewNotFoundError("Webhook not found.", nil))
+
+				return webhookService
+			},
+			expected:       `{"message":"Webhook not found."}`,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h := NewWebhookHandler(tt.webhookService())
+			h.Put(w, withURLParams(httptest.NewRequest("PUT", "/api/v1/webhooks/"+webhookId1, strings.NewReader(string(body))), "id", webhookId1))
+
+			if w.Body.String() != tt.expected {
+				t.Errorf("Expected json response %s, got %s", tt.expected, w.Body.String())
+			}
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_webhookHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name           string
+		webhookService func() service.WebhookService
+		expected       string
+		expectedStatus int
+	}{
+		{
+			name: "success to delete webhook",
+			webhookService: func() service.WebhookService {
+				webhookService := service.NewMockWebhookService(ctrl)
+				webhookService.EXPECT().Delete(gomock.Any(), webhookId1).Return(nil)
+
+				return webhookService
+			},
+			expected:       `{"message":"Deleted webhook successfully!"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "failed to delete webhook",
+			webhookService: func() service.WebhookService {
+				webhookService := service.NewMockWebhookService(ctrl)
+				webhookService.EXPECT().Delete(gomock.Any(), webhookId1).Return(errors.New("error occur"))
+
+				return webhookService
+			},
+			expected:       `{"message":"Something went wrong, please try again."}`,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h := NewWebhookHandler(tt.webhookService())
+			h.Delete(w, withURLParams(httptest.NewRequest("DELETE", "/api/v1/webhooks/"+webhookId1, nil), "id", webhookId1))
+
+			if w.Body.String() != tt.expected {
+				t.Errorf("Expected json response %s, got %s", tt.expected, w.Body.String())
+			}
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
@@ -7,20 +7,121 @@ import (
 )
 
 type Database struct {
+	Driver  string `yaml:"driver"`
 	URL     string `yaml:"url"`
 	Name    string `yaml:"name"`
 	Timeout int    `yaml:"timeout"`
+	Migrate bool   `yaml:"migrate"`
+}
+
+type CORS struct {
+	AllowedOrigins   []string `yaml:"allowedOrigins"`
+	AllowedMethods   []string `yaml:"allowedMethods"`
+	AllowedHeaders   []string `yaml:"allowedHeaders"`
+	ExposedHeaders   []string `yaml:"exposedHeaders"`
+	AllowCredentials bool     `yaml:"allowCredentials"`
+	MaxAge           int      `yaml:"maxAge"`
+}
+
+type TLS struct {
+	Enabled        bool   `yaml:"enabled"`
+	CertFile       string `yaml:"certFile"`
+	KeyFile        string `yaml:"keyFile"`
+	ClientCAFile   string `yaml:"clientCAFile"`
+	ClientAuth     string `yaml:"clientAuth"`
+	ReloadInterval int    `yaml:"reloadInterval"`
+	RedirectPort   string `yaml:"redirectPort"`
 }
 
 type Server struct {
-	Debug bool   `yaml:"debug"`
-	Port  string `yaml:"port"`
-	Host  string `yaml:"host"`
+	Debug       bool   `yaml:"debug"`
+	Port        string `yaml:"port"`
+	Host        string `yaml:"host"`
+	CORS        CORS   `yaml:"cors"`
+	HSTSMaxAge  int    `yaml:"hstsMaxAge"`
+	MaxBodySize int64  `yaml:"maxBodySize"`
+	TLS         TLS    `yaml:"tls"`
+}
+
+type Log struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+type Tracing struct {
+	Enabled     bool    `yaml:"enabled"`
+	Exporter    string  `yaml:"exporter"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	ServiceName string  `yaml:"serviceName"`
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+type RateLimitRule struct {
+	Requests int `yaml:"requests"`
+	Period   int `yaml:"period"`
+	Burst    int `yaml:"burst"`
+}
+
+type RateLimitGroup struct {
+	Default RateLimitRule            `yaml:"default"`
+	Roles   map[string]RateLimitRule `yaml:"roles"`
+}
+
+type RateLimit struct {
+	Enabled bool                      `yaml:"enabled"`
+	Backend string                    `yaml:"backend"`
+	Groups  map[string]RateLimitGroup `yaml:"groups"`
+}
+
+type Firebase struct {
+	CredentialFile string `yaml:"credentialFile"`
+	DatabaseURL    string `yaml:"databaseURL"`
+	Path           string `yaml:"path"`
+}
+
+type Relay struct {
+	PollInterval int `yaml:"pollInterval"`
+	Lease        int `yaml:"lease"`
+	MaxAttempts  int `yaml:"maxAttempts"`
+	Backoff      int `yaml:"backoff"`
+	MaxBackoff   int `yaml:"maxBackoff"`
+}
+
+type Webhooks struct {
+	Timeout int `yaml:"timeout"`
+	Relay   `yaml:",inline"`
+}
+
+type Stream struct {
+	ReplaySize int `yaml:"replaySize"`
+	Buffer     int `yaml:"buffer"`
+	Heartbeat  int `yaml:"heartbeat"`
+}
+
+type Events struct {
+	Publisher string   `yaml:"publisher"`
+	File      string   `yaml:"file"`
+	Firebase  Firebase `yaml:"firebase"`
+	Outbox    Relay    `yaml:"outbox"`
+	Webhooks  Webhooks `yaml:"webhooks"`
+	Stream    Stream   `yaml:"stream"`
+}
+
+type Covers struct {
+	Store   string `yaml:"store"`
+	Dir     string `yaml:"dir"`
+	MaxSize int64  `yaml:"maxSize"`
 }
 
 type Config struct {
-	DB     Database `yaml:"database"`
-	Server Server   `yaml:"server"`
+	DB        Database  `yaml:"database"`
+	Server    Server    `yaml:"server"`
+	Log       Log       `yaml:"log"`
+	Tracing   Tracing   `yaml:"tracing"`
+	RateLimit RateLimit `yaml:"rateLimit"`
+	Events    Events    `yaml:"events"`
+	Covers    Covers    `yaml:"covers"`
 }
 
 func NewConfig(configFile string) (*Config, error) {
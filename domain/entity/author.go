@@ -4,12 +4,31 @@ import (
 	"time"
 )
 
+// Author is a person writing or contributing to books. Biography is Markdown
+// source, rendered by the clients. Aliases are the pen names the author is
+// also found by, ISNI and VIAF their identifiers in these registries.
+// PhotoId identifies the current photo upload, empty for an author without
+// photo. Translations are the names of the author in other languages, keyed
+// by BCP 47 tag.
 type Author struct {
-	Id          string    `json:"id" bson:"_id"`
-	FirstName   string    `json:"firstName" bson:"firstName"`
-	LastName    string    `json:"lastName" bson:"lastName"`
-	BirthDate   string    `json:"birthDate" bson:"birthDate"`
-	Nationality string    `json:"nationality" bson:"nationality"`
-	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+	Id           string                        `json:"id" bson:"_id"`
+	FirstName    string                        `json:"firstName" bson:"firstName"`
+	LastName     string                        `json:"lastName" bson:"lastName"`
+	BirthDate    string                        `json:"birthDate" bson:"birthDate"`
+	DeathDate    string                        `json:"deathDate" bson:"deathDate"`
+	Nationality  string                        `json:"nationality" bson:"nationality"`
+	Biography    string                        `json:"biography" bson:"biography"`
+	Aliases      []string                      `json:"aliases" bson:"aliases"`
+	ISNI         string                        `json:"isni" bson:"isni"`
+	VIAF         string                        `json:"viaf" bson:"viaf"`
+	PhotoId      string                        `json:"photoId" bson:"photoId"`
+	Translations map[string]*AuthorTranslation `json:"translations" bson:"translations"`
+	CreatedAt    time.Time                     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time                     `json:"updatedAt" bson:"updatedAt"`
+}
+
+// AuthorTranslation is the name of an author in one language.
+type AuthorTranslation struct {
+	FirstName string `json:"firstName" bson:"firstName"`
+	LastName  string `json:"lastName" bson:"lastName"`
 }
@@ -0,0 +1,13 @@
+package entity
+
+import (
+	"time"
+)
+
+// Blob is a binary object, such as a cover image, stored under a key.
+type Blob struct {
+	Key         string
+	ContentType string
+	Data        []byte
+	UpdatedAt   time.Time
+}
@@ -2,14 +2,120 @@ package entity
 
 import "time"
 
+// Contributor roles.
+const (
+	ContributorAuthor      = "author"
+	ContributorEditor      = "editor"
+	ContributorTranslator  = "translator"
+	ContributorIllustrator = "illustrator"
+)
+
+var ContributorRoles = []string{ContributorAuthor, ContributorEditor, ContributorTranslator, ContributorIllustrator}
+
+// Edition formats.
+const (
+	FormatHardcover = "hardcover"
+	FormatPaperback = "paperback"
+	FormatEbook     = "ebook"
+	FormatAudiobook = "audiobook"
+)
+
+var Formats = []string{FormatHardcover, FormatPaperback, FormatEbook, FormatAudiobook}
+
+// Book is written by an ordered list of contributors. AuthorId and Author
+// are the first contributor, kept for the clients of the single author API.
+// PublisherId is optional; Publisher is filled in when the book is read.
+// A book of a series has its SeriesId and its SeriesPosition in it, unique
+// within the series. CoverId identifies the current cover upload, empty for
+// a book without cover.
+//
+// Language is the BCP 47 tag of the language the book is written in, and
+// Translations the name and description in other languages, keyed by tag. A
+// translated book has the OriginalId of the book it is a translation of.
+//
+// Tags are free-form labels set by the merchandising team, normalized to
+// lower case and sorted.
+//
+// A book is a work: its editions are the versions of it that can be bought.
 type Book struct {
-	Id              string    `json:"id" bson:"_id"`
-	AuthorId        string    `json:"authorId" bson:"authorId"`
-	Author          *Author   `json:"author" bson:"author"`
-	Name            string    `json:"name" bson:"name"`
-	Description     string    `json:"description" bson:"description"`
-	PublicationDate string    `json:"publicationDate" bson:"publicationDate"`
-	Price           float64   `json:"price" bson:"price"`
-	CreatedAt       time.Time `json:"createdAt" bson:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt" bson:"updatedAt"`
+	Id              string                      `json:"id" bson:"_id"`
+	AuthorId        string                      `json:"authorId" bson:"authorId"`
+	Author          *Author                     `json:"author" bson:"author"`
+	Contributors    []*Contributor              `json:"contributors" bson:"contributors"`
+	CategoryIds     []string                    `json:"categoryIds" bson:"categoryIds"`
+	Tags            []string                    `json:"tags" bson:"tags"`
+	PublisherId     string                      `json:"publisherId" bson:"publisherId"`
+	Publisher       *Publisher                  `json:"publisher" bson:"publisher"`
+	Editions        []*Edition                  `json:"editions" bson:"editions"`
+	SeriesId        string                      `json:"seriesId" bson:"seriesId"`
+	SeriesPosition  int                         `json:"seriesPosition" bson:"seriesPosition"`
+	CoverId         string                      `json:"coverId" bson:"coverId"`
+	Language        string                      `json:"language" bson:"language"`
+	OriginalId      string                      `json:"originalId" bson:"originalId"`
+	Translations    map[string]*BookTranslation `json:"translations" bson:"translations"`
+	Name            string                      `json:"name" bson:"name"`
+	Description     string                      `json:"description" bson:"description"`
+	PublicationDate string                      `json:"publicationDate" bson:"publicationDate"`
+	Price           float64                     `json:"price" bson:"price"`
+	ISBN10          string                      `json:"isbn10" bson:"isbn10"`
+	ISBN13          string                      `json:"isbn13" bson:"isbn13"`
+	CreatedAt       time.Time                   `json:"createdAt" bson:"createdAt"`
+	UpdatedAt       time.Time                   `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Contributor is an author taking part in a book. Author is filled in when
+// the book is read.
+type Contributor struct {
+	AuthorId string  `json:"authorId" bson:"authorId"`
+	Role     string  `json:"role" bson:"role"`
+	Author   *Author `json:"author,omitempty" bson:"author,omitempty"`
+}
+
+// BookTranslation is the name and description of a book in one language.
+type BookTranslation struct {
+	Name        string `json:"name" bson:"name"`
+	Description string `json:"description" bson:"description"`
+}
+
+// Edition is a version of a book in one format. Printed books and ebooks have
+// a page count, audiobooks a duration in minutes.
+type Edition struct {
+	Id              string  `json:"id" bson:"_id"`
+	Format          string  `json:"format" bson:"format"`
+	ISBN10          string  `json:"isbn10" bson:"isbn10"`
+	ISBN13          string  `json:"isbn13" bson:"isbn13"`
+	PageCount       int     `json:"pageCount" bson:"pageCount"`
+	DurationMinutes int     `json:"durationMinutes" bson:"durationMinutes"`
+	Price           float64 `json:"price" bson:"price"`
+	PublicationDate string  `json:"publicationDate" bson:"publicationDate"`
+}
+
+// ContributorsOrAuthor returns the contributors of the book, or AuthorId as
+// its only author when it has none.
+func (b *Book) ContributorsOrAuthor() []*Contributor {
+	if len(b.Contributors) > 0 {
+		return b.Contributors
+	}
+
+	return []*Contributor{{AuthorId: b.AuthorId, Role: ContributorAuthor}}
+}
+
+func IsContributorRole(role string) bool {
+	for _, r := range ContributorRoles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+func IsFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// Category classifies books. Categories form a tree: ParentId is empty for a
+// top level category.
+type Category struct {
+	Id        string    `json:"id" bson:"_id"`
+	Name      string    `json:"name" bson:"name"`
+	ParentId  string    `json:"parentId" bson:"parentId"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
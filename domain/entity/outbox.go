@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"bookstore.com/domain/event"
+)
+
+const (
+	OutboxPending   = "pending"
+	OutboxDelivered = "delivered"
+	OutboxDead      = "dead"
+)
+
+// OutboxMessage is an event waiting in the outbox to be relayed to the
+// publisher.
+type OutboxMessage struct {
+	Id            string       `json:"id"`
+	Event         *event.Event `json:"event"`
+	Status        string       `json:"status"`
+	Attempts      int          `json:"attempts"`
+	NextAttemptAt time.Time    `json:"nextAttemptAt"`
+	LastError     string       `json:"lastError"`
+	CreatedAt     time.Time    `json:"createdAt"`
+	UpdatedAt     time.Time    `json:"updatedAt"`
+}
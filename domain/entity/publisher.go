@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"time"
+)
+
+type Publisher struct {
+	Id        string    `json:"id" bson:"_id"`
+	Name      string    `json:"name" bson:"name"`
+	Country   string    `json:"country" bson:"country"`
+	Website   string    `json:"website" bson:"website"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
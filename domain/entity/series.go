@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+)
+
+// Series is an ordered set of books. A book of the series has its position,
+// starting at 1, in Book.SeriesPosition.
+type Series struct {
+	Id          string    `json:"id" bson:"_id"`
+	Name        string    `json:"name" bson:"name"`
+	Description string    `json:"description" bson:"description"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
@@ -0,0 +1,7 @@
+package entity
+
+// TagCount is a tag with the number of books it labels.
+type TagCount struct {
+	Tag   string `json:"tag" bson:"_id"`
+	Count int    `json:"count" bson:"count"`
+}
@@ -6,17 +6,24 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	Id        string    `json:"id" bson:"_id"`
 	Username  string    `json:"username" bson:"username"`
 	Password  string    `json:"password" bson:"password"`
 	FirstName string    `json:"firstName" bson:"firstName"`
 	LastName  string    `json:"lastName" bson:"lastName"`
+	Role      string    `json:"role" bson:"role"`
 	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
 }
 
 type Claims struct {
 	Username string `json:"username"`
+	Role     string `json:"role"`
 	jwt.RegisteredClaims
 }
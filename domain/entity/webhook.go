@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead"
+)
+
+type Webhook struct {
+	Id         string    `json:"id" bson:"_id"`
+	URL        string    `json:"url" bson:"url"`
+	EventTypes []string  `json:"eventTypes" bson:"eventTypes"`
+	Secret     string    `json:"secret" bson:"secret"`
+	CreatedAt  time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// WebhookDelivery is an event to send, or sent, to a webhook.
+type WebhookDelivery struct {
+	Id            string             `json:"id" bson:"_id"`
+	WebhookId     string             `json:"webhookId" bson:"webhookId"`
+	EventId       string             `json:"eventId" bson:"eventId"`
+	EventType     string             `json:"eventType" bson:"eventType"`
+	Payload       string             `json:"payload" bson:"payload"`
+	RedeliveryOf  string             `json:"redeliveryOf" bson:"redeliveryOf"`
+	Status        string             `json:"status" bson:"status"`
+	Attempts      []*DeliveryAttempt `json:"attempts" bson:"attempts"`
+	NextAttemptAt time.Time          `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+// DeliveryAttempt records one try to send a WebhookDelivery.
+type DeliveryAttempt struct {
+	At         time.Time `json:"at" bson:"at"`
+	StatusCode int       `json:"statusCode" bson:"statusCode"`
+	Error      string    `json:"error" bson:"error"`
+	Duration   int64     `json:"duration" bson:"duration"`
+}
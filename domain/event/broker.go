@@ -0,0 +1,143 @@
+package event
+
+import (
+	"context"
+	"sync"
+)
+
+// Filter selects the events a subscriber is interested in. Empty fields match
+// everything.
+type Filter struct {
+	Types     []Type
+	EntityIds []string
+}
+
+// Match reports whether e passes the filter.
+func (f Filter) Match(e *Event) bool {
+	return (len(f.Types) == 0 || contains(f.Types, e.Type)) &&
+		(len(f.EntityIds) == 0 || contains(f.EntityIds, e.EntityId))
+}
+
+func contains[T comparable](list []T, v T) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Broker is a Publisher fanning events out to live subscribers. The latest
+// events are kept in a bounded replay buffer so that a reconnecting client
+// can resume after the last event it received.
+type Broker struct {
+	mu         sync.Mutex
+	replay     []*Event
+	replaySize int
+	buffer     int
+	subs       map[*Subscription]struct{}
+}
+
+// NewBroker creates a Broker keeping the last replaySize events. Every
+// subscriber can lag buffer events behind before it is dropped.
+func NewBroker(replaySize, buffer int) *Broker {
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	return &Broker{
+		replaySize: replaySize,
+		buffer:     buffer,
+		subs:       map[*Subscription]struct{}{},
+	}
+}
+
+// Subscription receives the events matching its filter until it is closed.
+type Subscription struct {
+	broker *Broker
+	filter Filter
+	events chan *Event
+}
+
+// Events returns the channel of events. It is closed when the subscription is
+// closed, or when the subscriber lagged too far behind and was dropped.
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.remove(s)
+}
+
+// Publish stores e in the replay buffer and sends it to the matching
+// subscribers. It never blocks on a slow subscriber.
+func (b *Broker) Publish(ctx context.Context, e *Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.replaySize > 0 {
+		b.replay = append(b.replay, e)
+		if len(b.replay) > b.replaySize {
+			b.replay = b.replay[len(b.replay)-b.replaySize:]
+		}
+	}
+
+	for sub := range b.subs {
+		if !sub.filter.Match(e) {
+			continue
+		}
+
+		select {
+		case sub.events <- e:
+		default:
+			b.remove(sub)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers a subscriber for the events matching filter. When
+// lastEventId is set, the buffered events published after it are returned to
+// be sent first. If lastEventId is no longer buffered, every buffered event is
+// returned.
+func (b *Broker) Subscribe(filter Filter, lastEventId string) (*Subscription, []*Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []*Event
+	if lastEventId != "" {
+		start := 0
+		for i, e := range b.replay {
+			if e.Id == lastEventId {
+				start = i + 1
+				break
+			}
+		}
+
+		for _, e := range b.replay[start:] {
+			if filter.Match(e) {
+				missed = append(missed, e)
+			}
+		}
+	}
+
+	sub := &Subscription{broker: b, filter: filter, events: make(chan *Event, b.buffer)}
+	b.subs[sub] = struct{}{}
+
+	return sub, missed
+}
+
+func (b *Broker) remove(sub *Subscription) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+
+	delete(b.subs, sub)
+	close(sub.events)
+}
@@ -0,0 +1,109 @@
+package event
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func ids(events []*Event) []string {
+	list := []string{}
+	for _, e := range events {
+		list = append(list, e.Id)
+	}
+
+	return list
+}
+
+func TestBroker_Subscribe(t *testing.T) {
+	events := []*Event{
+		{Id: "1", Type: BookCreated, EntityId: "book-1"},
+		{Id: "2", Type: AuthorCreated, EntityId: "author-1"},
+		{Id: "3", Type: BookUpdated, EntityId: "book-1"},
+		{Id: "4", Type: BookCreated, EntityId: "book-2"},
+	}
+
+	tests := []struct {
+		name        string
+		filter      Filter
+		lastEventId string
+		wantMissed  []string
+		wantLive    []string
+	}{
+		{
+			name:       "no resume",
+			wantMissed: []string{},
+			wantLive:   []string{"1", "2", "3", "4"},
+		},
+		{
+			name:        "resume after last event",
+			lastEventId: "2",
+			wantMissed:  []string{"3", "4"},
+			wantLive:    []string{"1", "2", "3", "4"},
+		},
+		{
+			name:        "resume from evicted event",
+			lastEventId: "0",
+			wantMissed:  []string{"2", "3", "4"},
+			wantLive:    []string{"1", "2", "3", "4"},
+		},
+		{
+			name:        "filter by type",
+			filter:      Filter{Types: []Type{BookCreated}},
+			lastEventId: "0",
+			wantMissed:  []string{"4"},
+			wantLive:    []string{"1", "4"},
+		},
+		{
+			name:        "filter by entity",
+			filter:      Filter{EntityIds: []string{"book-1"}},
+			lastEventId: "1",
+			wantMissed:  []string{"3"},
+			wantLive:    []string{"1", "3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBroker(3, 10)
+			for _, e := range events {
+				b.Publish(context.Background(), e)
+			}
+
+			sub, missed := b.Subscribe(tt.filter, tt.lastEventId)
+			if got := ids(missed); !reflect.DeepEqual(got, tt.wantMissed) {
+				t.Errorf("missed = %v, want %v", got, tt.wantMissed)
+			}
+
+			for _, e := range events {
+				b.Publish(context.Background(), e)
+			}
+			sub.Close()
+
+			live := []*Event{}
+			for e := range sub.Events() {
+				live = append(live, e)
+			}
+			if got := ids(live); !reflect.DeepEqual(got, tt.wantLive) {
+				t.Errorf("live = %v, want %v", got, tt.wantLive)
+			}
+		})
+	}
+}
+
+func TestBroker_dropsSlowSubscriber(t *testing.T) {
+	b := NewBroker(10, 1)
+	sub, _ := b.Subscribe(Filter{}, "")
+
+	b.Publish(context.Background(), &Event{Id: "1"})
+	b.Publish(context.Background(), &Event{Id: "2"})
+
+	if e, ok := <-sub.Events(); !ok || e.Id != "1" {
+		t.Fatalf("first event = %v, %v", e, ok)
+	}
+	if _, ok := <-sub.Events(); ok {
+		t.Errorf("subscription still open after overflow")
+	}
+
+	sub.Close()
+}
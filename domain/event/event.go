@@ -0,0 +1,134 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Type string
+
+const (
+	BookCreated   Type = "book.created"
+	BookUpdated   Type = "book.updated"
+	BookDeleted   Type = "book.deleted"
+	AuthorCreated Type = "author.created"
+	AuthorUpdated Type = "author.updated"
+	AuthorDeleted Type = "author.deleted"
+
+	PublisherCreated Type = "publisher.created"
+	PublisherUpdated Type = "publisher.updated"
+	PublisherDeleted Type = "publisher.deleted"
+
+	SeriesCreated Type = "series.created"
+	SeriesUpdated Type = "series.updated"
+	SeriesDeleted Type = "series.deleted"
+
+	CategoryCreated Type = "category.created"
+	CategoryUpdated Type = "category.updated"
+	CategoryDeleted Type = "category.deleted"
+)
+
+// Types lists every event type.
+var Types = []Type{
+	BookCreated,
+	BookUpdated,
+	BookDeleted,
+	AuthorCreated,
+	AuthorUpdated,
+	AuthorDeleted,
+	PublisherCreated,
+	PublisherUpdated,
+	PublisherDeleted,
+	SeriesCreated,
+	SeriesUpdated,
+	SeriesDeleted,
+	CategoryCreated,
+	CategoryUpdated,
+	CategoryDeleted,
+}
+
+// Valid reports whether t is a known event type.
+func (t Type) Valid() bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Event is a change that happened in the catalog.
+type Event struct {
+	Id        string          `json:"id" bson:"_id"`
+	Type      Type            `json:"type" bson:"type"`
+	EntityId  string          `json:"entityId" bson:"entityId"`
+	Actor     string          `json:"actor" bson:"actor"`
+	Timestamp time.Time       `json:"timestamp" bson:"timestamp"`
+	Payload   json.RawMessage `json:"payload,omitempty" bson:"payload,omitempty"`
+}
+
+// Publisher delivers events to the interested parties.
+type Publisher interface {
+	Publish(ctx context.Context, e *Event) error
+}
+
+type multiPublisher []Publisher
+
+// NewMultiPublisher returns a Publisher delivering every event to each of
+// publishers, whatever the others return. It fails with the errors of the
+// publishers that failed, joined.
+func NewMultiPublisher(publishers ...Publisher) Publisher {
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(ctx context.Context, e *Event) error {
+	var errs []error
+	for _, p := range m {
+		if err := p.Publish(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// New creates an event of type t about the entity entityId, performed by the
+// actor carried by ctx. The payload is encoded as JSON.
+func New(ctx context.Context, t Type, entityId string, payload any) (*Event, error) {
+	e := &Event{
+		Id:        uuid.NewString(),
+		Type:      t,
+		EntityId:  entityId,
+		Actor:     ActorFromContext(ctx),
+		Timestamp: time.Now().UTC(),
+	}
+
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		e.Payload = raw
+	}
+
+	return e, nil
+}
+
+type actorCtxKey struct{}
+
+// ContextWithActor returns a copy of ctx carrying the name of the user
+// performing the request.
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx, or an empty string.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorCtxKey{}).(string)
+	return actor
+}
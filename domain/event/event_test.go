@@ -0,0 +1,40 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type publisherFunc func(ctx context.Context, e *Event) error
+
+func (f publisherFunc) Publish(ctx context.Context, e *Event) error {
+	return f(ctx, e)
+}
+
+func TestMultiPublisher_Publish(t *testing.T) {
+	errFirst := errors.New("first failed")
+	errLast := errors.New("last failed")
+	var delivered []string
+	sink := func(name string, err error) Publisher {
+		return publisherFunc(func(ctx context.Context, e *Event) error {
+			delivered = append(delivered, name)
+			return err
+		})
+	}
+
+	p := NewMultiPublisher(sink("first", errFirst), sink("second", nil), sink("last", errLast))
+	err := p.Publish(context.Background(), &Event{Id: "1", Type: BookCreated})
+
+	if len(delivered) != 3 {
+		t.Errorf("Publish() delivered to %v, want every publisher", delivered)
+	}
+	if !errors.Is(err, errFirst) || !errors.Is(err, errLast) {
+		t.Errorf("Publish() error = %v, want both failures", err)
+	}
+
+	delivered = nil
+	if err := NewMultiPublisher(sink("only", nil)).Publish(context.Background(), &Event{Id: "2"}); err != nil {
+		t.Errorf("Publish() error = %v, want none", err)
+	}
+}
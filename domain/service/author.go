@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
 	portError "bookstore.com/port/error"
 	"bookstore.com/port/payload"
 	"bookstore.com/repository"
@@ -11,12 +13,14 @@ import (
 )
 
 type authorService struct {
-	authorRepo       repository.AuthorRepository
-	notificationRepo repository.NotificationRepository
+	authorRepo repository.AuthorRepository
+	images     *imageStore
+	publisher  event.Publisher
+	tx         repository.Transactor
 }
 
-func NewAuthorService(authRepo repository.AuthorRepository, notificationRepo repository.NotificationRepository) AuthorService {
-	return &authorService{authorRepo: authRepo, notificationRepo: notificationRepo}
+func NewAuthorService(authRepo repository.AuthorRepository, blobs repository.BlobStore, publisher event.Publisher, tx repository.Transactor) AuthorService {
+	return &authorService{authorRepo: authRepo, images: &imageStore{blobs: blobs}, publisher: publisher, tx: tx}
 }
 
 func (s *authorService) Find(ctx context.Context, id string) (*payload.AuthorResponse, error) {
@@ -29,12 +33,7 @@ func (s *authorService) Find(ctx context.Context, id string) (*payload.AuthorRes
 		return nil, err
 	}
 
-	res := &payload.AuthorResponse{}
-	if err := mapper.MapStructsWithJSONTags(author, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return newAuthorResponse(ctx, author)
 }
 
 func (s *authorService) Store(ctx context.Context, req *payload.AuthorRequest) error {
@@ -46,12 +45,15 @@ func (s *authorService) Store(ctx context.Context, req *payload.AuthorRequest) e
 	if err := mapper.MapStructsWithJSONTags(req, author); err != nil {
 		return err
 	}
-	if s.notificationRepo != nil {
-		s.notificationRepo.AddAction(ctx, "addAuthor")
-	}
-	return s.authorRepo.Store(ctx, author)
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.authorRepo.Store(ctx, author); err != nil {
+			return err
+		}
 
+		return publish(ctx, s.publisher, event.AuthorCreated, author.Id, author)
+	})
 }
+
 func (s *authorService) Update(ctx context.Context, id string, req *payload.AuthorRequest) error {
 	if id == "" {
 		return portError.NewBadRequestError("id is empty", nil)
@@ -66,16 +68,21 @@ func (s *authorService) Update(ctx context.Context, id string, req *payload.Auth
 		return err
 	}
 
+	// The request replaces the translations.
+	author.Translations = nil
 	if err := mapper.MapStructsWithJSONTags(req, author); err != nil {
 		return err
 	}
 
 	author.Id = id
 
-	if s.notificationRepo != nil {
-		s.notificationRepo.AddAction(ctx, "updateAuthor")
-	}
-	return s.authorRepo.Update(ctx, author)
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.authorRepo.Update(ctx, author); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.AuthorUpdated, author.Id, author)
+	})
 }
 
 func (s *authorService) FindAll(ctx context.Context) ([]*payload.AuthorResponse, error) {
@@ -84,26 +91,85 @@ func (s *authorService) FindAll(ctx context.Context) ([]*payload.AuthorResponse,
 		return nil, err
 	}
 
-	list := []*payload.AuthorResponse{}
-	for _, author := range authors {
-		authorRes := &payload.AuthorResponse{}
-		if err := mapper.MapStructsWithJSONTags(author, authorRes); err != nil {
-			return nil, err
-		}
-		list = append(list, authorRes)
+	return newAuthorResponses(ctx, authors)
+}
+
+// Search finds the authors by a part of their name or of one of their
+// aliases, ignoring case.
+func (s *authorService) Search(ctx context.Context, query string) ([]*payload.AuthorResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, portError.NewBadRequestError("q: field required", nil)
 	}
 
-	return list, nil
+	authors, err := s.authorRepo.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuthorResponses(ctx, authors)
 }
 
+// Delete deletes the author, then their photo.
 func (s *authorService) Delete(ctx context.Context, id string) error {
-	_, err := s.Find(ctx, id)
+	if id == "" {
+		return portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	author, err := s.authorRepo.Find(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if s.notificationRepo != nil {
-		s.notificationRepo.AddAction(ctx, "deleteAuthor")
+	err = withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.authorRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.AuthorDeleted, id, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	if author.PhotoId != "" {
+		s.images.delete(ctx, photoPrefix(id), author.PhotoId)
+	}
+
+	return nil
+}
+
+// newAuthorResponse maps author to its response. An author with a photo links
+// to it with the photo version, so the URL changes with every upload.
+func newAuthorResponse(ctx context.Context, author *entity.Author) (*payload.AuthorResponse, error) {
+	res := &payload.AuthorResponse{}
+	if err := mapper.MapStructsWithJSONTags(author, res); err != nil {
+		return nil, err
 	}
-	return s.authorRepo.Delete(ctx, id)
+
+	completeAuthorResponse(ctx, author, res)
+	return res, nil
+}
+
+// completeAuthorResponse sets the fields of the response of author that are
+// not mapped from it.
+func completeAuthorResponse(ctx context.Context, author *entity.Author, res *payload.AuthorResponse) {
+	if author.PhotoId != "" {
+		res.PhotoUrl = photoUrl(author.Id) + "?v=" + author.PhotoId
+	}
+
+	localizeAuthor(ctx, author, res)
+}
+
+func newAuthorResponses(ctx context.Context, authors []*entity.Author) ([]*payload.AuthorResponse, error) {
+	list := []*payload.AuthorResponse{}
+	for _, author := range authors {
+		res, err := newAuthorResponse(ctx, author)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, res)
+	}
+
+	return list, nil
 }
@@ -3,10 +3,13 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
 	"bookstore.com/port/payload"
 	"bookstore.com/repository"
 	"bookstore.com/test"
@@ -26,13 +29,14 @@ func Test_authorService_Find(t *testing.T) {
 			name: "find author succesfully",
 			id:   test.AuthorId1,
 			want: &payload.AuthorResponse{
-				Id:          test.AuthorId1,
-				FirstName:   test.AuthorFirstName1,
-				LastName:    test.AuthorLastName1,
-				BirthDate:   test.AuthorBirthDate1,
-				Nationality: test.AuthorNationality1,
-				CreatedAt:   test.CreatedAtStr,
-				UpdatedAt:   test.UpdatedAtStr,
+				Id:           test.AuthorId1,
+				FirstName:    test.AuthorFirstName1,
+				LastName:     test.AuthorLastName1,
+				BirthDate:    test.AuthorBirthDate1,
+				Nationality:  test.AuthorNationality1,
+				Translations: map[string]*payload.AuthorTranslationResponse{},
+				CreatedAt:    test.CreatedAtStr,
+				UpdatedAt:    test.UpdatedAtStr,
 			},
 			wantErr: false,
 			authorRepo: func() repository.AuthorRepository {
@@ -76,7 +80,7 @@ func Test_authorService_Find(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewAuthorService(tt.authorRepo(), nil)
+			s := NewAuthorService(tt.authorRepo(), nil, nil, nil)
 			got, err := s.Find(context.TODO(), tt.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("authorService.Find() error = %v, wantErr %v", err, tt.wantErr)
@@ -113,6 +117,7 @@ func Test_authorService_Store(t *testing.T) {
 					LastName:    test.AuthorLastName1,
 					BirthDate:   test.AuthorBirthDate1,
 					Nationality: test.AuthorNationality1,
+					Aliases:     []string{},
 				}).Return(nil)
 
 				return authorRepo
@@ -182,6 +187,7 @@ func Test_authorService_Store(t *testing.T) {
 					LastName:    test.AuthorLastName1,
 					BirthDate:   test.AuthorBirthDate1,
 					Nationality: test.AuthorNationality1,
+					Aliases:     []string{},
 				}).Return(errors.New("error occur"))
 
 				return authorRepo
@@ -191,7 +197,7 @@ func Test_authorService_Store(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewAuthorService(tt.AuthorRepo(), nil)
+			s := NewAuthorService(tt.AuthorRepo(), nil, nil, nil)
 			if err := s.Store(context.TODO(), tt.req); (err != nil) != tt.wantErr {
 				t.Errorf("authorService.Store() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -227,6 +233,7 @@ func Test_authorService_Update(t *testing.T) {
 					LastName:    test.AuthorLastName1,
 					BirthDate:   test.AuthorBirthDate1,
 					Nationality: test.AuthorNationality1,
+					Aliases:     []string{},
 				}).Return(nil)
 
 				return authorRepo
@@ -303,6 +310,7 @@ func Test_authorService_Update(t *testing.T) {
 					LastName:    test.AuthorLastName1,
 					BirthDate:   test.AuthorBirthDate1,
 					Nationality: test.AuthorNationality1,
+					Aliases:     []string{},
 				}).Return(errors.New("error occur"))
 
 				return authorRepo
@@ -312,7 +320,7 @@ func Test_authorService_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewAuthorService(tt.AuthorRepo(), nil)
+			s := NewAuthorService(tt.AuthorRepo(), nil, nil, nil)
 			if err := s.Update(context.TODO(), tt.id, tt.req); (err != nil) != tt.wantErr {
 				t.Errorf("authorService.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -333,13 +341,14 @@ func Test_authorService_FindAll(t *testing.T) {
 			wantErr: false,
 			want: []*payload.AuthorResponse{
 				{
-					Id:          test.AuthorId1,
-					FirstName:   test.AuthorFirstName1,
-					LastName:    test.AuthorLastName1,
-					BirthDate:   test.AuthorBirthDate1,
-					Nationality: test.AuthorNationality1,
-					CreatedAt:   test.CreatedAtStr,
-					UpdatedAt:   test.UpdatedAtStr,
+					Id:           test.AuthorId1,
+					FirstName:    test.AuthorFirstName1,
+					LastName:     test.AuthorLastName1,
+					BirthDate:    test.AuthorBirthDate1,
+					Nationality:  test.AuthorNationality1,
+					Translations: map[string]*payload.AuthorTranslationResponse{},
+					CreatedAt:    test.CreatedAtStr,
+					UpdatedAt:    test.UpdatedAtStr,
 				},
 			},
 			AuthorRepo: func() repository.AuthorRepository {
@@ -373,7 +382,7 @@ func Test_authorService_FindAll(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewAuthorService(tt.AuthorRepo(), nil)
+			s := NewAuthorService(tt.AuthorRepo(), nil, nil, nil)
 			got, err := s.FindAll(context.TODO())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("authorService.FindAll() error = %v, wantErr %v", err, tt.wantErr)
@@ -422,6 +431,18 @@ func Test_authorService_Delete(t *testing.T) {
 				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{}, nil)
 				authorRepo.EXPECT().Delete(gomock.Any(), test.AuthorId1).Return(errors.New("error occur"))
 
+				return authorRepo
+			},
+		},
+		{
+			name:    "delete author failed because they have books",
+			id:      test.AuthorId1,
+			wantErr: true,
+			AuthorRepo: func() repository.AuthorRepository {
+				authorRepo := repository.NewMockAuthorRepository(ctrl)
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{}, nil)
+				authorRepo.EXPECT().Delete(gomock.Any(), test.AuthorId1).Return(portError.NewConflictError("Author has books.", nil))
+
 				return authorRepo
 			},
 		},
@@ -429,10 +450,202 @@ func Test_authorService_Delete(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewAuthorService(tt.AuthorRepo(), nil)
+			s := NewAuthorService(tt.AuthorRepo(), nil, nil, nil)
 			if err := s.Delete(context.TODO(), tt.id); (err != nil) != tt.wantErr {
 				t.Errorf("authorService.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_authorService_Delete_photo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantKeys  []string
+	}{
+		{
+			name: "delete author with their photo",
+			wantKeys: []string{
+				"photos/" + test.AuthorId1 + "/v1/original",
+				"photos/" + test.AuthorId1 + "/v1/small",
+				"photos/" + test.AuthorId1 + "/v1/medium",
+			},
+		},
+		{
+			name:      "keep the photo when the author is not deleted",
+			deleteErr: errors.New("error occur"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1, PhotoId: "v1"}, nil)
+			authorRepo.EXPECT().Delete(gomock.Any(), test.AuthorId1).Return(tt.deleteErr)
+			blobs := repository.NewMockBlobStore(ctrl)
+			var keys []string
+			blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string) error {
+				keys = append(keys, key)
+				return nil
+			}).Times(len(tt.wantKeys))
+
+			s := NewAuthorService(authorRepo, blobs, nil, nil)
+			if err := s.Delete(context.TODO(), test.AuthorId1); (err != nil) != (tt.deleteErr != nil) {
+				t.Errorf("authorService.Delete() error = %v, want %v", err, tt.deleteErr)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("authorService.Delete() deleted blobs %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func Test_authorService_Store_details(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		req        *payload.AuthorRequest
+		want       *entity.Author
+		wantStatus int
+	}{
+		{
+			name: "store author with details successfully",
+			req: &payload.AuthorRequest{
+				DeathDate: "2001-01-01",
+				Biography: "Wrote *many* books.",
+				Aliases:   []string{" Pen Name ", "Other Name"},
+				ISNI:      "0000 0001 2281 955x",
+				VIAF:      "102333412",
+			},
+			want: &entity.Author{
+				DeathDate: "2001-01-01",
+				Biography: "Wrote *many* books.",
+				Aliases:   []string{"Pen Name", "Other Name"},
+				ISNI:      "000000012281955X",
+				VIAF:      "102333412",
+			},
+		},
+		{
+			name:       "store author failed because the death date is before the birth date",
+			req:        &payload.AuthorRequest{DeathDate: "1985-04-03"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store author failed because the death date is the birth date",
+			req:        &payload.AuthorRequest{DeathDate: test.AuthorBirthDate1},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store author failed because the death date is invalid",
+			req:        &payload.AuthorRequest{DeathDate: "2001-13-01"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store author failed because an alias is empty",
+			req:        &payload.AuthorRequest{Aliases: []string{"Pen Name", " "}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store author failed because an alias is duplicate",
+			req:        &payload.AuthorRequest{Aliases: []string{"Pen Name", "pen name"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store author failed because an alias has several lines",
+			req:        &payload.AuthorRequest{Aliases: []string{"Pen\nName"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store author failed because the isni is invalid",
+			req:        &payload.AuthorRequest{ISNI: "0000000122819551"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store author failed because the viaf is invalid",
+			req:        &payload.AuthorRequest{VIAF: "viaf-102333412"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store author failed because the biography is too long",
+			req:        &payload.AuthorRequest{Biography: strings.Repeat("a", 20001)},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.FirstName = test.AuthorFirstName1
+			tt.req.LastName = test.AuthorLastName1
+			tt.req.BirthDate = test.AuthorBirthDate1
+			tt.req.Nationality = test.AuthorNationality1
+
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			if tt.want != nil {
+				tt.want.FirstName = test.AuthorFirstName1
+				tt.want.LastName = test.AuthorLastName1
+				tt.want.BirthDate = test.AuthorBirthDate1
+				tt.want.Nationality = test.AuthorNationality1
+				authorRepo.EXPECT().Store(gomock.Any(), tt.want).Return(nil)
+			}
+
+			s := NewAuthorService(authorRepo, nil, nil, nil)
+			err := s.Store(context.TODO(), tt.req)
+			if status := apiStatus(err); status != tt.wantStatus || (tt.wantStatus == 0 && err != nil) {
+				t.Errorf("authorService.Store() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_authorService_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		query      string
+		wantQuery  string
+		want       []*payload.AuthorResponse
+		wantStatus int
+	}{
+		{
+			name:      "search authors successfully",
+			query:     " twain ",
+			wantQuery: "twain",
+			want: []*payload.AuthorResponse{{
+				Id:           test.AuthorId1,
+				Aliases:      []string{"Mark Twain"},
+				PhotoUrl:     "/api/v1/authors/" + test.AuthorId1 + "/photo?v=current",
+				Translations: map[string]*payload.AuthorTranslationResponse{},
+				CreatedAt:    test.CreatedAtStr,
+				UpdatedAt:    test.UpdatedAtStr,
+			}},
+		},
+		{
+			name:       "search authors failed because the query is empty",
+			query:      " ",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			if tt.wantQuery != "" {
+				authorRepo.EXPECT().Search(gomock.Any(), tt.wantQuery).Return([]*entity.Author{{
+					Id:        test.AuthorId1,
+					Aliases:   []string{"Mark Twain"},
+					PhotoId:   "current",
+					CreatedAt: test.CreatedAt,
+					UpdatedAt: test.UpdatedAt,
+				}}, nil)
+			}
+
+			s := NewAuthorService(authorRepo, nil, nil, nil)
+			got, err := s.Search(context.TODO(), tt.query)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Fatalf("authorService.Search() error = %v, want status %v", err, tt.wantStatus)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("authorService.Search() = %+v, want %+v", got[0], tt.want[0])
+			}
+		})
+	}
+}
@@ -4,24 +4,45 @@ import (
 	"context"
 
 	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
 	portError "bookstore.com/port/error"
 	"bookstore.com/port/payload"
 	"bookstore.com/repository"
+	"bookstore.com/tools/isbn"
 	"bookstore.com/tools/mapper"
 )
 
 type bookService struct {
-	bookRepo         repository.BookRepository
-	authorRepo       repository.AuthorRepository
-	notificationRepo repository.NotificationRepository
+	bookRepo      repository.BookRepository
+	authorRepo    repository.AuthorRepository
+	categoryRepo  repository.CategoryRepository
+	publisherRepo repository.PublisherRepository
+	seriesRepo    repository.SeriesRepository
+	images        *imageStore
+	publisher     event.Publisher
+	tx            repository.Transactor
 }
 
 func NewBookService(
 	bookRepo repository.BookRepository,
 	authorRepo repository.AuthorRepository,
-	notificationRepo repository.NotificationRepository,
+	categoryRepo repository.CategoryRepository,
+	publisherRepo repository.PublisherRepository,
+	seriesRepo repository.SeriesRepository,
+	blobs repository.BlobStore,
+	publisher event.Publisher,
+	tx repository.Transactor,
 ) BookService {
-	return &bookService{bookRepo: bookRepo, authorRepo: authorRepo, notificationRepo: notificationRepo}
+	return &bookService{
+		bookRepo:      bookRepo,
+		authorRepo:    authorRepo,
+		categoryRepo:  categoryRepo,
+		publisherRepo: publisherRepo,
+		seriesRepo:    seriesRepo,
+		images:        &imageStore{blobs: blobs},
+		publisher:     publisher,
+		tx:            tx,
+	}
 }
 
 func (s *bookService) Find(ctx context.Context, id string) (*payload.BookResponse, error) {
@@ -34,12 +55,22 @@ func (s *bookService) Find(ctx context.Context, id string) (*payload.BookRespons
 		return nil, err
 	}
 
-	res := &payload.BookResponse{}
-	if err := mapper.MapStructsWithJSONTags(book, res); err != nil {
+	return newBookResponse(ctx, book)
+}
+
+// FindByISBN finds a book by its ISBN-10 or ISBN-13.
+func (s *bookService) FindByISBN(ctx context.Context, number string) (*payload.BookResponse, error) {
+	_, isbn13, err := isbn.Parse(number)
+	if err != nil {
+		return nil, portError.NewBadRequestError("isbn: "+err.Error(), err)
+	}
+
+	book, err := s.bookRepo.FindByISBN(ctx, isbn13)
+	if err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return newBookResponse(ctx, book)
 }
 
 func (s *bookService) Store(ctx context.Context, req *payload.BookRequest) error {
@@ -47,8 +78,29 @@ func (s *bookService) Store(ctx context.Context, req *payload.BookRequest) error
 		return portError.NewBadRequestError(err.Error(), nil)
 	}
 
-	_, err := s.authorRepo.Find(ctx, req.AuthorId)
-	if err != nil {
+	if err := s.checkContributors(ctx, req); err != nil {
+		return err
+	}
+
+	if err := s.checkCategories(ctx, req); err != nil {
+		return err
+	}
+
+	if req.PublisherId != "" {
+		if _, err := s.publisherRepo.Find(ctx, req.PublisherId); err != nil {
+			return err
+		}
+	}
+
+	if err := checkEditions(req, nil); err != nil {
+		return err
+	}
+
+	if err := s.checkSeries(ctx, "", req); err != nil {
+		return err
+	}
+
+	if err := s.checkOriginal(ctx, "", req); err != nil {
 		return err
 	}
 
@@ -57,12 +109,14 @@ func (s *bookService) Store(ctx context.Context, req *payload.BookRequest) error
 		return err
 	}
 
-	_, err = s.bookRepo.Store(ctx, book)
-	if s.notificationRepo != nil && err == nil {
-		s.notificationRepo.AddAction(ctx, "newBook")
-	}
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		stored, err := s.bookRepo.Store(ctx, book)
+		if err != nil {
+			return err
+		}
 
-	return err
+		return publish(ctx, s.publisher, event.BookCreated, stored.Id, stored)
+	})
 }
 func (s *bookService) Update(ctx context.Context, id string, req *payload.BookRequest) error {
 	if id == "" {
@@ -78,22 +132,58 @@ func (s *bookService) Update(ctx context.Context, id string, req *payload.BookRe
 		return err
 	}
 
-	_, err = s.authorRepo.Find(ctx, req.AuthorId)
-	if err != nil {
+	if err := s.checkContributors(ctx, req); err != nil {
+		return err
+	}
+
+	if err := s.checkCategories(ctx, req); err != nil {
+		return err
+	}
+
+	if req.PublisherId != "" {
+		if _, err := s.publisherRepo.Find(ctx, req.PublisherId); err != nil {
+			return err
+		}
+	}
+
+	if err := checkEditions(req, book); err != nil {
+		return err
+	}
+
+	if err := s.checkSeries(ctx, id, req); err != nil {
 		return err
 	}
 
+	if err := s.checkOriginal(ctx, id, req); err != nil {
+		return err
+	}
+
+	// The request replaces the references, with their joined documents, the
+	// editions and the translations.
+	book.Author = nil
+	book.Contributors = nil
+	book.CategoryIds = nil
+	book.Tags = nil
+	book.PublisherId = ""
+	book.Publisher = nil
+	book.Editions = nil
+	book.SeriesId = ""
+	book.SeriesPosition = 0
+	book.OriginalId = ""
+	book.Translations = nil
 	if err := mapper.MapStructsWithJSONTags(req, book); err != nil {
 		return err
 	}
 
 	book.Id = id
 
-	err = s.bookRepo.Update(ctx, book)
-	if s.notificationRepo != nil && err == nil {
-		s.notificationRepo.AddAction(ctx, "updateBook")
-	}
-	return err
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.bookRepo.Update(ctx, book); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.BookUpdated, id, book)
+	})
 }
 
 func (s *bookService) FindAll(ctx context.Context) ([]*payload.BookResponse, error) {
@@ -104,8 +194,8 @@ func (s *bookService) FindAll(ctx context.Context) ([]*payload.BookResponse, err
 
 	list := []*payload.BookResponse{}
 	for _, book := range books {
-		bookRes := &payload.BookResponse{}
-		if err := mapper.MapStructsWithJSONTags(book, bookRes); err != nil {
+		bookRes, err := newBookResponse(ctx, book)
+		if err != nil {
 			return nil, err
 		}
 		list = append(list, bookRes)
@@ -114,15 +204,324 @@ func (s *bookService) FindAll(ctx context.Context) ([]*payload.BookResponse, err
 	return list, nil
 }
 
+// FindTranslations returns the translations of the book.
+func (s *bookService) FindTranslations(ctx context.Context, id string) ([]*payload.BookResponse, error) {
+	if id == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	if _, err := s.bookRepo.Find(ctx, id); err != nil {
+		return nil, err
+	}
+
+	books, err := s.bookRepo.FindByOriginal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.BookResponse{}
+	for _, book := range books {
+		res, err := newBookResponse(ctx, book)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, res)
+	}
+
+	return list, nil
+}
+
+// FindByTags returns the books with any of the tags, or with all of them when
+// all is set.
+func (s *bookService) FindByTags(ctx context.Context, tags []string, all bool) ([]*payload.BookResponse, error) {
+	tags, err := payload.NormalizeTags(tags)
+	if err != nil {
+		return nil, portError.NewBadRequestError(err.Error(), nil)
+	}
+	if len(tags) == 0 {
+		return nil, portError.NewBadRequestError("tags: field required", nil)
+	}
+
+	books, err := s.bookRepo.FindByTags(ctx, tags, all)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.BookResponse{}
+	for _, book := range books {
+		res, err := newBookResponse(ctx, book)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, res)
+	}
+
+	return list, nil
+}
+
+// AddTags adds the tags to the book and returns it.
+func (s *bookService) AddTags(ctx context.Context, id string, req *payload.TagsRequest) (*payload.BookResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	return s.changeTags(ctx, id, func(ctx context.Context) error {
+		return s.bookRepo.AddTags(ctx, id, req.Tags)
+	})
+}
+
+// RemoveTag removes the tag from the book and returns it. Removing a tag the
+// book does not have is not an error.
+func (s *bookService) RemoveTag(ctx context.Context, id, tag string) (*payload.BookResponse, error) {
+	tags, err := payload.NormalizeTags([]string{tag})
+	if err != nil {
+		return nil, portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	return s.changeTags(ctx, id, func(ctx context.Context) error {
+		return s.bookRepo.RemoveTags(ctx, id, tags)
+	})
+}
+
+// changeTags changes the tags of the book id with change and publishes the
+// book updated.
+func (s *bookService) changeTags(ctx context.Context, id string, change func(ctx context.Context) error) (*payload.BookResponse, error) {
+	if id == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	if _, err := s.bookRepo.Find(ctx, id); err != nil {
+		return nil, err
+	}
+
+	var book *entity.Book
+	err := withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		if book, err = s.bookRepo.Find(ctx, id); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.BookUpdated, id, book)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newBookResponse(ctx, book)
+}
+
+// CountTags returns every tag with its number of books, most used first.
+func (s *bookService) CountTags(ctx context.Context) ([]*payload.TagCountResponse, error) {
+	counts, err := s.bookRepo.CountTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.TagCountResponse{}
+	for _, c := range counts {
+		list = append(list, &payload.TagCountResponse{Tag: c.Tag, Count: c.Count})
+	}
+
+	return list, nil
+}
+
+// Delete deletes the book, then its cover. An original work cannot be deleted
+// while it has translations.
 func (s *bookService) Delete(ctx context.Context, id string) error {
-	_, err := s.Find(ctx, id)
+	if id == "" {
+		return portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	book, err := s.bookRepo.Find(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	err = s.bookRepo.Delete(ctx, id)
-	if s.notificationRepo != nil && err == nil {
-		s.notificationRepo.AddAction(ctx, "deleteBook")
+	err = withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		translations, err := s.bookRepo.FindByOriginal(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(translations) > 0 {
+			return portError.NewConflictError("Book has translations.", nil)
+		}
+
+		if err := s.bookRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.BookDeleted, id, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	if book.CoverId != "" {
+		s.images.delete(ctx, coverPrefix(id), book.CoverId)
+	}
+
+	return nil
+}
+
+// newBookResponse maps book to its response, localized like its authors in
+// the language requested in ctx. A book with a cover links to it with the
+// cover version, so the URL changes with every upload, and so do its authors
+// with a photo.
+func newBookResponse(ctx context.Context, book *entity.Book) (*payload.BookResponse, error) {
+	res := &payload.BookResponse{}
+	if err := mapper.MapStructsWithJSONTags(book, res); err != nil {
+		return nil, err
+	}
+
+	if book.CoverId != "" {
+		res.CoverUrl = coverUrl(book.Id) + "?v=" + book.CoverId
 	}
-	return err
+
+	localizeBook(ctx, book, res)
+
+	if book.Author != nil && res.Author != nil {
+		completeAuthorResponse(ctx, book.Author, res.Author)
+	}
+	for i, c := range book.Contributors {
+		if c.Author != nil && i < len(res.Contributors) && res.Contributors[i].Author != nil {
+			completeAuthorResponse(ctx, c.Author, res.Contributors[i].Author)
+		}
+	}
+
+	return res, nil
+}
+
+// checkContributors returns the error of the first contributor whose author
+// cannot be found.
+func (s *bookService) checkContributors(ctx context.Context, req *payload.BookRequest) error {
+	seen := map[string]bool{}
+	for _, c := range req.Contributors {
+		if seen[c.AuthorId] {
+			continue
+		}
+		seen[c.AuthorId] = true
+
+		if _, err := s.authorRepo.Find(ctx, c.AuthorId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkEditions checks that the editions of req with an ID are editions of
+// book, which is nil for a new book.
+func checkEditions(req *payload.BookRequest, book *entity.Book) error {
+	existing := map[string]bool{}
+	if book != nil {
+		for _, e := range book.Editions {
+			existing[e.Id] = true
+		}
+	}
+
+	for _, e := range req.Editions {
+		if e.Id != "" && !existing[e.Id] {
+			return portError.NewNotFoundError("Edition not found.", nil)
+		}
+	}
+
+	return nil
+}
+
+// checkSeries checks that the series of the book exists and that no other
+// book than id is at its position in it.
+func (s *bookService) checkSeries(ctx context.Context, id string, req *payload.BookRequest) error {
+	if req.SeriesId == "" {
+		return nil
+	}
+
+	if _, err := s.seriesRepo.Find(ctx, req.SeriesId); err != nil {
+		return err
+	}
+
+	books, err := s.bookRepo.FindBySeries(ctx, req.SeriesId)
+	if err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		if book.Id != id && book.SeriesPosition == req.SeriesPosition {
+			return portError.NewConflictError("A book already has this position in the series.", nil)
+		}
+	}
+
+	return nil
+}
+
+// checkOriginal checks that the original of a translated book exists, is not
+// a translation itself and is in another language, and that the book id,
+// empty for a new book, has no translations of its own. An original work must
+// be in another language than each of its translations.
+func (s *bookService) checkOriginal(ctx context.Context, id string, req *payload.BookRequest) error {
+	if req.OriginalId == "" {
+		return s.checkTranslations(ctx, id, req)
+	}
+
+	if req.OriginalId == id {
+		return portError.NewBadRequestError("originalId: must not be the book itself", nil)
+	}
+
+	original, err := s.bookRepo.Find(ctx, req.OriginalId)
+	if err != nil {
+		return err
+	}
+	if original.OriginalId != "" {
+		return portError.NewBadRequestError("originalId: must be an original work, not a translation", nil)
+	}
+	if original.Language == req.Language {
+		return portError.NewBadRequestError("language: must differ from the language of the original", nil)
+	}
+
+	if id == "" {
+		return nil
+	}
+
+	translations, err := s.bookRepo.FindByOriginal(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(translations) > 0 {
+		return portError.NewConflictError("Book has translations, it cannot be a translation.", nil)
+	}
+
+	return nil
+}
+
+func (s *bookService) checkTranslations(ctx context.Context, id string, req *payload.BookRequest) error {
+	if id == "" {
+		return nil
+	}
+
+	translations, err := s.bookRepo.FindByOriginal(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, translation := range translations {
+		if translation.Language == req.Language {
+			return portError.NewBadRequestError("language: must differ from the language of the translations", nil)
+		}
+	}
+
+	return nil
+}
+
+// checkCategories returns the error of the first category of the book that
+// cannot be found.
+func (s *bookService) checkCategories(ctx context.Context, req *payload.BookRequest) error {
+	for _, id := range req.CategoryIds {
+		if _, err := s.categoryRepo.Find(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"reflect"
 	"testing"
 
 	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
 	"bookstore.com/port/payload"
 	"bookstore.com/repository"
+	memoryrepo "bookstore.com/repository/memory"
 	"bookstore.com/test"
+	"bookstore.com/tools/locale"
 	"go.uber.org/mock/gomock"
 )
 
@@ -57,18 +62,20 @@ func Test_bookService_Find(t *testing.T) {
 			want: &payload.BookResponse{
 				Id: test.BookId1,
 				Author: &payload.AuthorResponse{
-					Id:          test.AuthorId1,
-					FirstName:   test.AuthorFirstName1,
-					LastName:    test.AuthorLastName1,
-					BirthDate:   test.AuthorBirthDate1,
-					Nationality: test.AuthorNationality1,
-					CreatedAt:   test.CreatedAtStr,
-					UpdatedAt:   test.UpdatedAtStr,
+					Id:           test.AuthorId1,
+					FirstName:    test.AuthorFirstName1,
+					LastName:     test.AuthorLastName1,
+					BirthDate:    test.AuthorBirthDate1,
+					Nationality:  test.AuthorNationality1,
+					Translations: map[string]*payload.AuthorTranslationResponse{},
+					CreatedAt:    test.CreatedAtStr,
+					UpdatedAt:    test.UpdatedAtStr,
 				},
 				Name:            test.BookName1,
 				Description:     test.BookDescription1,
 				PublicationDate: test.PublicationDate1,
 				Price:           test.Price1,
+				Translations:    map[string]*payload.BookTranslationResponse{},
 				CreatedAt:       test.CreatedAtStr,
 				UpdatedAt:       test.UpdatedAtStr,
 			},
@@ -92,7 +99,7 @@ func Test_bookService_Find(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil)
+			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil, nil, nil, nil, nil, nil)
 			got, err := s.Find(context.TODO(), tt.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("bookService.Find() error = %v, wantErr %v", err, tt.wantErr)
@@ -107,6 +114,71 @@ func Test_bookService_Find(t *testing.T) {
 	}
 }
 
+func Test_bookService_FindByISBN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name     string
+		bookRepo func() repository.BookRepository
+		isbn     string
+		wantId   string
+		wantErr  bool
+	}{
+		{
+			name: "find book by isbn-13",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindByISBN(gomock.Any(), test.ISBN13_1).Return(&entity.Book{Id: test.BookId1}, nil)
+
+				return bookRepo
+			},
+			isbn:   test.ISBN13_1,
+			wantId: test.BookId1,
+		},
+		{
+			name: "find book by hyphenated isbn-10",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindByISBN(gomock.Any(), test.ISBN13_1).Return(&entity.Book{Id: test.BookId1}, nil)
+
+				return bookRepo
+			},
+			isbn:   "0-306-40615-2",
+			wantId: test.BookId1,
+		},
+		{
+			name: "invalid isbn",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			isbn:    "0306406153",
+			wantErr: true,
+		},
+		{
+			name: "book not found",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindByISBN(gomock.Any(), test.ISBN13_1).Return(nil, errors.New("book not found"))
+
+				return bookRepo
+			},
+			isbn:    test.ISBN13_1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewBookService(tt.bookRepo(), repository.NewMockAuthorRepository(ctrl), nil, nil, nil, nil, nil, nil)
+			got, err := s.FindByISBN(context.TODO(), tt.isbn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("bookService.FindByISBN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != nil && got.Id != tt.wantId {
+				t.Errorf("bookService.FindByISBN() = %v, want book %s", got.Id, tt.wantId)
+			}
+		})
+	}
+}
+
 func Test_bookService_Store(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	tests := []struct {
@@ -122,6 +194,7 @@ func Test_bookService_Store(t *testing.T) {
 				bookRepo := repository.NewMockBookRepository(ctrl)
 				bookRepo.EXPECT().Store(gomock.Any(), &entity.Book{
 					AuthorId:        test.AuthorId1,
+					Contributors:    []*entity.Contributor{{AuthorId: test.AuthorId1, Role: entity.ContributorAuthor}},
 					Name:            test.BookName1,
 					Description:     test.BookDescription1,
 					PublicationDate: test.PublicationDate1,
@@ -270,16 +343,19 @@ func Test_bookService_Store(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "store book failed",
+			name: "store book with isbn-10",
 			bookRepo: func() repository.BookRepository {
 				bookRepo := repository.NewMockBookRepository(ctrl)
 				bookRepo.EXPECT().Store(gomock.Any(), &entity.Book{
 					AuthorId:        test.AuthorId1,
+					Contributors:    []*entity.Contributor{{AuthorId: test.AuthorId1, Role: entity.ContributorAuthor}},
 					Name:            test.BookName1,
 					Description:     test.BookDescription1,
 					PublicationDate: test.PublicationDate1,
 					Price:           test.Price1,
-				}).Return(nil, errors.New("error occur"))
+					ISBN10:          test.ISBN10_1,
+					ISBN13:          test.ISBN13_1,
+				}).Return(&entity.Book{}, nil)
 
 				return bookRepo
 			},
@@ -303,63 +379,41 @@ func Test_bookService_Store(t *testing.T) {
 				Description:     test.BookDescription1,
 				PublicationDate: test.PublicationDate1,
 				Price:           test.Price1,
+				ISBN10:          "0-306-40615-2",
 			},
-			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil)
-			if err := s.Store(context.TODO(), tt.req); (err != nil) != tt.wantErr {
-				t.Errorf("bookService.Store() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func Test_bookService_Update(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	tests := []struct {
-		name       string
-		bookRepo   func() repository.BookRepository
-		authorRepo func() repository.AuthorRepository
-		id         string
-		req        *payload.BookRequest
-		wantErr    bool
-	}{
 		{
-			name: "update book successfully",
+			name: "store book with contributors",
 			bookRepo: func() repository.BookRepository {
 				bookRepo := repository.NewMockBookRepository(ctrl)
-				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
-				bookRepo.EXPECT().Update(gomock.Any(), &entity.Book{
-					Id:              test.BookId1,
-					AuthorId:        test.AuthorId1,
+				bookRepo.EXPECT().Store(gomock.Any(), &entity.Book{
+					AuthorId: test.AuthorId1,
+					Contributors: []*entity.Contributor{
+						{AuthorId: test.AuthorId1, Role: entity.ContributorAuthor},
+						{AuthorId: test.AuthorId2, Role: entity.ContributorTranslator},
+						{AuthorId: test.AuthorId1, Role: entity.ContributorIllustrator},
+					},
 					Name:            test.BookName1,
 					Description:     test.BookDescription1,
 					PublicationDate: test.PublicationDate1,
 					Price:           test.Price1,
-				}).Return(nil)
+				}).Return(&entity.Book{}, nil)
 
 				return bookRepo
 			},
 			authorRepo: func() repository.AuthorRepository {
 				authorRepo := repository.NewMockAuthorRepository(ctrl)
-				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{
-					Id:          test.AuthorId1,
-					FirstName:   test.AuthorFirstName1,
-					LastName:    test.AuthorLastName1,
-					BirthDate:   test.AuthorBirthDate1,
-					Nationality: test.AuthorNationality1,
-					CreatedAt:   test.CreatedAt,
-					UpdatedAt:   test.UpdatedAt,
-				}, nil)
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId2).Return(&entity.Author{Id: test.AuthorId2}, nil)
 
 				return authorRepo
 			},
-			id: test.BookId1,
 			req: &payload.BookRequest{
-				AuthorId:        test.AuthorId1,
+				Contributors: []*payload.ContributorRequest{
+					{AuthorId: test.AuthorId1},
+					{AuthorId: test.AuthorId2, Role: entity.ContributorTranslator},
+					{AuthorId: test.AuthorId1, Role: entity.ContributorIllustrator},
+				},
 				Name:            test.BookName1,
 				Description:     test.BookDescription1,
 				PublicationDate: test.PublicationDate1,
@@ -367,16 +421,23 @@ func Test_bookService_Update(t *testing.T) {
 			},
 		},
 		{
-			name: "id empty",
+			name: "contributor not found",
 			bookRepo: func() repository.BookRepository {
 				return repository.NewMockBookRepository(ctrl)
 			},
 			authorRepo: func() repository.AuthorRepository {
-				return repository.NewMockAuthorRepository(ctrl)
+				authorRepo := repository.NewMockAuthorRepository(ctrl)
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId2).Return(nil, errors.New("author not found"))
+
+				return authorRepo
 			},
-			id: "",
 			req: &payload.BookRequest{
-				AuthorId:        test.AuthorId1,
+				AuthorId: test.AuthorId1,
+				Contributors: []*payload.ContributorRequest{
+					{AuthorId: test.AuthorId1, Role: entity.ContributorAuthor},
+					{AuthorId: test.AuthorId2, Role: entity.ContributorEditor},
+				},
 				Name:            test.BookName1,
 				Description:     test.BookDescription1,
 				PublicationDate: test.PublicationDate1,
@@ -385,15 +446,15 @@ func Test_bookService_Update(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "author id empty",
+			name: "invalid contributor role",
 			bookRepo: func() repository.BookRepository {
 				return repository.NewMockBookRepository(ctrl)
 			},
 			authorRepo: func() repository.AuthorRepository {
 				return repository.NewMockAuthorRepository(ctrl)
 			},
-			id: test.BookId1,
 			req: &payload.BookRequest{
+				Contributors:    []*payload.ContributorRequest{{AuthorId: test.AuthorId1, Role: "narrator"}},
 				Name:            test.BookName1,
 				Description:     test.BookDescription1,
 				PublicationDate: test.PublicationDate1,
@@ -402,149 +463,72 @@ func Test_bookService_Update(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "name empty",
-			bookRepo: func() repository.BookRepository {
-				return repository.NewMockBookRepository(ctrl)
-			},
-			authorRepo: func() repository.AuthorRepository {
-				return repository.NewMockAuthorRepository(ctrl)
-			},
-			id: test.BookId1,
-			req: &payload.BookRequest{
-				AuthorId:        test.AuthorId1,
-				Description:     test.BookDescription1,
-				PublicationDate: test.PublicationDate1,
-				Price:           test.Price1,
-			},
-			wantErr: true,
-		},
-		{
-			name: "description id empty",
+			name: "author id not the first contributor",
 			bookRepo: func() repository.BookRepository {
 				return repository.NewMockBookRepository(ctrl)
 			},
 			authorRepo: func() repository.AuthorRepository {
 				return repository.NewMockAuthorRepository(ctrl)
 			},
-			id: test.BookId1,
 			req: &payload.BookRequest{
-				AuthorId:        test.AuthorId1,
+				AuthorId:        test.AuthorId2,
+				Contributors:    []*payload.ContributorRequest{{AuthorId: test.AuthorId1}},
 				Name:            test.BookName1,
+				Description:     test.BookDescription1,
 				PublicationDate: test.PublicationDate1,
 				Price:           test.Price1,
 			},
 			wantErr: true,
 		},
 		{
-			name: "publication date empty",
-			bookRepo: func() repository.BookRepository {
-				return repository.NewMockBookRepository(ctrl)
-			},
-			authorRepo: func() repository.AuthorRepository {
-				return repository.NewMockAuthorRepository(ctrl)
-			},
-			id: test.BookId1,
-			req: &payload.BookRequest{
-				AuthorId:    test.AuthorId1,
-				Name:        test.BookName1,
-				Description: test.BookDescription1,
-				Price:       test.Price1,
-			},
-			wantErr: true,
-		},
-		{
-			name: "price equal to 0",
+			name: "invalid isbn checksum",
 			bookRepo: func() repository.BookRepository {
 				return repository.NewMockBookRepository(ctrl)
 			},
 			authorRepo: func() repository.AuthorRepository {
 				return repository.NewMockAuthorRepository(ctrl)
 			},
-			id: test.BookId1,
 			req: &payload.BookRequest{
 				AuthorId:        test.AuthorId1,
 				Name:            test.BookName1,
 				Description:     test.BookDescription1,
 				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+				ISBN13:          "9780306406158",
 			},
 			wantErr: true,
 		},
 		{
-			name: "invalid price",
+			name: "isbn-10 not matching isbn-13",
 			bookRepo: func() repository.BookRepository {
 				return repository.NewMockBookRepository(ctrl)
 			},
 			authorRepo: func() repository.AuthorRepository {
 				return repository.NewMockAuthorRepository(ctrl)
 			},
-			id: test.BookId1,
-			req: &payload.BookRequest{
-				AuthorId:        test.AuthorId1,
-				Name:            test.BookName1,
-				Description:     test.BookDescription1,
-				PublicationDate: test.PublicationDate1,
-				Price:           test.InvalidPrice1,
-			},
-			wantErr: true,
-		},
-		{
-			name: "find book failed",
-			bookRepo: func() repository.BookRepository {
-				bookRepo := repository.NewMockBookRepository(ctrl)
-				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(nil, errors.New("error occur"))
-
-				return bookRepo
-			},
-			authorRepo: func() repository.AuthorRepository {
-				return repository.NewMockAuthorRepository(ctrl)
-			},
-			id: test.BookId1,
-			req: &payload.BookRequest{
-				AuthorId:        test.AuthorId1,
-				Name:            test.BookName1,
-				Description:     test.BookDescription1,
-				PublicationDate: test.PublicationDate1,
-				Price:           test.Price1,
-			},
-			wantErr: true,
-		},
-		{
-			name: "find author failed",
-			bookRepo: func() repository.BookRepository {
-				bookRepo := repository.NewMockBookRepository(ctrl)
-				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
-
-				return bookRepo
-			},
-			authorRepo: func() repository.AuthorRepository {
-				authorRepo := repository.NewMockAuthorRepository(ctrl)
-				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(nil, errors.New("error occur"))
-
-				return authorRepo
-			},
-			id: test.BookId1,
 			req: &payload.BookRequest{
 				AuthorId:        test.AuthorId1,
 				Name:            test.BookName1,
 				Description:     test.BookDescription1,
 				PublicationDate: test.PublicationDate1,
 				Price:           test.Price1,
+				ISBN10:          "080442957X",
+				ISBN13:          test.ISBN13_1,
 			},
 			wantErr: true,
 		},
 		{
-			name: "update book failed",
+			name: "store book failed",
 			bookRepo: func() repository.BookRepository {
 				bookRepo := repository.NewMockBookRepository(ctrl)
-				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
-				bookRepo.EXPECT().Update(gomock.Any(), &entity.Book{
-					Id:              test.BookId1,
+				bookRepo.EXPECT().Store(gomock.Any(), &entity.Book{
 					AuthorId:        test.AuthorId1,
+					Contributors:    []*entity.Contributor{{AuthorId: test.AuthorId1, Role: entity.ContributorAuthor}},
 					Name:            test.BookName1,
 					Description:     test.BookDescription1,
 					PublicationDate: test.PublicationDate1,
 					Price:           test.Price1,
-				}).Return(errors.New("error occur"))
+				}).Return(nil, errors.New("error occur"))
 
 				return bookRepo
 			},
@@ -562,7 +546,6 @@ func Test_bookService_Update(t *testing.T) {
 
 				return authorRepo
 			},
-			id: test.BookId1,
 			req: &payload.BookRequest{
 				AuthorId:        test.AuthorId1,
 				Name:            test.BookName1,
@@ -575,162 +558,1578 @@ func Test_bookService_Update(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil)
-			if err := s.Update(context.TODO(), tt.id, tt.req); (err != nil) != tt.wantErr {
-				t.Errorf("bookService.Update() error = %v, wantErr %v", err, tt.wantErr)
+			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil, nil, nil, nil, nil, nil)
+			if err := s.Store(context.TODO(), tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("bookService.Store() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func Test_bookService_FindAll(t *testing.T) {
+func Test_bookService_Store_categories(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	tests := []struct {
-		name       string
-		bookRepo   func() repository.BookRepository
-		authorRepo func() repository.AuthorRepository
-		want       []*payload.BookResponse
-		wantErr    bool
+		name         string
+		bookRepo     func() repository.BookRepository
+		categoryRepo func() repository.CategoryRepository
+		categoryIds  []string
+		wantErr      bool
 	}{
 		{
-			name: "find all books successfully",
+			name: "store book in categories successfully",
 			bookRepo: func() repository.BookRepository {
 				bookRepo := repository.NewMockBookRepository(ctrl)
-				bookRepo.EXPECT().FindAll(gomock.Any()).Return([]*entity.Book{
-					{
-						Id:       test.BookId1,
-						AuthorId: test.AuthorId1,
-						Author: &entity.Author{
-							Id:          test.AuthorId1,
-							FirstName:   test.AuthorFirstName1,
-							LastName:    test.AuthorLastName1,
-							BirthDate:   test.AuthorBirthDate1,
-							Nationality: test.AuthorNationality1,
-							CreatedAt:   test.CreatedAt,
-							UpdatedAt:   test.UpdatedAt,
-						},
-						Name:            test.BookName1,
-						Description:     test.BookDescription1,
-						PublicationDate: test.PublicationDate1,
-						Price:           test.Price1,
-						CreatedAt:       test.CreatedAt,
-						UpdatedAt:       test.UpdatedAt,
-					},
-				}, nil)
+				bookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *entity.Book) (*entity.Book, error) {
+					if !reflect.DeepEqual(book.CategoryIds, []string{test.CategoryId1, test.CategoryId2}) {
+						t.Errorf("bookRepository.Store() categoryIds = %v", book.CategoryIds)
+					}
+					return &entity.Book{Id: test.BookId1}, nil
+				})
 
 				return bookRepo
 			},
-			authorRepo: func() repository.AuthorRepository {
-				return repository.NewMockAuthorRepository(ctrl)
-			},
-			want: []*payload.BookResponse{
-				{
-					Id: test.BookId1,
-					Author: &payload.AuthorResponse{
-						Id:          test.AuthorId1,
-						FirstName:   test.AuthorFirstName1,
-						LastName:    test.AuthorLastName1,
-						BirthDate:   test.AuthorBirthDate1,
-						Nationality: test.AuthorNationality1,
-						CreatedAt:   test.CreatedAtStr,
-						UpdatedAt:   test.UpdatedAtStr,
-					},
-					Name:            test.BookName1,
-					Description:     test.BookDescription1,
-					PublicationDate: test.PublicationDate1,
-					Price:           test.Price1,
-					CreatedAt:       test.CreatedAtStr,
-					UpdatedAt:       test.UpdatedAtStr,
-				},
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(&entity.Category{Id: test.CategoryId1}, nil)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId2).Return(&entity.Category{Id: test.CategoryId2}, nil)
+
+				return categoryRepo
 			},
+			categoryIds: []string{test.CategoryId1, test.CategoryId2},
+			wantErr:     false,
 		},
 		{
-			name: "find all books failed",
+			name: "store book failed because category not found",
 			bookRepo: func() repository.BookRepository {
-				bookRepo := repository.NewMockBookRepository(ctrl)
-				bookRepo.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("error occur"))
-
+				return repository.NewMockBookRepository(ctrl)
+			},
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(nil, errors.New("category not found"))
+
+				return categoryRepo
+			},
+			categoryIds: []string{test.CategoryId1},
+			wantErr:     true,
+		},
+		{
+			name: "store book failed because of duplicate categories",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			categoryRepo: func() repository.CategoryRepository {
+				return repository.NewMockCategoryRepository(ctrl)
+			},
+			categoryIds: []string{test.CategoryId1, test.CategoryId1},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil).AnyTimes()
+
+			s := NewBookService(tt.bookRepo(), authorRepo, tt.categoryRepo(), nil, nil, nil, nil, nil)
+			err := s.Store(context.TODO(), &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+				CategoryIds:     tt.categoryIds,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bookService.Store() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_bookService_Store_editions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	hardcover := func() *payload.EditionRequest {
+		return &payload.EditionRequest{
+			Format:          entity.FormatHardcover,
+			ISBN13:          "978-0-306-40615-7",
+			PageCount:       320,
+			Price:           test.Price1,
+			PublicationDate: test.PublicationDate1,
+		}
+	}
+	tests := []struct {
+		name     string
+		bookRepo func() repository.BookRepository
+		editions func() []*payload.EditionRequest
+		wantErr  bool
+	}{
+		{
+			name: "store book with editions successfully",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *entity.Book) (*entity.Book, error) {
+					want := []*entity.Edition{
+						{Format: entity.FormatHardcover, ISBN10: test.ISBN10_1, ISBN13: test.ISBN13_1, PageCount: 320, Price: test.Price1, PublicationDate: test.PublicationDate1},
+						{Format: entity.FormatAudiobook, DurationMinutes: 540, Price: test.Price1, PublicationDate: test.PublicationDate1},
+					}
+					if !reflect.DeepEqual(book.Editions, want) {
+						t.Errorf("bookRepository.Store() editions = %+v, want %+v", book.Editions, want)
+					}
+					return &entity.Book{Id: test.BookId1}, nil
+				})
+
 				return bookRepo
 			},
-			authorRepo: func() repository.AuthorRepository {
-				return repository.NewMockAuthorRepository(ctrl)
+			editions: func() []*payload.EditionRequest {
+				return []*payload.EditionRequest{hardcover(), {
+					Format:          entity.FormatAudiobook,
+					DurationMinutes: 540,
+					Price:           test.Price1,
+					PublicationDate: test.PublicationDate1,
+				}}
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid format",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			editions: func() []*payload.EditionRequest {
+				e := hardcover()
+				e.Format = "scroll"
+				return []*payload.EditionRequest{e}
+			},
+			wantErr: true,
+		},
+		{
+			name: "audiobook without duration",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			editions: func() []*payload.EditionRequest {
+				e := hardcover()
+				e.Format = entity.FormatAudiobook
+				return []*payload.EditionRequest{e}
+			},
+			wantErr: true,
+		},
+		{
+			name: "printed edition without page count",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			editions: func() []*payload.EditionRequest {
+				e := hardcover()
+				e.PageCount = 0
+				return []*payload.EditionRequest{e}
+			},
+			wantErr: true,
+		},
+		{
+			name: "edition price equal to 0",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			editions: func() []*payload.EditionRequest {
+				e := hardcover()
+				e.Price = 0
+				return []*payload.EditionRequest{e}
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate edition isbn",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			editions: func() []*payload.EditionRequest {
+				return []*payload.EditionRequest{hardcover(), hardcover()}
+			},
+			wantErr: true,
+		},
+		{
+			name: "edition id unknown to a new book",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			editions: func() []*payload.EditionRequest {
+				e := hardcover()
+				e.Id = test.BookId1
+				return []*payload.EditionRequest{e}
 			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil)
-			got, err := s.FindAll(context.TODO())
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil).AnyTimes()
+
+			s := NewBookService(tt.bookRepo(), authorRepo, nil, nil, nil, nil, nil, nil)
+			err := s.Store(context.TODO(), &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+				Editions:        tt.editions(),
+			})
 			if (err != nil) != tt.wantErr {
-				t.Errorf("bookService.FindAll() error = %v, wantErr %v", err, tt.wantErr)
-				return
+				t.Errorf("bookService.Store() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("bookService.FindAll() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func Test_bookService_Update_editions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	const editionId = "64fbf00fc3a88d3a02b96701"
+	existing := &entity.Book{
+		Id:       test.BookId1,
+		AuthorId: test.AuthorId1,
+		Editions: []*entity.Edition{{Id: editionId, Format: entity.FormatEbook, PageCount: 300, Price: 5, PublicationDate: test.PublicationDate1}},
+	}
+	tests := []struct {
+		name      string
+		bookRepo  func() repository.BookRepository
+		editionId string
+		wantErr   int
+	}{
+		{
+			name: "update an edition of the book",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(existing, nil)
+				bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return(nil, nil)
+				bookRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *entity.Book) error {
+					want := []*entity.Edition{{Id: editionId, Format: entity.FormatPaperback, PageCount: 310, Price: test.Price1, PublicationDate: test.PublicationDate1}}
+					if !reflect.DeepEqual(book.Editions, want) {
+						t.Errorf("bookRepository.Update() editions = %+v, want %+v", book.Editions, want)
+					}
+					return nil
+				})
+
+				return bookRepo
+			},
+			editionId: editionId,
+		},
+		{
+			name: "edition not found",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(existing, nil)
+
+				return bookRepo
+			},
+			editionId: "64fbf00fc3a88d3a02b96702",
+			wantErr:   http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil).AnyTimes()
+
+			s := NewBookService(tt.bookRepo(), authorRepo, nil, nil, nil, nil, nil, nil)
+			err := s.Update(context.TODO(), test.BookId1, &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+				Editions: []*payload.EditionRequest{{
+					Id:              tt.editionId,
+					Format:          entity.FormatPaperback,
+					PageCount:       310,
+					Price:           test.Price1,
+					PublicationDate: test.PublicationDate1,
+				}},
+			})
+			if apiStatus(err) != tt.wantErr || (tt.wantErr == 0 && err != nil) {
+				t.Errorf("bookService.Update() error = %v, want status %d", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func Test_bookService_Delete(t *testing.T) {
+func Test_bookService_Store_publishesEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(&entity.Book{Id: test.BookId1}, nil)
+	authorRepo := repository.NewMockAuthorRepository(ctrl)
+	authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{}, nil)
+	publisher := memoryrepo.NewPublisher()
+
+	s := NewBookService(bookRepo, authorRepo, nil, nil, nil, nil, publisher, nil)
+	ctx := event.ContextWithActor(context.TODO(), "test_name")
+	err := s.Store(ctx, &payload.BookRequest{
+		AuthorId:        test.AuthorId1,
+		Name:            test.BookName1,
+		Description:     test.BookDescription1,
+		PublicationDate: test.PublicationDate1,
+		Price:           test.Price1,
+	})
+	if err != nil {
+		t.Fatalf("bookService.Store() error = %v", err)
+	}
+
+	events := publisher.Events()
+	if len(events) != 1 {
+		t.Fatalf("bookService.Store() published %d events, want 1", len(events))
+	}
+
+	e := events[0]
+	if e.Type != event.BookCreated || e.EntityId != test.BookId1 || e.Actor != "test_name" {
+		t.Errorf("bookService.Store() published %+v", e)
+	}
+}
+
+func Test_bookService_Store_publishFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(&entity.Book{Id: test.BookId1}, nil)
+	authorRepo := repository.NewMockAuthorRepository(ctrl)
+	authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{}, nil)
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	outbox.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("error occur"))
+	tx := repository.NewMockTransactor(ctrl)
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	s := NewBookService(bookRepo, authorRepo, nil, nil, nil, nil, outbox, tx)
+	err := s.Store(context.TODO(), &payload.BookRequest{
+		AuthorId:        test.AuthorId1,
+		Name:            test.BookName1,
+		Description:     test.BookDescription1,
+		PublicationDate: test.PublicationDate1,
+		Price:           test.Price1,
+	})
+	if err == nil {
+		t.Errorf("bookService.Store() expected the outbox error to abort the transaction")
+	}
+}
+
+func Test_bookService_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	tests := []struct {
 		name       string
 		bookRepo   func() repository.BookRepository
 		authorRepo func() repository.AuthorRepository
 		id         string
+		req        *payload.BookRequest
 		wantErr    bool
 	}{
 		{
-			name: "delete book successfully",
+			name: "update book successfully",
 			bookRepo: func() repository.BookRepository {
 				bookRepo := repository.NewMockBookRepository(ctrl)
 				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
-				bookRepo.EXPECT().Delete(gomock.Any(), test.BookId1).Return(nil)
+				bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return(nil, nil)
+				bookRepo.EXPECT().Update(gomock.Any(), &entity.Book{
+					Id:              test.BookId1,
+					AuthorId:        test.AuthorId1,
+					Contributors:    []*entity.Contributor{{AuthorId: test.AuthorId1, Role: entity.ContributorAuthor}},
+					Name:            test.BookName1,
+					Description:     test.BookDescription1,
+					PublicationDate: test.PublicationDate1,
+					Price:           test.Price1,
+				}).Return(nil)
 
 				return bookRepo
 			},
+			authorRepo: func() repository.AuthorRepository {
+				authorRepo := repository.NewMockAuthorRepository(ctrl)
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{
+					Id:          test.AuthorId1,
+					FirstName:   test.AuthorFirstName1,
+					LastName:    test.AuthorLastName1,
+					BirthDate:   test.AuthorBirthDate1,
+					Nationality: test.AuthorNationality1,
+					CreatedAt:   test.CreatedAt,
+					UpdatedAt:   test.UpdatedAt,
+				}, nil)
+
+				return authorRepo
+			},
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			},
+		},
+		{
+			name: "id empty",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id: "",
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "author id empty",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
 			authorRepo: func() repository.AuthorRepository {
 				return repository.NewMockAuthorRepository(ctrl)
 			},
 			id: test.BookId1,
+			req: &payload.BookRequest{
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			},
+			wantErr: true,
 		},
 		{
-			name: "find book failed",
+			name: "name empty",
 			bookRepo: func() repository.BookRepository {
-				bookRepo := repository.NewMockBookRepository(ctrl)
-				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, errors.New("error occur"))
-				return bookRepo
+				return repository.NewMockBookRepository(ctrl)
 			},
 			authorRepo: func() repository.AuthorRepository {
 				return repository.NewMockAuthorRepository(ctrl)
 			},
-			id:      test.BookId1,
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			},
 			wantErr: true,
 		},
 		{
-			name: "delete book failed",
+			name: "description id empty",
 			bookRepo: func() repository.BookRepository {
-				bookRepo := repository.NewMockBookRepository(ctrl)
-				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
-				bookRepo.EXPECT().Delete(gomock.Any(), test.BookId1).Return(errors.New("error occur"))
-
-				return bookRepo
+				return repository.NewMockBookRepository(ctrl)
 			},
 			authorRepo: func() repository.AuthorRepository {
 				return repository.NewMockAuthorRepository(ctrl)
 			},
-			id:      test.BookId1,
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			},
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil)
-			if err := s.Delete(context.TODO(), tt.id); (err != nil) != tt.wantErr {
-				t.Errorf("bookService.Delete() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
+		{
+			name: "publication date empty",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:    test.AuthorId1,
+				Name:        test.BookName1,
+				Description: test.BookDescription1,
+				Price:       test.Price1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "price equal to 0",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid price",
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.InvalidPrice1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "find book failed",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(nil, errors.New("error occur"))
+
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "find author failed",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
+
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				authorRepo := repository.NewMockAuthorRepository(ctrl)
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(nil, errors.New("error occur"))
+
+				return authorRepo
+			},
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "update book failed",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
+				bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return(nil, nil)
+				bookRepo.EXPECT().Update(gomock.Any(), &entity.Book{
+					Id:              test.BookId1,
+					AuthorId:        test.AuthorId1,
+					Contributors:    []*entity.Contributor{{AuthorId: test.AuthorId1, Role: entity.ContributorAuthor}},
+					Name:            test.BookName1,
+					Description:     test.BookDescription1,
+					PublicationDate: test.PublicationDate1,
+					Price:           test.Price1,
+				}).Return(errors.New("error occur"))
+
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				authorRepo := repository.NewMockAuthorRepository(ctrl)
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{
+					Id:          test.AuthorId1,
+					FirstName:   test.AuthorFirstName1,
+					LastName:    test.AuthorLastName1,
+					BirthDate:   test.AuthorBirthDate1,
+					Nationality: test.AuthorNationality1,
+					CreatedAt:   test.CreatedAt,
+					UpdatedAt:   test.UpdatedAt,
+				}, nil)
+
+				return authorRepo
+			},
+			id: test.BookId1,
+			req: &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil, nil, nil, nil, nil, nil)
+			if err := s.Update(context.TODO(), tt.id, tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("bookService.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_bookService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		bookRepo   func() repository.BookRepository
+		authorRepo func() repository.AuthorRepository
+		want       []*payload.BookResponse
+		wantErr    bool
+	}{
+		{
+			name: "find all books successfully",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindAll(gomock.Any()).Return([]*entity.Book{
+					{
+						Id:       test.BookId1,
+						AuthorId: test.AuthorId1,
+						Author: &entity.Author{
+							Id:          test.AuthorId1,
+							FirstName:   test.AuthorFirstName1,
+							LastName:    test.AuthorLastName1,
+							BirthDate:   test.AuthorBirthDate1,
+							Nationality: test.AuthorNationality1,
+							CreatedAt:   test.CreatedAt,
+							UpdatedAt:   test.UpdatedAt,
+						},
+						Name:            test.BookName1,
+						Description:     test.BookDescription1,
+						PublicationDate: test.PublicationDate1,
+						Price:           test.Price1,
+						CreatedAt:       test.CreatedAt,
+						UpdatedAt:       test.UpdatedAt,
+					},
+				}, nil)
+
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			want: []*payload.BookResponse{
+				{
+					Id: test.BookId1,
+					Author: &payload.AuthorResponse{
+						Id:           test.AuthorId1,
+						FirstName:    test.AuthorFirstName1,
+						LastName:     test.AuthorLastName1,
+						BirthDate:    test.AuthorBirthDate1,
+						Nationality:  test.AuthorNationality1,
+						Translations: map[string]*payload.AuthorTranslationResponse{},
+						CreatedAt:    test.CreatedAtStr,
+						UpdatedAt:    test.UpdatedAtStr,
+					},
+					Name:            test.BookName1,
+					Description:     test.BookDescription1,
+					PublicationDate: test.PublicationDate1,
+					Price:           test.Price1,
+					Translations:    map[string]*payload.BookTranslationResponse{},
+					CreatedAt:       test.CreatedAtStr,
+					UpdatedAt:       test.UpdatedAtStr,
+				},
+			},
+		},
+		{
+			name: "find all books failed",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("error occur"))
+
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil, nil, nil, nil, nil, nil)
+			got, err := s.FindAll(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bookService.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bookService.FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_bookService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		bookRepo   func() repository.BookRepository
+		authorRepo func() repository.AuthorRepository
+		id         string
+		wantErr    bool
+	}{
+		{
+			name: "delete book successfully",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
+				bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return([]*entity.Book{}, nil)
+				bookRepo.EXPECT().Delete(gomock.Any(), test.BookId1).Return(nil)
+
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id: test.BookId1,
+		},
+		{
+			name: "find book failed",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, errors.New("error occur"))
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id:      test.BookId1,
+			wantErr: true,
+		},
+		{
+			name: "delete book failed because it has translations",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
+				bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return([]*entity.Book{{Id: test.BookId2}}, nil)
+
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id:      test.BookId1,
+			wantErr: true,
+		},
+		{
+			name: "delete book failed",
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
+				bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return([]*entity.Book{}, nil)
+				bookRepo.EXPECT().Delete(gomock.Any(), test.BookId1).Return(errors.New("error occur"))
+
+				return bookRepo
+			},
+			authorRepo: func() repository.AuthorRepository {
+				return repository.NewMockAuthorRepository(ctrl)
+			},
+			id:      test.BookId1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewBookService(tt.bookRepo(), tt.authorRepo(), nil, nil, nil, nil, nil, nil)
+			if err := s.Delete(context.TODO(), tt.id); (err != nil) != tt.wantErr {
+				t.Errorf("bookService.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_bookService_Delete_translationsInTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inTx := false
+	tx := repository.NewMockTransactor(ctrl)
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			inTx = true
+			defer func() { inTx = false }()
+			return fn(ctx)
+		},
+	)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{}, nil)
+	bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).DoAndReturn(func(ctx context.Context, id string) ([]*entity.Book, error) {
+		if !inTx {
+			t.Errorf("bookRepository.FindByOriginal() called outside the transaction")
+		}
+		return []*entity.Book{}, nil
+	})
+	bookRepo.EXPECT().Delete(gomock.Any(), test.BookId1).Return(nil)
+
+	s := NewBookService(bookRepo, nil, nil, nil, nil, nil, nil, tx)
+	if err := s.Delete(context.TODO(), test.BookId1); err != nil {
+		t.Errorf("bookService.Delete() error = %v", err)
+	}
+}
+
+func Test_bookService_Delete_cover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantKeys  []string
+	}{
+		{
+			name: "delete book with its cover",
+			wantKeys: []string{
+				"covers/" + test.BookId1 + "/v1/original",
+				"covers/" + test.BookId1 + "/v1/small",
+				"covers/" + test.BookId1 + "/v1/medium",
+			},
+		},
+		{
+			name:      "keep the cover when the book is not deleted",
+			deleteErr: errors.New("error occur"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1, CoverId: "v1"}, nil)
+			bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return([]*entity.Book{}, nil)
+			bookRepo.EXPECT().Delete(gomock.Any(), test.BookId1).Return(tt.deleteErr)
+			blobs := repository.NewMockBlobStore(ctrl)
+			var keys []string
+			blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string) error {
+				keys = append(keys, key)
+				return nil
+			}).Times(len(tt.wantKeys))
+
+			s := NewBookService(bookRepo, nil, nil, nil, nil, blobs, nil, nil)
+			if err := s.Delete(context.TODO(), test.BookId1); (err != nil) != (tt.deleteErr != nil) {
+				t.Errorf("bookService.Delete() error = %v, want %v", err, tt.deleteErr)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("bookService.Delete() deleted blobs %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func Test_bookService_Store_tags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		tags       []string
+		wantTags   []string
+		wantStatus int
+	}{
+		{
+			name:     "store book with tags normalized successfully",
+			tags:     []string{" Summer-2026", "staff-pick", "STAFF-PICK"},
+			wantTags: []string{"staff-pick", "summer-2026"},
+		},
+		{
+			name: "store book without tags successfully",
+		},
+		{
+			name:       "store book failed because a tag has spaces",
+			tags:       []string{"staff pick"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store book failed because a tag is empty",
+			tags:       []string{"award", " "},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			if tt.wantStatus == 0 {
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+				bookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *entity.Book) (*entity.Book, error) {
+					if !reflect.DeepEqual(book.Tags, tt.wantTags) {
+						t.Errorf("bookRepository.Store() tags = %v, want %v", book.Tags, tt.wantTags)
+					}
+					return &entity.Book{Id: test.BookId1}, nil
+				})
+			}
+
+			s := NewBookService(bookRepo, authorRepo, nil, nil, nil, nil, nil, nil)
+			err := s.Store(context.TODO(), &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+				Tags:            tt.tags,
+			})
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("bookService.Store() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_bookService_FindByTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		tags       []string
+		all        bool
+		bookRepo   func() repository.BookRepository
+		want       []string
+		wantStatus int
+	}{
+		{
+			name: "find books with any tag successfully",
+			tags: []string{"Award", "staff-pick"},
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindByTags(gomock.Any(), []string{"award", "staff-pick"}, false).Return([]*entity.Book{
+					{Id: test.BookId1, Tags: []string{"award"}},
+					{Id: test.BookId2, Tags: []string{"staff-pick"}},
+				}, nil)
+				return bookRepo
+			},
+			want: []string{test.BookId1, test.BookId2},
+		},
+		{
+			name: "find books with all tags successfully",
+			tags: []string{"award", "staff-pick"},
+			all:  true,
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindByTags(gomock.Any(), []string{"award", "staff-pick"}, true).Return([]*entity.Book{}, nil)
+				return bookRepo
+			},
+			want: []string{},
+		},
+		{
+			name:       "find books failed because a tag is invalid",
+			tags:       []string{"staff_pick"},
+			bookRepo:   func() repository.BookRepository { return repository.NewMockBookRepository(ctrl) },
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "find books failed because there is no tag",
+			bookRepo:   func() repository.BookRepository { return repository.NewMockBookRepository(ctrl) },
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewBookService(tt.bookRepo(), nil, nil, nil, nil, nil, nil, nil)
+			got, err := s.FindByTags(context.TODO(), tt.tags, tt.all)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Fatalf("bookService.FindByTags() error = %v, want status %v", err, tt.wantStatus)
+			}
+			if err != nil {
+				return
+			}
+
+			ids := []string{}
+			for _, book := range got {
+				ids = append(ids, book.Id)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("bookService.FindByTags() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func Test_bookService_AddTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	t.Run("add tags successfully", func(t *testing.T) {
+		bookRepo := repository.NewMockBookRepository(ctrl)
+		gomock.InOrder(
+			bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1}, nil),
+			bookRepo.EXPECT().AddTags(gomock.Any(), test.BookId1, []string{"staff-pick", "summer-2026"}).Return(nil),
+			bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{
+				Id:   test.BookId1,
+				Tags: []string{"award", "staff-pick", "summer-2026"},
+			}, nil),
+		)
+		publisher := memoryrepo.NewPublisher()
+
+		s := NewBookService(bookRepo, nil, nil, nil, nil, nil, publisher, nil)
+		got, err := s.AddTags(context.TODO(), test.BookId1, &payload.TagsRequest{Tags: []string{"Summer-2026", "staff-pick"}})
+		if err != nil {
+			t.Fatalf("bookService.AddTags() error = %v", err)
+		}
+		if want := []string{"award", "staff-pick", "summer-2026"}; !reflect.DeepEqual(got.Tags, want) {
+			t.Errorf("bookService.AddTags() tags = %v, want %v", got.Tags, want)
+		}
+
+		events := publisher.Events()
+		if len(events) != 1 || events[0].Type != event.BookUpdated || events[0].EntityId != test.BookId1 {
+			t.Errorf("bookService.AddTags() published %+v, want the book updated", events)
+		}
+	})
+
+	t.Run("add tags failed because there is none", func(t *testing.T) {
+		s := NewBookService(repository.NewMockBookRepository(ctrl), nil, nil, nil, nil, nil, nil, nil)
+		if _, err := s.AddTags(context.TODO(), test.BookId1, &payload.TagsRequest{}); apiStatus(err) != http.StatusBadRequest {
+			t.Errorf("bookService.AddTags() error = %v, want bad request", err)
+		}
+	})
+
+	t.Run("add tags failed because the book is not found", func(t *testing.T) {
+		bookRepo := repository.NewMockBookRepository(ctrl)
+		bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(nil, portError.NewNotFoundError("Book not found.", nil))
+
+		s := NewBookService(bookRepo, nil, nil, nil, nil, nil, nil, nil)
+		_, err := s.AddTags(context.TODO(), test.BookId1, &payload.TagsRequest{Tags: []string{"award"}})
+		if apiStatus(err) != http.StatusNotFound {
+			t.Errorf("bookService.AddTags() error = %v, want not found", err)
+		}
+	})
+}
+
+func Test_bookService_RemoveTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	gomock.InOrder(
+		bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1, Tags: []string{"award"}}, nil),
+		bookRepo.EXPECT().RemoveTags(gomock.Any(), test.BookId1, []string{"award"}).Return(nil),
+		bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1, Tags: []string{}}, nil),
+	)
+
+	s := NewBookService(bookRepo, nil, nil, nil, nil, nil, nil, nil)
+	got, err := s.RemoveTag(context.TODO(), test.BookId1, "AWARD")
+	if err != nil {
+		t.Fatalf("bookService.RemoveTag() error = %v", err)
+	}
+	if len(got.Tags) != 0 {
+		t.Errorf("bookService.RemoveTag() tags = %v, want none", got.Tags)
+	}
+
+	if _, err := s.RemoveTag(context.TODO(), test.BookId1, "not a tag"); apiStatus(err) != http.StatusBadRequest {
+		t.Errorf("bookService.RemoveTag() error = %v, want bad request", err)
+	}
+}
+
+func Test_bookService_CountTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().CountTags(gomock.Any()).Return([]*entity.TagCount{{Tag: "award", Count: 2}, {Tag: "staff-pick", Count: 1}}, nil)
+
+	s := NewBookService(bookRepo, nil, nil, nil, nil, nil, nil, nil)
+	got, err := s.CountTags(context.TODO())
+	if err != nil {
+		t.Fatalf("bookService.CountTags() error = %v", err)
+	}
+	want := []*payload.TagCountResponse{{Tag: "award", Count: 2}, {Tag: "staff-pick", Count: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bookService.CountTags() = %v, want %v", got, want)
+	}
+}
+
+func Test_bookService_Store_publisher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name          string
+		publisherRepo func() repository.PublisherRepository
+		wantStored    bool
+		wantErr       bool
+	}{
+		{
+			name: "store book with publisher successfully",
+			publisherRepo: func() repository.PublisherRepository {
+				publisherRepo := repository.NewMockPublisherRepository(ctrl)
+				publisherRepo.EXPECT().Find(gomock.Any(), test.PublisherId1).Return(&entity.Publisher{Id: test.PublisherId1}, nil)
+
+				return publisherRepo
+			},
+			wantStored: true,
+		},
+		{
+			name: "store book failed because publisher not found",
+			publisherRepo: func() repository.PublisherRepository {
+				publisherRepo := repository.NewMockPublisherRepository(ctrl)
+				publisherRepo.EXPECT().Find(gomock.Any(), test.PublisherId1).Return(nil, errors.New("publisher not found"))
+
+				return publisherRepo
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			if tt.wantStored {
+				bookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *entity.Book) (*entity.Book, error) {
+					if book.PublisherId != test.PublisherId1 {
+						t.Errorf("bookRepository.Store() publisherId = %q, want %q", book.PublisherId, test.PublisherId1)
+					}
+					return &entity.Book{Id: test.BookId1}, nil
+				})
+			}
+
+			s := NewBookService(bookRepo, authorRepo, nil, tt.publisherRepo(), nil, nil, nil, nil)
+			err := s.Store(context.TODO(), &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				PublisherId:     test.PublisherId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bookService.Store() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_bookService_Store_series(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		req        func() *payload.BookRequest
+		seriesRepo func() repository.SeriesRepository
+		books      []*entity.Book
+		wantStored bool
+		wantStatus int
+	}{
+		{
+			name: "store book in series successfully",
+			req:  func() *payload.BookRequest { return seriesBookRequest(test.SeriesId1, 2) },
+			seriesRepo: func() repository.SeriesRepository {
+				seriesRepo := repository.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().Find(gomock.Any(), test.SeriesId1).Return(&entity.Series{Id: test.SeriesId1}, nil)
+
+				return seriesRepo
+			},
+			books:      []*entity.Book{{Id: test.BookId1, SeriesId: test.SeriesId1, SeriesPosition: 1}},
+			wantStored: true,
+		},
+		{
+			name: "store book failed because position is taken",
+			req:  func() *payload.BookRequest { return seriesBookRequest(test.SeriesId1, 1) },
+			seriesRepo: func() repository.SeriesRepository {
+				seriesRepo := repository.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().Find(gomock.Any(), test.SeriesId1).Return(&entity.Series{Id: test.SeriesId1}, nil)
+
+				return seriesRepo
+			},
+			books:      []*entity.Book{{Id: test.BookId1, SeriesId: test.SeriesId1, SeriesPosition: 1}},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "store book failed because series not found",
+			req:  func() *payload.BookRequest { return seriesBookRequest(test.SeriesId1, 1) },
+			seriesRepo: func() repository.SeriesRepository {
+				seriesRepo := repository.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().Find(gomock.Any(), test.SeriesId1).Return(nil, portError.NewNotFoundError("Series not found.", nil))
+
+				return seriesRepo
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "store book failed because position is missing",
+			req:  func() *payload.BookRequest { return seriesBookRequest(test.SeriesId1, 0) },
+			seriesRepo: func() repository.SeriesRepository {
+				return repository.NewMockSeriesRepository(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store book failed because series is missing",
+			req:  func() *payload.BookRequest { return seriesBookRequest("", 1) },
+			seriesRepo: func() repository.SeriesRepository {
+				return repository.NewMockSeriesRepository(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil).AnyTimes()
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			if tt.books != nil {
+				bookRepo.EXPECT().FindBySeries(gomock.Any(), test.SeriesId1).Return(tt.books, nil)
+			}
+			if tt.wantStored {
+				bookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *entity.Book) (*entity.Book, error) {
+					if book.SeriesId != test.SeriesId1 || book.SeriesPosition != 2 {
+						t.Errorf("bookRepository.Store() series = %q %d", book.SeriesId, book.SeriesPosition)
+					}
+					return &entity.Book{Id: test.BookId1}, nil
+				})
+			}
+
+			s := NewBookService(bookRepo, authorRepo, nil, nil, tt.seriesRepo(), nil, nil, nil)
+			err := s.Store(context.TODO(), tt.req())
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("bookService.Store() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_bookService_Update_keepsSeriesPosition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorRepo := repository.NewMockAuthorRepository(ctrl)
+	authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+	seriesRepo := repository.NewMockSeriesRepository(ctrl)
+	seriesRepo.EXPECT().Find(gomock.Any(), test.SeriesId1).Return(&entity.Series{Id: test.SeriesId1}, nil)
+	book := &entity.Book{Id: test.BookId1, SeriesId: test.SeriesId1, SeriesPosition: 1}
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(book, nil)
+	bookRepo.EXPECT().FindBySeries(gomock.Any(), test.SeriesId1).Return([]*entity.Book{book}, nil)
+	bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return(nil, nil)
+	bookRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	s := NewBookService(bookRepo, authorRepo, nil, nil, seriesRepo, nil, nil, nil)
+	if err := s.Update(context.TODO(), test.BookId1, seriesBookRequest(test.SeriesId1, 1)); err != nil {
+		t.Errorf("bookService.Update() error = %v", err)
+	}
+}
+
+func seriesBookRequest(seriesId string, position int) *payload.BookRequest {
+	return &payload.BookRequest{
+		AuthorId:        test.AuthorId1,
+		SeriesId:        seriesId,
+		SeriesPosition:  position,
+		Name:            test.BookName1,
+		Description:     test.BookDescription1,
+		PublicationDate: test.PublicationDate1,
+		Price:           test.Price1,
+	}
+}
+
+func Test_bookService_Find_coverUrl(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1, CoverId: "current"}, nil)
+
+	s := NewBookService(bookRepo, nil, nil, nil, nil, nil, nil, nil)
+	got, err := s.Find(context.TODO(), test.BookId1)
+	if err != nil {
+		t.Fatalf("bookService.Find() error = %v", err)
+	}
+	if want := "/api/v1/books/" + test.BookId1 + "/cover?v=current"; got.CoverUrl != want {
+		t.Errorf("bookService.Find() coverUrl = %q, want %q", got.CoverUrl, want)
+	}
+}
+
+func Test_bookService_Find_localized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	book := &entity.Book{
+		Id:          test.BookId1,
+		Name:        "The name",
+		Description: "The description",
+		Language:    "en",
+		Translations: map[string]*entity.BookTranslation{
+			"de":    {Name: "Der Name", Description: "Die Beschreibung"},
+			"pt-BR": {Name: "O nome", Description: "A descrição"},
+		},
+		Author: &entity.Author{
+			Id:           test.AuthorId1,
+			FirstName:    "Leo",
+			LastName:     "Tolstoy",
+			Translations: map[string]*entity.AuthorTranslation{"ru": {FirstName: "Лев", LastName: "Толстой"}},
+		},
+	}
+	tests := []struct {
+		name                string
+		tags                []string
+		wantName            string
+		wantContentLanguage string
+		wantAuthor          string
+		wantAuthorLanguage  string
+	}{
+		{
+			name:                "no language requested",
+			wantName:            "The name",
+			wantContentLanguage: "en",
+			wantAuthor:          "Leo Tolstoy",
+		},
+		{
+			name:                "translation of a less specific language",
+			tags:                []string{"de-AT"},
+			wantName:            "Der Name",
+			wantContentLanguage: "de",
+			wantAuthor:          "Leo Tolstoy",
+		},
+		{
+			name:                "translation of a more specific language",
+			tags:                []string{"pt"},
+			wantName:            "O nome",
+			wantContentLanguage: "pt-BR",
+			wantAuthor:          "Leo Tolstoy",
+		},
+		{
+			name:                "original language down the chain",
+			tags:                []string{"ja", "en-GB", "de"},
+			wantName:            "The name",
+			wantContentLanguage: "en",
+			wantAuthor:          "Leo Tolstoy",
+		},
+		{
+			name:                "author translated only",
+			tags:                []string{"ru"},
+			wantName:            "The name",
+			wantContentLanguage: "en",
+			wantAuthor:          "Лев Толстой",
+			wantAuthorLanguage:  "ru",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(book, nil)
+
+			ctx := context.TODO()
+			if tt.tags != nil {
+				ctx = locale.NewContext(ctx, locale.Chain(tt.tags))
+			}
+
+			s := NewBookService(bookRepo, nil, nil, nil, nil, nil, nil, nil)
+			got, err := s.Find(ctx, test.BookId1)
+			if err != nil {
+				t.Fatalf("bookService.Find() error = %v", err)
+			}
+			if got.Name != tt.wantName || got.ContentLanguage != tt.wantContentLanguage {
+				t.Errorf("bookService.Find() = %q in %q, want %q in %q", got.Name, got.ContentLanguage, tt.wantName, tt.wantContentLanguage)
+			}
+			if got.Language != "en" || len(got.Translations) != 2 || got.Translations["de"].Name != "Der Name" {
+				t.Errorf("bookService.Find() language = %q, translations = %v", got.Language, got.Translations)
+			}
+			if name := got.Author.FirstName + " " + got.Author.LastName; name != tt.wantAuthor || got.Author.ContentLanguage != tt.wantAuthorLanguage {
+				t.Errorf("bookService.Find() author = %q in %q, want %q in %q", name, got.Author.ContentLanguage, tt.wantAuthor, tt.wantAuthorLanguage)
+			}
+		})
+	}
+}
+
+func Test_bookService_Store_translations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name             string
+		language         string
+		originalId       string
+		translations     map[string]*payload.BookTranslationRequest
+		original         *entity.Book
+		wantLanguage     string
+		wantTranslations map[string]*entity.BookTranslation
+		wantStatus       int
+	}{
+		{
+			name:     "store book with translations successfully",
+			language: "EN",
+			translations: map[string]*payload.BookTranslationRequest{
+				"pt_br": {Name: "O nome", Description: "A descrição"},
+			},
+			wantLanguage:     "en",
+			wantTranslations: map[string]*entity.BookTranslation{"pt-BR": {Name: "O nome", Description: "A descrição"}},
+		},
+		{
+			name:         "store translation of a book successfully",
+			language:     "fr",
+			originalId:   test.BookId2,
+			original:     &entity.Book{Id: test.BookId2, Language: "en"},
+			wantLanguage: "fr",
+		},
+		{
+			name:       "store book failed because the language is invalid",
+			language:   "english",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "store book failed because a translation is in the language of the book",
+			language: "en",
+			translations: map[string]*payload.BookTranslationRequest{
+				"en": {Name: "The name", Description: "The description"},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store book failed because two translations have the same language",
+			translations: map[string]*payload.BookTranslationRequest{
+				"pt-br": {Name: "O nome", Description: "A descrição"},
+				"pt_BR": {Name: "O nome", Description: "A descrição"},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store book failed because a translation has no name",
+			translations: map[string]*payload.BookTranslationRequest{
+				"de": {Description: "Die Beschreibung"},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store translation failed because it has no language",
+			originalId: test.BookId2,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store translation failed because the original is a translation",
+			language:   "fr",
+			originalId: test.BookId2,
+			original:   &entity.Book{Id: test.BookId2, Language: "de", OriginalId: test.BookId1},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store translation failed because the original has the same language",
+			language:   "fr",
+			originalId: test.BookId2,
+			original:   &entity.Book{Id: test.BookId2, Language: "fr"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil).AnyTimes()
+			if tt.original != nil {
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId2).Return(tt.original, nil)
+			}
+			if tt.wantStatus == 0 {
+				bookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *entity.Book) (*entity.Book, error) {
+					if book.Language != tt.wantLanguage || book.OriginalId != tt.originalId {
+						t.Errorf("bookRepository.Store() = %q %q, want %q %q", book.Language, book.OriginalId, tt.wantLanguage, tt.originalId)
+					}
+					if !reflect.DeepEqual(book.Translations, tt.wantTranslations) {
+						t.Errorf("bookRepository.Store() translations = %v, want %v", book.Translations, tt.wantTranslations)
+					}
+					return &entity.Book{Id: test.BookId1}, nil
+				})
+			}
+
+			s := NewBookService(bookRepo, authorRepo, nil, nil, nil, nil, nil, nil)
+			err := s.Store(context.TODO(), &payload.BookRequest{
+				AuthorId:        test.AuthorId1,
+				Name:            test.BookName1,
+				Description:     test.BookDescription1,
+				PublicationDate: test.PublicationDate1,
+				Price:           test.Price1,
+				Language:        tt.language,
+				OriginalId:      tt.originalId,
+				Translations:    tt.translations,
+			})
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("bookService.Store() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_bookService_Update_translations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	req := func() *payload.BookRequest {
+		return &payload.BookRequest{
+			AuthorId:        test.AuthorId1,
+			Name:            test.BookName1,
+			Description:     test.BookDescription1,
+			PublicationDate: test.PublicationDate1,
+			Price:           test.Price1,
+			Language:        "fr",
+		}
+	}
+
+	t.Run("update replaces the translations", func(t *testing.T) {
+		bookRepo := repository.NewMockBookRepository(ctrl)
+		authorRepo := repository.NewMockAuthorRepository(ctrl)
+		authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+		bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{
+			Id:           test.BookId1,
+			OriginalId:   test.BookId2,
+			Translations: map[string]*entity.BookTranslation{"de": {Name: "Der Name", Description: "Die Beschreibung"}},
+		}, nil)
+		bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return(nil, nil)
+		bookRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *entity.Book) error {
+			if book.OriginalId != "" || book.Translations != nil || book.Language != "fr" {
+				t.Errorf("bookRepository.Update() = %q %v %q, want no original nor translations", book.OriginalId, book.Translations, book.Language)
+			}
+			return nil
+		})
+
+		s := NewBookService(bookRepo, authorRepo, nil, nil, nil, nil, nil, nil)
+		if err := s.Update(context.TODO(), test.BookId1, req()); err != nil {
+			t.Errorf("bookService.Update() error = %v", err)
+		}
+	})
+
+	t.Run("update failed because the book is its own original", func(t *testing.T) {
+		bookRepo := repository.NewMockBookRepository(ctrl)
+		authorRepo := repository.NewMockAuthorRepository(ctrl)
+		authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+		bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1}, nil)
+
+		r := req()
+		r.OriginalId = test.BookId1
+		s := NewBookService(bookRepo, authorRepo, nil, nil, nil, nil, nil, nil)
+		if err := s.Update(context.TODO(), test.BookId1, r); apiStatus(err) != http.StatusBadRequest {
+			t.Errorf("bookService.Update() error = %v, want bad request", err)
+		}
+	})
+
+	t.Run("update failed because a book with translations cannot be one", func(t *testing.T) {
+		bookRepo := repository.NewMockBookRepository(ctrl)
+		authorRepo := repository.NewMockAuthorRepository(ctrl)
+		authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+		bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1}, nil)
+		bookRepo.EXPECT().Find(gomock.Any(), test.BookId2).Return(&entity.Book{Id: test.BookId2, Language: "en"}, nil)
+		bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return([]*entity.Book{{Id: test.AuthorId2}}, nil)
+
+		r := req()
+		r.OriginalId = test.BookId2
+		s := NewBookService(bookRepo, authorRepo, nil, nil, nil, nil, nil, nil)
+		if err := s.Update(context.TODO(), test.BookId1, r); apiStatus(err) != http.StatusConflict {
+			t.Errorf("bookService.Update() error = %v, want conflict", err)
+		}
+	})
+
+	t.Run("update failed because an original takes the language of a translation", func(t *testing.T) {
+		bookRepo := repository.NewMockBookRepository(ctrl)
+		authorRepo := repository.NewMockAuthorRepository(ctrl)
+		authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+		bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1, Language: "en"}, nil)
+		bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return([]*entity.Book{
+			{Id: test.BookId2, Language: "fr", OriginalId: test.BookId1},
+		}, nil)
+
+		s := NewBookService(bookRepo, authorRepo, nil, nil, nil, nil, nil, nil)
+		if err := s.Update(context.TODO(), test.BookId1, req()); apiStatus(err) != http.StatusBadRequest {
+			t.Errorf("bookService.Update() error = %v, want bad request", err)
+		}
+	})
+}
+
+func Test_bookService_FindTranslations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{Id: test.BookId1, Language: "en"}, nil)
+	bookRepo.EXPECT().FindByOriginal(gomock.Any(), test.BookId1).Return([]*entity.Book{
+		{Id: test.BookId2, Name: "Le nom", Language: "fr", OriginalId: test.BookId1},
+	}, nil)
+
+	s := NewBookService(bookRepo, nil, nil, nil, nil, nil, nil, nil)
+	got, err := s.FindTranslations(context.TODO(), test.BookId1)
+	if err != nil {
+		t.Fatalf("bookService.FindTranslations() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Id != test.BookId2 || got[0].OriginalId != test.BookId1 || got[0].ContentLanguage != "fr" {
+		t.Errorf("bookService.FindTranslations() = %+v", got)
 	}
 }
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/tools/mapper"
+)
+
+type categoryService struct {
+	categoryRepo repository.CategoryRepository
+	bookRepo     repository.BookRepository
+	publisher    event.Publisher
+	tx           repository.Transactor
+}
+
+func NewCategoryService(
+	categoryRepo repository.CategoryRepository,
+	bookRepo repository.BookRepository,
+	publisher event.Publisher,
+	tx repository.Transactor,
+) CategoryService {
+	return &categoryService{categoryRepo: categoryRepo, bookRepo: bookRepo, publisher: publisher, tx: tx}
+}
+
+func (s *categoryService) Find(ctx context.Context, id string) (*payload.CategoryResponse, error) {
+	if id == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	category, err := s.categoryRepo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &payload.CategoryResponse{}
+	if err := mapper.MapStructsWithJSONTags(category, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *categoryService) Store(ctx context.Context, req *payload.CategoryRequest) (*payload.CategoryResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	category := &entity.Category{}
+	if err := mapper.MapStructsWithJSONTags(req, category); err != nil {
+		return nil, err
+	}
+
+	err := withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.checkParent(ctx, "", req.ParentId); err != nil {
+			return err
+		}
+
+		if err := s.categoryRepo.Store(ctx, category); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.CategoryCreated, category.Id, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &payload.CategoryResponse{}
+	if err := mapper.MapStructsWithJSONTags(category, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *categoryService) Update(ctx context.Context, id string, req *payload.CategoryRequest) error {
+	if id == "" {
+		return portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	if err := req.Validate(); err != nil {
+		return portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	category, err := s.categoryRepo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := mapper.MapStructsWithJSONTags(req, category); err != nil {
+		return err
+	}
+
+	category.Id = id
+
+	// The tree is checked within the transaction storing the move, so that two
+	// concurrent moves cannot make a cycle.
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.checkParent(ctx, id, req.ParentId); err != nil {
+			return err
+		}
+
+		if err := s.categoryRepo.Update(ctx, category); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.CategoryUpdated, category.Id, category)
+	})
+}
+
+func (s *categoryService) FindAll(ctx context.Context) ([]*payload.CategoryResponse, error) {
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.CategoryResponse{}
+	for _, category := range categories {
+		res := &payload.CategoryResponse{}
+		if err := mapper.MapStructsWithJSONTags(category, res); err != nil {
+			return nil, err
+		}
+		list = append(list, res)
+	}
+
+	return list, nil
+}
+
+// Delete refuses to delete a category that still has subcategories or books,
+// checked within the transaction deleting it.
+func (s *categoryService) Delete(ctx context.Context, id string) error {
+	if _, err := s.Find(ctx, id); err != nil {
+		return err
+	}
+
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		categories, err := s.categoryRepo.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, category := range categories {
+			if category.ParentId == id {
+				return portError.NewConflictError("Category has subcategories.", nil)
+			}
+		}
+
+		books, err := s.bookRepo.FindByCategories(ctx, []string{id})
+		if err != nil {
+			return err
+		}
+
+		if len(books) > 0 {
+			return portError.NewConflictError("Category has books.", nil)
+		}
+
+		if err := s.categoryRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.CategoryDeleted, id, nil)
+	})
+}
+
+// FindBooks lists the books of the category and of all its descendants.
+func (s *categoryService) FindBooks(ctx context.Context, id string) ([]*payload.BookResponse, error) {
+	if _, err := s.Find(ctx, id); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[string][]string{}
+	for _, category := range categories {
+		children[category.ParentId] = append(children[category.ParentId], category.Id)
+	}
+
+	ids := []string{id}
+	seen := map[string]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+
+	books, err := s.bookRepo.FindByCategories(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.BookResponse{}
+	for _, book := range books {
+		res, err := newBookResponse(ctx, book)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, res)
+	}
+
+	return list, nil
+}
+
+// checkParent checks that parentId exists, failing with a bad request
+// otherwise, and, when id is set, that it is neither the category itself nor
+// one of its descendants.
+func (s *categoryService) checkParent(ctx context.Context, id, parentId string) error {
+	if parentId == "" {
+		return nil
+	}
+
+	if parentId == id {
+		return portError.NewBadRequestError("A category cannot be its own parent.", nil)
+	}
+
+	if _, err := s.categoryRepo.Find(ctx, parentId); err != nil {
+		var apiErr *portError.ApiError
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+			return portError.NewBadRequestError("parentId: category not found", err)
+		}
+		return err
+	}
+
+	if id == "" {
+		return nil
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	parents := map[string]string{}
+	for _, category := range categories {
+		parents[category.Id] = category.ParentId
+	}
+
+	seen := map[string]bool{}
+	for ancestor := parentId; ancestor != "" && !seen[ancestor]; ancestor = parents[ancestor] {
+		seen[ancestor] = true
+		if ancestor == id {
+			return portError.NewBadRequestError("A category cannot be moved under its own subcategory.", nil)
+		}
+	}
+
+	return nil
+}
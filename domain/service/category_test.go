@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	memoryrepo "bookstore.com/repository/memory"
+	"bookstore.com/test"
+	"go.uber.org/mock/gomock"
+)
+
+// categoryTree returns CategoryId1 with its child CategoryId2, itself the
+// parent of CategoryId3.
+func categoryTree() []*entity.Category {
+	return []*entity.Category{
+		{Id: test.CategoryId1, Name: test.CategoryName1},
+		{Id: test.CategoryId2, Name: test.CategoryName1, ParentId: test.CategoryId1},
+		{Id: test.CategoryId3, Name: test.CategoryName1, ParentId: test.CategoryId2},
+	}
+}
+
+func apiStatus(err error) int {
+	var apiErr *portError.ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+
+	return 0
+}
+
+func Test_categoryService_Store(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name         string
+		categoryRepo func() repository.CategoryRepository
+		req          *payload.CategoryRequest
+		want         *payload.CategoryResponse
+		wantStatus   int
+	}{
+		{
+			name: "store category successfully",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(&entity.Category{Id: test.CategoryId1}, nil)
+				categoryRepo.EXPECT().Store(gomock.Any(), &entity.Category{
+					Name:     test.CategoryName1,
+					ParentId: test.CategoryId1,
+				}).DoAndReturn(func(ctx context.Context, category *entity.Category) error {
+					category.Id = test.CategoryId2
+					category.CreatedAt = test.CreatedAt
+					category.UpdatedAt = test.UpdatedAt
+					return nil
+				})
+
+				return categoryRepo
+			},
+			req: &payload.CategoryRequest{Name: test.CategoryName1, ParentId: test.CategoryId1},
+			want: &payload.CategoryResponse{
+				Id:        test.CategoryId2,
+				Name:      test.CategoryName1,
+				ParentId:  test.CategoryId1,
+				CreatedAt: test.CreatedAtStr,
+				UpdatedAt: test.UpdatedAtStr,
+			},
+		},
+		{
+			name: "store category failed because name is empty",
+			categoryRepo: func() repository.CategoryRepository {
+				return repository.NewMockCategoryRepository(ctrl)
+			},
+			req:        &payload.CategoryRequest{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store category failed because parent not found",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(nil, portError.NewNotFoundError("Category not found.", nil))
+
+				return categoryRepo
+			},
+			req:        &payload.CategoryRequest{Name: test.CategoryName1, ParentId: test.CategoryId1},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCategoryService(tt.categoryRepo(), repository.NewMockBookRepository(ctrl), nil, nil)
+			got, err := s.Store(context.TODO(), tt.req)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("categoryService.Store() error = %v, want status %v", err, tt.wantStatus)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("categoryService.Store() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_categoryService_publishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	categoryRepo := repository.NewMockCategoryRepository(ctrl)
+	categoryRepo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, category *entity.Category) error {
+		category.Id = test.CategoryId1
+		return nil
+	})
+	categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(categoryTree()[0], nil).Times(2)
+	categoryRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categoryTree()[:1], nil)
+	categoryRepo.EXPECT().Delete(gomock.Any(), test.CategoryId1).Return(nil)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().FindByCategories(gomock.Any(), []string{test.CategoryId1}).Return([]*entity.Book{}, nil)
+	publisher := memoryrepo.NewPublisher()
+
+	s := NewCategoryService(categoryRepo, bookRepo, publisher, nil)
+	ctx := event.ContextWithActor(context.TODO(), "test_name")
+	if _, err := s.Store(ctx, &payload.CategoryRequest{Name: test.CategoryName1}); err != nil {
+		t.Fatalf("categoryService.Store() error = %v", err)
+	}
+	if err := s.Update(ctx, test.CategoryId1, &payload.CategoryRequest{Name: test.CategoryName1}); err != nil {
+		t.Fatalf("categoryService.Update() error = %v", err)
+	}
+	if err := s.Delete(ctx, test.CategoryId1); err != nil {
+		t.Fatalf("categoryService.Delete() error = %v", err)
+	}
+
+	want := []event.Type{event.CategoryCreated, event.CategoryUpdated, event.CategoryDeleted}
+	events := publisher.Events()
+	if len(events) != len(want) {
+		t.Fatalf("categoryService published %d events, want %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if e.Type != want[i] || e.EntityId != test.CategoryId1 || e.Actor != "test_name" {
+			t.Errorf("categoryService published %+v, want a %s", e, want[i])
+		}
+	}
+}
+
+func Test_categoryService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name         string
+		categoryRepo func() repository.CategoryRepository
+		id           string
+		req          *payload.CategoryRequest
+		wantStatus   int
+	}{
+		{
+			name: "move category successfully",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId3).Return(categoryTree()[2], nil)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(categoryTree()[0], nil)
+				categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categoryTree(), nil)
+				categoryRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, category *entity.Category) error {
+					if category.Id != test.CategoryId3 || category.ParentId != test.CategoryId1 {
+						t.Errorf("categoryRepository.Update() category = %v", category)
+					}
+					return nil
+				})
+
+				return categoryRepo
+			},
+			id:  test.CategoryId3,
+			req: &payload.CategoryRequest{Name: test.CategoryName1, ParentId: test.CategoryId1},
+		},
+		{
+			name: "update category failed because it is its own parent",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(categoryTree()[0], nil)
+
+				return categoryRepo
+			},
+			id:         test.CategoryId1,
+			req:        &payload.CategoryRequest{Name: test.CategoryName1, ParentId: test.CategoryId1},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "update category failed because parent is a descendant",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(categoryTree()[0], nil)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId3).Return(categoryTree()[2], nil)
+				categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categoryTree(), nil)
+
+				return categoryRepo
+			},
+			id:         test.CategoryId1,
+			req:        &payload.CategoryRequest{Name: test.CategoryName1, ParentId: test.CategoryId3},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "update category failed because parent not found",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId3).Return(categoryTree()[2], nil)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(nil, portError.NewNotFoundError("Category not found.", nil))
+
+				return categoryRepo
+			},
+			id:         test.CategoryId3,
+			req:        &payload.CategoryRequest{Name: test.CategoryName1, ParentId: test.CategoryId1},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCategoryService(tt.categoryRepo(), repository.NewMockBookRepository(ctrl), nil, nil)
+			err := s.Update(context.TODO(), tt.id, tt.req)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("categoryService.Update() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_categoryService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name         string
+		categoryRepo func() repository.CategoryRepository
+		bookRepo     func() repository.BookRepository
+		id           string
+		wantStatus   int
+	}{
+		{
+			name: "delete category successfully",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId3).Return(categoryTree()[2], nil)
+				categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categoryTree(), nil)
+				categoryRepo.EXPECT().Delete(gomock.Any(), test.CategoryId3).Return(nil)
+
+				return categoryRepo
+			},
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindByCategories(gomock.Any(), []string{test.CategoryId3}).Return([]*entity.Book{}, nil)
+
+				return bookRepo
+			},
+			id: test.CategoryId3,
+		},
+		{
+			name: "delete category failed because it has subcategories",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId2).Return(categoryTree()[1], nil)
+				categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categoryTree(), nil)
+
+				return categoryRepo
+			},
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			id:         test.CategoryId2,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "delete category failed because it has books",
+			categoryRepo: func() repository.CategoryRepository {
+				categoryRepo := repository.NewMockCategoryRepository(ctrl)
+				categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId3).Return(categoryTree()[2], nil)
+				categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categoryTree(), nil)
+
+				return categoryRepo
+			},
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindByCategories(gomock.Any(), []string{test.CategoryId3}).Return([]*entity.Book{{Id: test.BookId1}}, nil)
+
+				return bookRepo
+			},
+			id:         test.CategoryId3,
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCategoryService(tt.categoryRepo(), tt.bookRepo(), nil, nil)
+			err := s.Delete(context.TODO(), tt.id)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("categoryService.Delete() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_categoryService_checksInTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inTx := false
+	tx := repository.NewMockTransactor(ctrl)
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			inTx = true
+			defer func() { inTx = false }()
+			return fn(ctx)
+		},
+	).Times(2)
+	inTxFindAll := func(ctx context.Context) ([]*entity.Category, error) {
+		if !inTx {
+			t.Errorf("categoryRepository.FindAll() called outside the transaction")
+		}
+		return categoryTree(), nil
+	}
+	categoryRepo := repository.NewMockCategoryRepository(ctrl)
+	categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId3).Return(categoryTree()[2], nil).Times(2)
+	categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(categoryTree()[0], nil)
+	categoryRepo.EXPECT().FindAll(gomock.Any()).DoAndReturn(inTxFindAll).Times(2)
+	categoryRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	categoryRepo.EXPECT().Delete(gomock.Any(), test.CategoryId3).Return(nil)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().FindByCategories(gomock.Any(), []string{test.CategoryId3}).DoAndReturn(func(ctx context.Context, ids []string) ([]*entity.Book, error) {
+		if !inTx {
+			t.Errorf("bookRepository.FindByCategories() called outside the transaction")
+		}
+		return []*entity.Book{}, nil
+	})
+
+	s := NewCategoryService(categoryRepo, bookRepo, nil, tx)
+	if err := s.Update(context.TODO(), test.CategoryId3, &payload.CategoryRequest{Name: test.CategoryName1, ParentId: test.CategoryId1}); err != nil {
+		t.Errorf("categoryService.Update() error = %v", err)
+	}
+	if err := s.Delete(context.TODO(), test.CategoryId3); err != nil {
+		t.Errorf("categoryService.Delete() error = %v", err)
+	}
+}
+
+func Test_categoryService_FindBooks_cycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	categoryRepo := repository.NewMockCategoryRepository(ctrl)
+	categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(categoryTree()[0], nil)
+	categoryRepo.EXPECT().FindAll(gomock.Any()).Return([]*entity.Category{
+		{Id: test.CategoryId1, ParentId: test.CategoryId2},
+		{Id: test.CategoryId2, ParentId: test.CategoryId1},
+	}, nil)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().FindByCategories(gomock.Any(), []string{test.CategoryId1, test.CategoryId2}).Return([]*entity.Book{}, nil)
+
+	s := NewCategoryService(categoryRepo, bookRepo, nil, nil)
+	if _, err := s.FindBooks(context.TODO(), test.CategoryId1); err != nil {
+		t.Errorf("categoryService.FindBooks() error = %v", err)
+	}
+}
+
+func Test_categoryService_FindBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	categoryRepo := repository.NewMockCategoryRepository(ctrl)
+	categoryRepo.EXPECT().Find(gomock.Any(), test.CategoryId1).Return(categoryTree()[0], nil)
+	categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categoryTree(), nil)
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().FindByCategories(gomock.Any(), []string{test.CategoryId1, test.CategoryId2, test.CategoryId3}).Return([]*entity.Book{
+		{Id: test.BookId1, CategoryIds: []string{test.CategoryId3}},
+	}, nil)
+
+	s := NewCategoryService(categoryRepo, bookRepo, nil, nil)
+	got, err := s.FindBooks(context.TODO(), test.CategoryId1)
+	if err != nil {
+		t.Fatalf("categoryService.FindBooks() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Id != test.BookId1 || !reflect.DeepEqual(got[0].CategoryIds, []string{test.CategoryId3}) {
+		t.Errorf("categoryService.FindBooks() = %v", got)
+	}
+}
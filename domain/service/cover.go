@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+)
+
+type coverService struct {
+	bookRepo repository.BookRepository
+	images   *imageStore
+}
+
+// NewCoverService stores the covers in blobs. maxSize is the largest cover
+// accepted in bytes.
+func NewCoverService(bookRepo repository.BookRepository, blobs repository.BlobStore, maxSize int64) CoverService {
+	return &coverService{
+		bookRepo: bookRepo,
+		images:   &imageStore{blobs: blobs, maxSize: maxSize},
+	}
+}
+
+// Upload stores the cover of the book with its thumbnails. Every upload is a
+// new version, the blobs of the previous one are removed once the book points
+// to the new one.
+func (s *coverService) Upload(ctx context.Context, bookId string, req *payload.ImageRequest) (*payload.CoverResponse, error) {
+	if bookId == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	img, err := s.images.decode("cover", req)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.bookRepo.Find(ctx, bookId)
+	if err != nil {
+		return nil, err
+	}
+
+	coverId, err := s.images.put(ctx, coverPrefix(bookId), req, img)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.bookRepo.SetCover(ctx, bookId, coverId); err != nil {
+		s.images.delete(ctx, coverPrefix(bookId), coverId)
+		return nil, err
+	}
+
+	if book.CoverId != "" {
+		s.images.delete(ctx, coverPrefix(bookId), book.CoverId)
+	}
+
+	res := &payload.CoverResponse{}
+	res.CoverUrl, res.Sizes = imageUrls(coverUrl(bookId), coverId)
+	return res, nil
+}
+
+// Find returns the current cover of the book in the given size, the original
+// when size is empty.
+func (s *coverService) Find(ctx context.Context, bookId, size string) (*payload.ImageFile, error) {
+	if bookId == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	size, err := checkImageSize(size)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.bookRepo.Find(ctx, bookId)
+	if err != nil {
+		return nil, err
+	}
+	if book.CoverId == "" {
+		return nil, portError.NewNotFoundError("Cover not found.", nil)
+	}
+
+	return s.images.get(ctx, coverPrefix(bookId), book.CoverId, size)
+}
+
+func coverPrefix(bookId string) string {
+	return "covers/" + bookId
+}
+
+func coverUrl(bookId string) string {
+	return fmt.Sprintf("/api/v1/books/%s/cover", bookId)
+}
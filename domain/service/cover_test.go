@@ -0,0 +1,220 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/test"
+	"go.uber.org/mock/gomock"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func Test_coverService_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cover := testPNG(t, 600, 900)
+	tests := []struct {
+		name       string
+		req        *payload.ImageRequest
+		book       *entity.Book
+		findErr    error
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name: "upload cover successfully",
+			req:  &payload.ImageRequest{ContentType: "image/png", Data: cover},
+			book: &entity.Book{Id: test.BookId1},
+		},
+		{
+			name: "replace cover successfully",
+			req:  &payload.ImageRequest{ContentType: "image/png", Data: cover},
+			book: &entity.Book{Id: test.BookId1, CoverId: "old"},
+		},
+		{
+			name:       "upload cover failed because it is empty",
+			req:        &payload.ImageRequest{ContentType: "image/png"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "upload cover failed because it is too large",
+			req:        &payload.ImageRequest{ContentType: "image/png", Data: make([]byte, 1<<20+1)},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "upload cover failed because the content type is not allowed",
+			req:        &payload.ImageRequest{ContentType: "image/gif", Data: []byte("GIF89a")},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "upload cover failed because the content does not match the content type",
+			req:        &payload.ImageRequest{ContentType: "image/jpeg", Data: cover},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "upload cover failed because the image is broken",
+			req:        &payload.ImageRequest{ContentType: "image/png", Data: cover[:64]},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "upload cover failed because book not found",
+			req:     &payload.ImageRequest{ContentType: "image/png", Data: cover},
+			findErr: errors.New("book not found"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			blobs := repository.NewMockBlobStore(ctrl)
+			if tt.book != nil || tt.findErr != nil {
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(tt.book, tt.findErr)
+			}
+
+			var coverId string
+			var sizes []string
+			if tt.book != nil {
+				blobs.EXPECT().Put(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(ctx context.Context, blob *entity.Blob) error {
+					parts := strings.Split(blob.Key, "/")
+					if len(parts) != 4 || parts[0] != "covers" || parts[1] != test.BookId1 {
+						t.Errorf("blobStore.Put() key = %q", blob.Key)
+						return nil
+					}
+					coverId = parts[2]
+					sizes = append(sizes, parts[3])
+
+					img, err := png.Decode(bytes.NewReader(blob.Data))
+					if err != nil {
+						t.Errorf("blobStore.Put() %s is not a png: %v", parts[3], err)
+						return nil
+					}
+					if width, ok := imageWidths[parts[3]]; ok && img.Bounds().Dx() != width {
+						t.Errorf("blobStore.Put() %s width = %d, want %d", parts[3], img.Bounds().Dx(), width)
+					}
+					return nil
+				})
+				bookRepo.EXPECT().SetCover(gomock.Any(), test.BookId1, gomock.Any()).DoAndReturn(func(ctx context.Context, id, newCoverId string) error {
+					if newCoverId != coverId {
+						t.Errorf("bookRepository.SetCover() coverId = %q, want %q", newCoverId, coverId)
+					}
+					return nil
+				})
+			}
+			if tt.book != nil && tt.book.CoverId != "" {
+				for _, size := range []string{ImageOriginal, ImageSmall, ImageMedium} {
+					blobs.EXPECT().Delete(gomock.Any(), "covers/"+test.BookId1+"/old/"+size).Return(nil)
+				}
+			}
+
+			s := NewCoverService(bookRepo, blobs, 1<<20)
+			got, err := s.Upload(context.TODO(), test.BookId1, tt.req)
+			if (err != nil) != (tt.wantErr || tt.wantStatus != 0) {
+				t.Fatalf("coverService.Upload() error = %v", err)
+			}
+			if tt.wantStatus != 0 && apiStatus(err) != tt.wantStatus {
+				t.Errorf("coverService.Upload() error = %v, want status %v", err, tt.wantStatus)
+			}
+			if err != nil {
+				return
+			}
+
+			sort.Strings(sizes)
+			if !reflect.DeepEqual(sizes, []string{ImageMedium, ImageOriginal, ImageSmall}) {
+				t.Errorf("blobStore.Put() sizes = %v", sizes)
+			}
+			want := "/api/v1/books/" + test.BookId1 + "/cover?v=" + coverId
+			if got.CoverUrl != want {
+				t.Errorf("coverService.Upload() coverUrl = %q, want %q", got.CoverUrl, want)
+			}
+			if got.Sizes[ImageSmall] != "/api/v1/books/"+test.BookId1+"/cover?size=small&v="+coverId {
+				t.Errorf("coverService.Upload() sizes = %v", got.Sizes)
+			}
+		})
+	}
+}
+
+func Test_coverService_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		size       string
+		book       *entity.Book
+		wantKey    string
+		wantStatus int
+	}{
+		{
+			name:    "find original cover successfully",
+			book:    &entity.Book{Id: test.BookId1, CoverId: "current"},
+			wantKey: "covers/" + test.BookId1 + "/current/original",
+		},
+		{
+			name:    "find thumbnail successfully",
+			size:    ImageSmall,
+			book:    &entity.Book{Id: test.BookId1, CoverId: "current"},
+			wantKey: "covers/" + test.BookId1 + "/current/small",
+		},
+		{
+			name:       "find cover failed because the book has none",
+			book:       &entity.Book{Id: test.BookId1},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "find cover failed because the size is unknown",
+			size:       "huge",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			blobs := repository.NewMockBlobStore(ctrl)
+			if tt.book != nil {
+				bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(tt.book, nil)
+			}
+			if tt.wantKey != "" {
+				blobs.EXPECT().Get(gomock.Any(), tt.wantKey).Return(&entity.Blob{
+					Key:         tt.wantKey,
+					ContentType: "image/png",
+					Data:        []byte("png"),
+				}, nil)
+			}
+
+			s := NewCoverService(bookRepo, blobs, 1<<20)
+			got, err := s.Find(context.TODO(), test.BookId1, tt.size)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Fatalf("coverService.Find() error = %v, want status %v", err, tt.wantStatus)
+			}
+			if err != nil {
+				return
+			}
+			if got.Version != "current" || got.ContentType != "image/png" || string(got.Data) != "png" {
+				t.Errorf("coverService.Find() = %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"bookstore.com/domain/event"
+	"bookstore.com/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// publish raises an event about a change. Services publish to the outbox
+// within the transaction storing the change, so a failure rolls the change
+// back.
+func publish(ctx context.Context, publisher event.Publisher, t event.Type, entityId string, payload any) (err error) {
+	if publisher == nil {
+		return nil
+	}
+
+	ctx, span := startSpan(ctx, "Publish "+string(t),
+		attribute.String("event.type", string(t)),
+		attribute.String("event.entity_id", entityId),
+	)
+	defer func() { endSpan(span, err) }()
+
+	e, err := event.New(ctx, t, entityId, payload)
+	if err != nil {
+		return err
+	}
+
+	return publisher.Publish(ctx, e)
+}
+
+// withinTransaction runs fn in a transaction when the service was given a
+// Transactor, and directly otherwise.
+func withinTransaction(ctx context.Context, tx repository.Transactor, fn func(ctx context.Context) error) error {
+	if tx == nil {
+		return fn(ctx)
+	}
+
+	return tx.WithinTransaction(ctx, fn)
+}
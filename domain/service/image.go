@@ -0,0 +1,170 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"net/http"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/tools/logger"
+	"bookstore.com/tools/thumbnail"
+	"github.com/google/uuid"
+)
+
+const (
+	ImageOriginal = "original"
+	ImageSmall    = "small"
+	ImageMedium   = "medium"
+)
+
+// imageWidths are the widths of the generated thumbnails.
+var imageWidths = map[string]int{
+	ImageSmall:  150,
+	ImageMedium: 400,
+}
+
+// maxImagePixels bounds the decoded size of an image, whatever its file size.
+const maxImagePixels = 50_000_000
+
+// imageStore keeps uploaded images with their thumbnails in blobs. Every
+// upload is a new version stored under <prefix>/<version>/<size>, so the URL
+// of a version never changes content.
+type imageStore struct {
+	blobs   repository.BlobStore
+	maxSize int64
+}
+
+// decode checks the uploaded image. name is used in the error messages.
+func (s *imageStore) decode(name string, req *payload.ImageRequest) (image.Image, error) {
+	if len(req.Data) == 0 {
+		return nil, portError.NewBadRequestError(name+": field required", nil)
+	}
+
+	if int64(len(req.Data)) > s.maxSize {
+		return nil, portError.NewRequestEntityTooLargeError(fmt.Sprintf("The %s is larger than %d bytes.", name, s.maxSize), nil)
+	}
+
+	if req.ContentType != "image/jpeg" && req.ContentType != "image/png" {
+		return nil, portError.NewBadRequestError(fmt.Sprintf("The %s must be a JPEG or PNG image.", name), nil)
+	}
+
+	if http.DetectContentType(req.Data) != req.ContentType {
+		return nil, portError.NewBadRequestError(fmt.Sprintf("The %s content does not match its content type.", name), nil)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(req.Data))
+	if err != nil {
+		return nil, portError.NewBadRequestError(fmt.Sprintf("The %s is not a valid image.", name), err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, portError.NewBadRequestError(fmt.Sprintf("The %s has too many pixels.", name), nil)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(req.Data))
+	if err != nil {
+		return nil, portError.NewBadRequestError(fmt.Sprintf("The %s is not a valid image.", name), err)
+	}
+
+	return img, nil
+}
+
+// put stores a new version of the image with its thumbnails and returns it.
+// Nothing is left behind when it fails.
+func (s *imageStore) put(ctx context.Context, prefix string, req *payload.ImageRequest, img image.Image) (string, error) {
+	sizes := map[string][]byte{ImageOriginal: req.Data}
+	for size, width := range imageWidths {
+		data, err := encodeImage(thumbnail.Scale(img, width), req.ContentType)
+		if err != nil {
+			return "", err
+		}
+		sizes[size] = data
+	}
+
+	version := uuid.NewString()
+	for size, data := range sizes {
+		blob := &entity.Blob{Key: imageKey(prefix, version, size), ContentType: req.ContentType, Data: data}
+		if err := s.blobs.Put(ctx, blob); err != nil {
+			s.delete(ctx, prefix, version)
+			return "", err
+		}
+	}
+
+	return version, nil
+}
+
+// get returns a version of the image in the given size, the original when
+// size is empty.
+func (s *imageStore) get(ctx context.Context, prefix, version, size string) (*payload.ImageFile, error) {
+	blob, err := s.blobs.Get(ctx, imageKey(prefix, version, size))
+	if err != nil {
+		return nil, err
+	}
+
+	return &payload.ImageFile{
+		Version:     version,
+		ContentType: blob.ContentType,
+		Data:        blob.Data,
+		UpdatedAt:   blob.UpdatedAt,
+	}, nil
+}
+
+// delete removes the blobs of a version. It is best effort: a left over blob
+// is never served again, so a failure is only logged.
+func (s *imageStore) delete(ctx context.Context, prefix, version string) {
+	for _, size := range []string{ImageOriginal, ImageSmall, ImageMedium} {
+		if err := s.blobs.Delete(ctx, imageKey(prefix, version, size)); err != nil {
+			logger.FromContext(ctx).Warn("failed to delete image",
+				slog.String("key", imageKey(prefix, version, size)), slog.Any("error", err))
+		}
+	}
+}
+
+// checkImageSize returns size, defaulting to the original.
+func checkImageSize(size string) (string, error) {
+	if size == "" {
+		return ImageOriginal, nil
+	}
+	if _, ok := imageWidths[size]; !ok && size != ImageOriginal {
+		return "", portError.NewBadRequestError(fmt.Sprintf("size: invalid, want one of %s, %s, %s", ImageOriginal, ImageSmall, ImageMedium), nil)
+	}
+
+	return size, nil
+}
+
+// imageUrls returns the URL of a version of the image served at url and the
+// URLs of its thumbnails.
+func imageUrls(url, version string) (string, map[string]string) {
+	sizes := map[string]string{}
+	for size := range imageWidths {
+		sizes[size] = fmt.Sprintf("%s?size=%s&v=%s", url, size, version)
+	}
+
+	return fmt.Sprintf("%s?v=%s", url, version), sizes
+}
+
+func imageKey(prefix, version, size string) string {
+	return fmt.Sprintf("%s/%s/%s", prefix, version, size)
+}
+
+func encodeImage(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
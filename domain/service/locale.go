@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/port/payload"
+	"bookstore.com/tools/locale"
+)
+
+// localizeBook sets the name and description of res in the first language
+// requested in ctx the book has, in its own language otherwise.
+func localizeBook(ctx context.Context, book *entity.Book, res *payload.BookResponse) {
+	if res.Translations == nil {
+		res.Translations = map[string]*payload.BookTranslationResponse{}
+	}
+	res.ContentLanguage = book.Language
+
+	available := []string{}
+	if book.Language != "" {
+		available = append(available, book.Language)
+	}
+	for tag := range book.Translations {
+		available = append(available, tag)
+	}
+
+	tag, ok := locale.Lookup(locale.FromContext(ctx), available)
+	if !ok || tag == book.Language {
+		return
+	}
+
+	t := book.Translations[tag]
+	res.Name, res.Description, res.ContentLanguage = t.Name, t.Description, tag
+}
+
+// localizeAuthor sets the names of res in the first language requested in ctx
+// the author has, leaving the untranslated names otherwise.
+func localizeAuthor(ctx context.Context, author *entity.Author, res *payload.AuthorResponse) {
+	if res.Translations == nil {
+		res.Translations = map[string]*payload.AuthorTranslationResponse{}
+	}
+
+	available := []string{}
+	for tag := range author.Translations {
+		available = append(available, tag)
+	}
+
+	tag, ok := locale.Lookup(locale.FromContext(ctx), available)
+	if !ok {
+		return
+	}
+
+	t := author.Translations[tag]
+	res.FirstName, res.LastName, res.ContentLanguage = t.FirstName, t.LastName, tag
+}
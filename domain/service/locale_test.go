@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/test"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_authorService_Store_translations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name             string
+		translations     map[string]*payload.AuthorTranslationRequest
+		wantTranslations map[string]*entity.AuthorTranslation
+		wantStatus       int
+	}{
+		{
+			name:             "store author with translations successfully",
+			translations:     map[string]*payload.AuthorTranslationRequest{"RU": {FirstName: "Лев", LastName: "Толстой"}},
+			wantTranslations: map[string]*entity.AuthorTranslation{"ru": {FirstName: "Лев", LastName: "Толстой"}},
+		},
+		{
+			name:         "store author failed because a language is invalid",
+			translations: map[string]*payload.AuthorTranslationRequest{"russian": {FirstName: "Лев", LastName: "Толстой"}},
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:         "store author failed because a translation has no last name",
+			translations: map[string]*payload.AuthorTranslationRequest{"ru": {FirstName: "Лев"}},
+			wantStatus:   http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			if tt.wantStatus == 0 {
+				authorRepo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, author *entity.Author) error {
+					if !reflect.DeepEqual(author.Translations, tt.wantTranslations) {
+						t.Errorf("authorRepository.Store() translations = %v, want %v", author.Translations, tt.wantTranslations)
+					}
+					return nil
+				})
+			}
+
+			s := NewAuthorService(authorRepo, nil, nil, nil)
+			err := s.Store(context.TODO(), &payload.AuthorRequest{
+				FirstName:    test.AuthorFirstName1,
+				LastName:     test.AuthorLastName1,
+				BirthDate:    test.AuthorBirthDate1,
+				Nationality:  test.AuthorNationality1,
+				Translations: tt.translations,
+			})
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("authorService.Store() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
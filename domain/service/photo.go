@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+)
+
+type photoService struct {
+	authorRepo repository.AuthorRepository
+	images     *imageStore
+}
+
+// NewPhotoService stores the author photos in blobs. maxSize is the largest
+// photo accepted in bytes.
+func NewPhotoService(authorRepo repository.AuthorRepository, blobs repository.BlobStore, maxSize int64) PhotoService {
+	return &photoService{
+		authorRepo: authorRepo,
+		images:     &imageStore{blobs: blobs, maxSize: maxSize},
+	}
+}
+
+// Upload stores the photo of the author with its thumbnails, replacing the
+// previous one like a book cover.
+func (s *photoService) Upload(ctx context.Context, authorId string, req *payload.ImageRequest) (*payload.PhotoResponse, error) {
+	if authorId == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	img, err := s.images.decode("photo", req)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := s.authorRepo.Find(ctx, authorId)
+	if err != nil {
+		return nil, err
+	}
+
+	photoId, err := s.images.put(ctx, photoPrefix(authorId), req, img)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorRepo.SetPhoto(ctx, authorId, photoId); err != nil {
+		s.images.delete(ctx, photoPrefix(authorId), photoId)
+		return nil, err
+	}
+
+	if author.PhotoId != "" {
+		s.images.delete(ctx, photoPrefix(authorId), author.PhotoId)
+	}
+
+	res := &payload.PhotoResponse{}
+	res.PhotoUrl, res.Sizes = imageUrls(photoUrl(authorId), photoId)
+	return res, nil
+}
+
+// Find returns the current photo of the author in the given size, the
+// original when size is empty.
+func (s *photoService) Find(ctx context.Context, authorId, size string) (*payload.ImageFile, error) {
+	if authorId == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	size, err := checkImageSize(size)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := s.authorRepo.Find(ctx, authorId)
+	if err != nil {
+		return nil, err
+	}
+	if author.PhotoId == "" {
+		return nil, portError.NewNotFoundError("Photo not found.", nil)
+	}
+
+	return s.images.get(ctx, photoPrefix(authorId), author.PhotoId, size)
+}
+
+func photoPrefix(authorId string) string {
+	return "photos/" + authorId
+}
+
+func photoUrl(authorId string) string {
+	return fmt.Sprintf("/api/v1/authors/%s/photo", authorId)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/test"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_photoService_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	photo := testPNG(t, 300, 400)
+	tests := []struct {
+		name       string
+		req        *payload.ImageRequest
+		author     *entity.Author
+		wantStatus int
+	}{
+		{
+			name:   "upload photo successfully",
+			req:    &payload.ImageRequest{ContentType: "image/png", Data: photo},
+			author: &entity.Author{Id: test.AuthorId1},
+		},
+		{
+			name:   "replace photo successfully",
+			req:    &payload.ImageRequest{ContentType: "image/png", Data: photo},
+			author: &entity.Author{Id: test.AuthorId1, PhotoId: "old"},
+		},
+		{
+			name:       "upload photo failed because the content type is not allowed",
+			req:        &payload.ImageRequest{ContentType: "text/plain", Data: []byte("photo")},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorRepo := repository.NewMockAuthorRepository(ctrl)
+			blobs := repository.NewMockBlobStore(ctrl)
+			var photoId string
+			if tt.author != nil {
+				authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(tt.author, nil)
+				blobs.EXPECT().Put(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(ctx context.Context, blob *entity.Blob) error {
+					parts := strings.Split(blob.Key, "/")
+					if len(parts) != 4 || parts[0] != "photos" || parts[1] != test.AuthorId1 {
+						t.Errorf("blobStore.Put() key = %q", blob.Key)
+						return nil
+					}
+					photoId = parts[2]
+					return nil
+				})
+				authorRepo.EXPECT().SetPhoto(gomock.Any(), test.AuthorId1, gomock.Any()).Return(nil)
+			}
+			if tt.author != nil && tt.author.PhotoId != "" {
+				for _, size := range []string{ImageOriginal, ImageSmall, ImageMedium} {
+					blobs.EXPECT().Delete(gomock.Any(), "photos/"+test.AuthorId1+"/old/"+size).Return(nil)
+				}
+			}
+
+			s := NewPhotoService(authorRepo, blobs, 1<<20)
+			got, err := s.Upload(context.TODO(), test.AuthorId1, tt.req)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Fatalf("photoService.Upload() error = %v, want status %v", err, tt.wantStatus)
+			}
+			if err != nil {
+				return
+			}
+			if want := "/api/v1/authors/" + test.AuthorId1 + "/photo?v=" + photoId; got.PhotoUrl != want {
+				t.Errorf("photoService.Upload() photoUrl = %q, want %q", got.PhotoUrl, want)
+			}
+		})
+	}
+}
+
+func Test_photoService_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorRepo := repository.NewMockAuthorRepository(ctrl)
+	authorRepo.EXPECT().Find(gomock.Any(), test.AuthorId1).Return(&entity.Author{Id: test.AuthorId1}, nil)
+
+	s := NewPhotoService(authorRepo, repository.NewMockBlobStore(ctrl), 1<<20)
+	if _, err := s.Find(context.TODO(), test.AuthorId1, ""); apiStatus(err) != http.StatusNotFound {
+		t.Errorf("photoService.Find() without photo error = %v, want not found", err)
+	}
+}
+
+func Test_bookService_Find_authorPhotoUrl(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	author := &entity.Author{Id: test.AuthorId1, PhotoId: "current"}
+	bookRepo := repository.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().Find(gomock.Any(), test.BookId1).Return(&entity.Book{
+		Id:           test.BookId1,
+		AuthorId:     test.AuthorId1,
+		Author:       author,
+		Contributors: []*entity.Contributor{{AuthorId: test.AuthorId1, Role: entity.ContributorAuthor, Author: author}},
+	}, nil)
+
+	s := NewBookService(bookRepo, nil, nil, nil, nil, nil, nil, nil)
+	got, err := s.Find(context.TODO(), test.BookId1)
+	if err != nil {
+		t.Fatalf("bookService.Find() error = %v", err)
+	}
+	want := "/api/v1/authors/" + test.AuthorId1 + "/photo?v=current"
+	if got.Author.PhotoUrl != want || got.Contributors[0].Author.PhotoUrl != want {
+		t.Errorf("bookService.Find() author photoUrl = %q %q, want %q", got.Author.PhotoUrl, got.Contributors[0].Author.PhotoUrl, want)
+	}
+}
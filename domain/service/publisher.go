@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/tools/mapper"
+)
+
+type publisherService struct {
+	publisherRepo repository.PublisherRepository
+	bookRepo      repository.BookRepository
+	publisher     event.Publisher
+	tx            repository.Transactor
+}
+
+func NewPublisherService(
+	publisherRepo repository.PublisherRepository,
+	bookRepo repository.BookRepository,
+	publisher event.Publisher,
+	tx repository.Transactor,
+) PublisherService {
+	return &publisherService{publisherRepo: publisherRepo, bookRepo: bookRepo, publisher: publisher, tx: tx}
+}
+
+func (s *publisherService) Find(ctx context.Context, id string) (*payload.PublisherResponse, error) {
+	if id == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	publisher, err := s.publisherRepo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &payload.PublisherResponse{}
+	if err := mapper.MapStructsWithJSONTags(publisher, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *publisherService) Store(ctx context.Context, req *payload.PublisherRequest) error {
+	if err := req.Validate(); err != nil {
+		return portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	publisher := &entity.Publisher{}
+	if err := mapper.MapStructsWithJSONTags(req, publisher); err != nil {
+		return err
+	}
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.publisherRepo.Store(ctx, publisher); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.PublisherCreated, publisher.Id, publisher)
+	})
+}
+
+func (s *publisherService) Update(ctx context.Context, id string, req *payload.PublisherRequest) error {
+	if id == "" {
+		return portError.NewBadRequestError("id is empty", nil)
+	}
+
+	if err := req.Validate(); err != nil {
+		return portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	publisher, err := s.publisherRepo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := mapper.MapStructsWithJSONTags(req, publisher); err != nil {
+		return err
+	}
+
+	publisher.Id = id
+
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.publisherRepo.Update(ctx, publisher); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.PublisherUpdated, publisher.Id, publisher)
+	})
+}
+
+func (s *publisherService) FindAll(ctx context.Context) ([]*payload.PublisherResponse, error) {
+	publishers, err := s.publisherRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.PublisherResponse{}
+	for _, publisher := range publishers {
+		publisherRes := &payload.PublisherResponse{}
+		if err := mapper.MapStructsWithJSONTags(publisher, publisherRes); err != nil {
+			return nil, err
+		}
+		list = append(list, publisherRes)
+	}
+
+	return list, nil
+}
+
+// Delete refuses to delete a publisher that still has books, checked within the
+// transaction deleting it.
+func (s *publisherService) Delete(ctx context.Context, id string) error {
+	_, err := s.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		books, err := s.bookRepo.FindByPublisher(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(books) > 0 {
+			return portError.NewConflictError("Publisher has books.", nil)
+		}
+
+		if err := s.publisherRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.PublisherDeleted, id, nil)
+	})
+}
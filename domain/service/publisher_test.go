@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/test"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_publisherService_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name          string
+		publisherRepo func() repository.PublisherRepository
+		id            string
+		want          *payload.PublisherResponse
+		wantErr       bool
+	}{
+		{
+			name: "find publisher successfully",
+			publisherRepo: func() repository.PublisherRepository {
+				publisherRepo := repository.NewMockPublisherRepository(ctrl)
+				publisherRepo.EXPECT().Find(gomock.Any(), test.PublisherId1).Return(&entity.Publisher{
+					Id:        test.PublisherId1,
+					Name:      test.PublisherName1,
+					Country:   test.PublisherCountry1,
+					Website:   test.PublisherWebsite1,
+					CreatedAt: test.CreatedAt,
+					UpdatedAt: test.UpdatedAt,
+				}, nil)
+
+				return publisherRepo
+			},
+			id: test.PublisherId1,
+			want: &payload.PublisherResponse{
+				Id:        test.PublisherId1,
+				Name:      test.PublisherName1,
+				Country:   test.PublisherCountry1,
+				Website:   test.PublisherWebsite1,
+				CreatedAt: test.CreatedAtStr,
+				UpdatedAt: test.UpdatedAtStr,
+			},
+		},
+		{
+			name: "id empty",
+			publisherRepo: func() repository.PublisherRepository {
+				return repository.NewMockPublisherRepository(ctrl)
+			},
+			id:      "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPublisherService(tt.publisherRepo(), repository.NewMockBookRepository(ctrl), nil, nil)
+			got, err := s.Find(context.TODO(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("publisherService.Find() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("publisherService.Find() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_publisherService_Store(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name          string
+		publisherRepo func() repository.PublisherRepository
+		req           *payload.PublisherRequest
+		wantErr       bool
+	}{
+		{
+			name: "store publisher successfully",
+			publisherRepo: func() repository.PublisherRepository {
+				publisherRepo := repository.NewMockPublisherRepository(ctrl)
+				publisherRepo.EXPECT().Store(gomock.Any(), &entity.Publisher{
+					Name:    test.PublisherName1,
+					Country: test.PublisherCountry1,
+					Website: test.PublisherWebsite1,
+				}).Return(nil)
+
+				return publisherRepo
+			},
+			req: &payload.PublisherRequest{
+				Name:    test.PublisherName1,
+				Country: test.PublisherCountry1,
+				Website: test.PublisherWebsite1,
+			},
+		},
+		{
+			name: "store publisher failed because country is empty",
+			publisherRepo: func() repository.PublisherRepository {
+				return repository.NewMockPublisherRepository(ctrl)
+			},
+			req:     &payload.PublisherRequest{Name: test.PublisherName1},
+			wantErr: true,
+		},
+		{
+			name: "store publisher failed because website is invalid",
+			publisherRepo: func() repository.PublisherRepository {
+				return repository.NewMockPublisherRepository(ctrl)
+			},
+			req: &payload.PublisherRequest{
+				Name:    test.PublisherName1,
+				Country: test.PublisherCountry1,
+				Website: "publisher.example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "store publisher failed",
+			publisherRepo: func() repository.PublisherRepository {
+				publisherRepo := repository.NewMockPublisherRepository(ctrl)
+				publisherRepo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(errors.New("error occur"))
+
+				return publisherRepo
+			},
+			req: &payload.PublisherRequest{
+				Name:    test.PublisherName1,
+				Country: test.PublisherCountry1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPublisherService(tt.publisherRepo(), repository.NewMockBookRepository(ctrl), nil, nil)
+			if err := s.Store(context.TODO(), tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("publisherService.Store() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_publisherService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		books      []*entity.Book
+		wantStatus int
+	}{
+		{
+			name: "delete publisher successfully",
+		},
+		{
+			name:       "delete publisher failed because it has books",
+			books:      []*entity.Book{{Id: test.BookId1, PublisherId: test.PublisherId1}},
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisherRepo := repository.NewMockPublisherRepository(ctrl)
+			publisherRepo.EXPECT().Find(gomock.Any(), test.PublisherId1).Return(&entity.Publisher{Id: test.PublisherId1}, nil)
+			if tt.wantStatus == 0 {
+				publisherRepo.EXPECT().Delete(gomock.Any(), test.PublisherId1).Return(nil)
+			}
+			inTx := false
+			tx := repository.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					inTx = true
+					defer func() { inTx = false }()
+					return fn(ctx)
+				},
+			)
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			bookRepo.EXPECT().FindByPublisher(gomock.Any(), test.PublisherId1).DoAndReturn(func(ctx context.Context, id string) ([]*entity.Book, error) {
+				if !inTx {
+					t.Errorf("bookRepository.FindByPublisher() called outside the transaction")
+				}
+				return tt.books, nil
+			})
+
+			s := NewPublisherService(publisherRepo, bookRepo, nil, tx)
+			err := s.Delete(context.TODO(), test.PublisherId1)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("publisherService.Delete() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	"bookstore.com/repository"
+	"bookstore.com/tools/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RelayOptions tunes the background workers relaying messages: the outbox
+// relay and the webhook worker. Zero values fall back to defaults.
+type RelayOptions struct {
+	PollInterval time.Duration
+	Lease        time.Duration
+	MaxAttempts  int
+	Backoff      time.Duration
+	MaxBackoff   time.Duration
+}
+
+// backoff returns the delay before the attempt following attempts failed
+// ones: Backoff doubled after every failure, capped at MaxBackoff.
+func (o RelayOptions) backoff(attempts int) time.Duration {
+	d := o.Backoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= o.MaxBackoff {
+			return o.MaxBackoff
+		}
+	}
+
+	return d
+}
+
+func (o *RelayOptions) setDefaults() {
+	if o.PollInterval <= 0 {
+		o.PollInterval = time.Second
+	}
+	if o.Lease <= 0 {
+		o.Lease = 30 * time.Second
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 10
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 5 * time.Minute
+	}
+}
+
+// OutboxRelay delivers the events stored in the outbox to the publisher. A
+// message is only marked delivered once the publisher accepted it, so every
+// event is delivered at least once. Failed deliveries are retried with an
+// exponential backoff and dead-lettered after MaxAttempts.
+type OutboxRelay struct {
+	outbox    repository.OutboxRepository
+	publisher event.Publisher
+	opts      RelayOptions
+}
+
+func NewOutboxRelay(outbox repository.OutboxRepository, publisher event.Publisher, opts RelayOptions) *OutboxRelay {
+	opts.setDefaults()
+	return &OutboxRelay{outbox: outbox, publisher: publisher, opts: opts}
+}
+
+// Run relays the outbox until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Drain(ctx); err != nil {
+			logger.FromContext(ctx).Error("outbox relay failed", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain relays every message due for delivery.
+func (r *OutboxRelay) Drain(ctx context.Context) error {
+	for ctx.Err() == nil {
+		msg, err := r.outbox.Claim(ctx, r.opts.Lease)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			return nil
+		}
+
+		if err := r.relay(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *OutboxRelay) relay(ctx context.Context, msg *entity.OutboxMessage) error {
+	pubCtx, span := startSpan(ctx, "OutboxRelay.Publish",
+		attribute.String("event.id", msg.Id),
+		attribute.String("event.type", string(msg.Event.Type)),
+		attribute.Int("outbox.attempt", msg.Attempts),
+	)
+	err := r.publisher.Publish(pubCtx, msg.Event)
+	endSpan(span, err)
+
+	if err == nil {
+		return r.outbox.MarkDelivered(ctx, msg.Id)
+	}
+
+	l := logger.FromContext(ctx).With(
+		slog.String("eventId", msg.Id),
+		slog.String("type", string(msg.Event.Type)),
+		slog.Int("attempts", msg.Attempts),
+		slog.Any("error", err),
+	)
+
+	if msg.Attempts >= r.opts.MaxAttempts {
+		l.Error("outbox message dead-lettered")
+		return r.outbox.MarkDead(ctx, msg.Id, fmt.Errorf("after %d attempts: %v", msg.Attempts, err))
+	}
+
+	retryAt := time.Now().Add(r.backoff(msg.Attempts))
+	l.Warn("outbox message delivery failed", slog.Time("retryAt", retryAt))
+	return r.outbox.MarkFailed(ctx, msg.Id, err, retryAt)
+}
+
+// backoff returns the delay before the next attempt, doubling after every
+// failed attempt up to MaxBackoff.
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	return r.opts.backoff(attempts)
+}
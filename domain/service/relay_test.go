@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	"bookstore.com/repository"
+	"go.uber.org/mock/gomock"
+)
+
+type publisherFunc func(ctx context.Context, e *event.Event) error
+
+func (f publisherFunc) Publish(ctx context.Context, e *event.Event) error {
+	return f(ctx, e)
+}
+
+func TestOutboxRelay_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	opts := RelayOptions{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: time.Minute}
+	msg := func(attempts int) *entity.OutboxMessage {
+		return &entity.OutboxMessage{
+			Id:       "event-1",
+			Event:    &event.Event{Id: "event-1", Type: event.BookCreated},
+			Status:   entity.OutboxPending,
+			Attempts: attempts,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		outbox    func() repository.OutboxRepository
+		publisher event.Publisher
+		wantErr   bool
+	}{
+		{
+			name: "deliver message",
+			outbox: func() repository.OutboxRepository {
+				outbox := repository.NewMockOutboxRepository(ctrl)
+				gomock.InOrder(
+					outbox.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(msg(1), nil),
+					outbox.EXPECT().MarkDelivered(gomock.Any(), "event-1").Return(nil),
+					outbox.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(nil, nil),
+				)
+				return outbox
+			},
+			publisher: publisherFunc(func(ctx context.Context, e *event.Event) error { return nil }),
+		},
+		{
+			name: "retry failed message",
+			outbox: func() repository.OutboxRepository {
+				outbox := repository.NewMockOutboxRepository(ctrl)
+				gomock.InOrder(
+					outbox.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(msg(2), nil),
+					outbox.EXPECT().MarkFailed(gomock.Any(), "event-1", gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, id string, cause error, retryAt time.Time) error {
+							if delay := time.Until(retryAt); delay < time.Second || delay > 2*time.Second {
+								t.Errorf("retry delay = %v, want 2s", delay)
+							}
+							return nil
+						}),
+					outbox.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(nil, nil),
+				)
+				return outbox
+			},
+			publisher: publisherFunc(func(ctx context.Context, e *event.Event) error { return errors.New("error occur") }),
+		},
+		{
+			name: "dead-letter message",
+			outbox: func() repository.OutboxRepository {
+				outbox := repository.NewMockOutboxRepository(ctrl)
+				gomock.InOrder(
+					outbox.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(msg(3), nil),
+					outbox.EXPECT().MarkDead(gomock.Any(), "event-1", gomock.Any()).Return(nil),
+					outbox.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(nil, nil),
+				)
+				return outbox
+			},
+			publisher: publisherFunc(func(ctx context.Context, e *event.Event) error { return errors.New("error occur") }),
+		},
+		{
+			name: "claim failed",
+			outbox: func() repository.OutboxRepository {
+				outbox := repository.NewMockOutboxRepository(ctrl)
+				outbox.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(nil, errors.New("error occur"))
+				return outbox
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewOutboxRelay(tt.outbox(), tt.publisher, opts)
+			if err := r.Drain(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("OutboxRelay.Drain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutboxRelay_backoff(t *testing.T) {
+	r := NewOutboxRelay(nil, nil, RelayOptions{Backoff: time.Second, MaxBackoff: 5 * time.Second})
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := r.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
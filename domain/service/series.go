@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/tools/mapper"
+)
+
+type seriesService struct {
+	seriesRepo repository.SeriesRepository
+	bookRepo   repository.BookRepository
+	publisher  event.Publisher
+	tx         repository.Transactor
+}
+
+func NewSeriesService(
+	seriesRepo repository.SeriesRepository,
+	bookRepo repository.BookRepository,
+	publisher event.Publisher,
+	tx repository.Transactor,
+) SeriesService {
+	return &seriesService{seriesRepo: seriesRepo, bookRepo: bookRepo, publisher: publisher, tx: tx}
+}
+
+func (s *seriesService) Find(ctx context.Context, id string) (*payload.SeriesResponse, error) {
+	if id == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	series, err := s.seriesRepo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &payload.SeriesResponse{}
+	if err := mapper.MapStructsWithJSONTags(series, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *seriesService) Store(ctx context.Context, req *payload.SeriesRequest) error {
+	if err := req.Validate(); err != nil {
+		return portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	series := &entity.Series{}
+	if err := mapper.MapStructsWithJSONTags(req, series); err != nil {
+		return err
+	}
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.seriesRepo.Store(ctx, series); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.SeriesCreated, series.Id, series)
+	})
+}
+
+func (s *seriesService) Update(ctx context.Context, id string, req *payload.SeriesRequest) error {
+	if id == "" {
+		return portError.NewBadRequestError("id is empty", nil)
+	}
+
+	if err := req.Validate(); err != nil {
+		return portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	series, err := s.seriesRepo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := mapper.MapStructsWithJSONTags(req, series); err != nil {
+		return err
+	}
+
+	series.Id = id
+
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.seriesRepo.Update(ctx, series); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.SeriesUpdated, series.Id, series)
+	})
+}
+
+func (s *seriesService) FindAll(ctx context.Context) ([]*payload.SeriesResponse, error) {
+	list, err := s.seriesRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*payload.SeriesResponse{}
+	for _, series := range list {
+		seriesRes := &payload.SeriesResponse{}
+		if err := mapper.MapStructsWithJSONTags(series, seriesRes); err != nil {
+			return nil, err
+		}
+		res = append(res, seriesRes)
+	}
+
+	return res, nil
+}
+
+// Delete refuses to delete a series that still has books, checked within the
+// transaction deleting it.
+func (s *seriesService) Delete(ctx context.Context, id string) error {
+	_, err := s.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return withinTransaction(ctx, s.tx, func(ctx context.Context) error {
+		books, err := s.bookRepo.FindBySeries(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(books) > 0 {
+			return portError.NewConflictError("Series has books.", nil)
+		}
+
+		if err := s.seriesRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return publish(ctx, s.publisher, event.SeriesDeleted, id, nil)
+	})
+}
+
+// FindBooks lists the books of the series in reading order.
+func (s *seriesService) FindBooks(ctx context.Context, id string) ([]*payload.BookResponse, error) {
+	if _, err := s.Find(ctx, id); err != nil {
+		return nil, err
+	}
+
+	books, err := s.bookRepo.FindBySeries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.BookResponse{}
+	for _, book := range books {
+		res, err := newBookResponse(ctx, book)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, res)
+	}
+
+	return list, nil
+}
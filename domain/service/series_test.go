@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/test"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_seriesService_Store(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		seriesRepo func() repository.SeriesRepository
+		req        *payload.SeriesRequest
+		wantErr    bool
+	}{
+		{
+			name: "store series successfully",
+			seriesRepo: func() repository.SeriesRepository {
+				seriesRepo := repository.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().Store(gomock.Any(), &entity.Series{
+					Name:        test.SeriesName1,
+					Description: test.SeriesDescription1,
+				}).Return(nil)
+
+				return seriesRepo
+			},
+			req: &payload.SeriesRequest{Name: test.SeriesName1, Description: test.SeriesDescription1},
+		},
+		{
+			name: "store series failed because name is empty",
+			seriesRepo: func() repository.SeriesRepository {
+				return repository.NewMockSeriesRepository(ctrl)
+			},
+			req:     &payload.SeriesRequest{Description: test.SeriesDescription1},
+			wantErr: true,
+		},
+		{
+			name: "store series failed",
+			seriesRepo: func() repository.SeriesRepository {
+				seriesRepo := repository.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(errors.New("error occur"))
+
+				return seriesRepo
+			},
+			req:     &payload.SeriesRequest{Name: test.SeriesName1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSeriesService(tt.seriesRepo(), repository.NewMockBookRepository(ctrl), nil, nil)
+			if err := s.Store(context.TODO(), tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("seriesService.Store() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_seriesService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		books      []*entity.Book
+		wantStatus int
+	}{
+		{
+			name: "delete series successfully",
+		},
+		{
+			name:       "delete series failed because it has books",
+			books:      []*entity.Book{{Id: test.BookId1, SeriesId: test.SeriesId1, SeriesPosition: 1}},
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seriesRepo := repository.NewMockSeriesRepository(ctrl)
+			seriesRepo.EXPECT().Find(gomock.Any(), test.SeriesId1).Return(&entity.Series{Id: test.SeriesId1}, nil)
+			if tt.wantStatus == 0 {
+				seriesRepo.EXPECT().Delete(gomock.Any(), test.SeriesId1).Return(nil)
+			}
+			inTx := false
+			tx := repository.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					inTx = true
+					defer func() { inTx = false }()
+					return fn(ctx)
+				},
+			)
+			bookRepo := repository.NewMockBookRepository(ctrl)
+			bookRepo.EXPECT().FindBySeries(gomock.Any(), test.SeriesId1).DoAndReturn(func(ctx context.Context, id string) ([]*entity.Book, error) {
+				if !inTx {
+					t.Errorf("bookRepository.FindBySeries() called outside the transaction")
+				}
+				return tt.books, nil
+			})
+
+			s := NewSeriesService(seriesRepo, bookRepo, nil, tx)
+			err := s.Delete(context.TODO(), test.SeriesId1)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("seriesService.Delete() error = %v, want status %v", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_seriesService_FindBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name       string
+		seriesRepo func() repository.SeriesRepository
+		bookRepo   func() repository.BookRepository
+		want       []string
+		wantStatus int
+	}{
+		{
+			name: "find books in reading order",
+			seriesRepo: func() repository.SeriesRepository {
+				seriesRepo := repository.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().Find(gomock.Any(), test.SeriesId1).Return(&entity.Series{Id: test.SeriesId1}, nil)
+
+				return seriesRepo
+			},
+			bookRepo: func() repository.BookRepository {
+				bookRepo := repository.NewMockBookRepository(ctrl)
+				bookRepo.EXPECT().FindBySeries(gomock.Any(), test.SeriesId1).Return([]*entity.Book{
+					{Id: "book-1", SeriesId: test.SeriesId1, SeriesPosition: 1},
+					{Id: "book-2", SeriesId: test.SeriesId1, SeriesPosition: 2},
+				}, nil)
+
+				return bookRepo
+			},
+			want: []string{"book-1", "book-2"},
+		},
+		{
+			name: "series not found",
+			seriesRepo: func() repository.SeriesRepository {
+				seriesRepo := repository.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().Find(gomock.Any(), test.SeriesId1).Return(nil, portError.NewNotFoundError("Series not found.", nil))
+
+				return seriesRepo
+			},
+			bookRepo: func() repository.BookRepository {
+				return repository.NewMockBookRepository(ctrl)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSeriesService(tt.seriesRepo(), tt.bookRepo(), nil, nil)
+			books, err := s.FindBooks(context.TODO(), test.SeriesId1)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Fatalf("seriesService.FindBooks() error = %v, want status %v", err, tt.wantStatus)
+			}
+
+			got := []string{}
+			for _, book := range books {
+				got = append(got, book.Id)
+			}
+			if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("seriesService.FindBooks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
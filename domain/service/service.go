@@ -11,18 +11,71 @@ type AuthorService interface {
 	Store(ctx context.Context, author *payload.AuthorRequest) error
 	Update(ctx context.Context, id string, author *payload.AuthorRequest) error
 	FindAll(ctx context.Context) ([]*payload.AuthorResponse, error)
+	Search(ctx context.Context, query string) ([]*payload.AuthorResponse, error)
 	Delete(ctx context.Context, id string) error
 }
 
 type BookService interface {
 	Find(ctx context.Context, id string) (*payload.BookResponse, error)
+	FindByISBN(ctx context.Context, isbn string) (*payload.BookResponse, error)
 	Store(ctx context.Context, author *payload.BookRequest) error
 	Update(ctx context.Context, id string, author *payload.BookRequest) error
 	FindAll(ctx context.Context) ([]*payload.BookResponse, error)
+	FindTranslations(ctx context.Context, id string) ([]*payload.BookResponse, error)
+	FindByTags(ctx context.Context, tags []string, all bool) ([]*payload.BookResponse, error)
+	AddTags(ctx context.Context, id string, req *payload.TagsRequest) (*payload.BookResponse, error)
+	RemoveTag(ctx context.Context, id, tag string) (*payload.BookResponse, error)
+	CountTags(ctx context.Context) ([]*payload.TagCountResponse, error)
 	Delete(ctx context.Context, id string) error
 }
 
+type CoverService interface {
+	Upload(ctx context.Context, bookId string, req *payload.ImageRequest) (*payload.CoverResponse, error)
+	Find(ctx context.Context, bookId, size string) (*payload.ImageFile, error)
+}
+
+type PhotoService interface {
+	Upload(ctx context.Context, authorId string, req *payload.ImageRequest) (*payload.PhotoResponse, error)
+	Find(ctx context.Context, authorId, size string) (*payload.ImageFile, error)
+}
+
+type PublisherService interface {
+	Find(ctx context.Context, id string) (*payload.PublisherResponse, error)
+	Store(ctx context.Context, publisher *payload.PublisherRequest) error
+	Update(ctx context.Context, id string, publisher *payload.PublisherRequest) error
+	FindAll(ctx context.Context) ([]*payload.PublisherResponse, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type SeriesService interface {
+	Find(ctx context.Context, id string) (*payload.SeriesResponse, error)
+	Store(ctx context.Context, series *payload.SeriesRequest) error
+	Update(ctx context.Context, id string, series *payload.SeriesRequest) error
+	FindAll(ctx context.Context) ([]*payload.SeriesResponse, error)
+	Delete(ctx context.Context, id string) error
+	FindBooks(ctx context.Context, id string) ([]*payload.BookResponse, error)
+}
+
+type CategoryService interface {
+	Find(ctx context.Context, id string) (*payload.CategoryResponse, error)
+	Store(ctx context.Context, category *payload.CategoryRequest) (*payload.CategoryResponse, error)
+	Update(ctx context.Context, id string, category *payload.CategoryRequest) error
+	FindAll(ctx context.Context) ([]*payload.CategoryResponse, error)
+	Delete(ctx context.Context, id string) error
+	FindBooks(ctx context.Context, id string) ([]*payload.BookResponse, error)
+}
+
 type UserService interface {
 	Register(ctx context.Context, user *payload.RegisterRequest) error
 	Login(ctx context.Context, user *payload.LoginRequest) (*payload.LoginResponse, error)
 }
+
+type WebhookService interface {
+	Find(ctx context.Context, id string) (*payload.WebhookResponse, error)
+	Store(ctx context.Context, webhook *payload.WebhookRequest) (*payload.WebhookResponse, error)
+	Update(ctx context.Context, id string, webhook *payload.WebhookRequest) error
+	FindAll(ctx context.Context) ([]*payload.WebhookResponse, error)
+	Delete(ctx context.Context, id string) error
+	FindDeliveries(ctx context.Context, id string) ([]*payload.WebhookDeliveryResponse, error)
+	Redeliver(ctx context.Context, id string, deliveryId string) (*payload.WebhookDeliveryResponse, error)
+}
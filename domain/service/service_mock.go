@@ -79,6 +79,21 @@ func (mr *MockAuthorServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuthorService)(nil).FindAll), ctx)
 }
 
+// Search mocks base method.
+func (m *MockAuthorService) Search(ctx context.Context, query string) ([]*payload.AuthorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].([]*payload.AuthorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAuthorServiceMockRecorder) Search(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAuthorService)(nil).Search), ctx, query)
+}
+
 // Store mocks base method.
 func (m *MockAuthorService) Store(ctx context.Context, author *payload.AuthorRequest) error {
 	m.ctrl.T.Helper()
@@ -130,6 +145,36 @@ func (m *MockBookService) EXPECT() *MockBookServiceMockRecorder {
 	return m.recorder
 }
 
+// AddTags mocks base method.
+func (m *MockBookService) AddTags(ctx context.Context, id string, req *payload.TagsRequest) (*payload.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", ctx, id, req)
+	ret0, _ := ret[0].(*payload.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockBookServiceMockRecorder) AddTags(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockBookService)(nil).AddTags), ctx, id, req)
+}
+
+// CountTags mocks base method.
+func (m *MockBookService) CountTags(ctx context.Context) ([]*payload.TagCountResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTags", ctx)
+	ret0, _ := ret[0].([]*payload.TagCountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTags indicates an expected call of CountTags.
+func (mr *MockBookServiceMockRecorder) CountTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTags", reflect.TypeOf((*MockBookService)(nil).CountTags), ctx)
+}
+
 // Delete mocks base method.
 func (m *MockBookService) Delete(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
@@ -174,6 +219,66 @@ func (mr *MockBookServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBookService)(nil).FindAll), ctx)
 }
 
+// FindByISBN mocks base method.
+func (m *MockBookService) FindByISBN(ctx context.Context, isbn string) (*payload.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByISBN", ctx, isbn)
+	ret0, _ := ret[0].(*payload.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByISBN indicates an expected call of FindByISBN.
+func (mr *MockBookServiceMockRecorder) FindByISBN(ctx, isbn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockBookService)(nil).FindByISBN), ctx, isbn)
+}
+
+// FindByTags mocks base method.
+func (m *MockBookService) FindByTags(ctx context.Context, tags []string, all bool) ([]*payload.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTags", ctx, tags, all)
+	ret0, _ := ret[0].([]*payload.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTags indicates an expected call of FindByTags.
+func (mr *MockBookServiceMockRecorder) FindByTags(ctx, tags, all interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTags", reflect.TypeOf((*MockBookService)(nil).FindByTags), ctx, tags, all)
+}
+
+// FindTranslations mocks base method.
+func (m *MockBookService) FindTranslations(ctx context.Context, id string) ([]*payload.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTranslations", ctx, id)
+	ret0, _ := ret[0].([]*payload.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTranslations indicates an expected call of FindTranslations.
+func (mr *MockBookServiceMockRecorder) FindTranslations(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTranslations", reflect.TypeOf((*MockBookService)(nil).FindTranslations), ctx, id)
+}
+
+// RemoveTag mocks base method.
+func (m *MockBookService) RemoveTag(ctx context.Context, id, tag string) (*payload.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTag", ctx, id, tag)
+	ret0, _ := ret[0].(*payload.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTag indicates an expected call of RemoveTag.
+func (mr *MockBookServiceMockRecorder) RemoveTag(ctx, id, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockBookService)(nil).RemoveTag), ctx, id, tag)
+}
+
 // Store mocks base method.
 func (m *MockBookService) Store(ctx context.Context, author *payload.BookRequest) error {
 	m.ctrl.T.Helper()
@@ -201,3 +306,603 @@ func (mr *MockBookServiceMockRecorder) Update(ctx, id, author interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookService)(nil).Update), ctx, id, author)
 }
+
+// MockCoverService is a mock of CoverService interface.
+type MockCoverService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoverServiceMockRecorder
+}
+
+// MockCoverServiceMockRecorder is the mock recorder for MockCoverService.
+type MockCoverServiceMockRecorder struct {
+	mock *MockCoverService
+}
+
+// NewMockCoverService creates a new mock instance.
+func NewMockCoverService(ctrl *gomock.Controller) *MockCoverService {
+	mock := &MockCoverService{ctrl: ctrl}
+	mock.recorder = &MockCoverServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoverService) EXPECT() *MockCoverServiceMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockCoverService) Find(ctx context.Context, bookId, size string) (*payload.ImageFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, bookId, size)
+	ret0, _ := ret[0].(*payload.ImageFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockCoverServiceMockRecorder) Find(ctx, bookId, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCoverService)(nil).Find), ctx, bookId, size)
+}
+
+// Upload mocks base method.
+func (m *MockCoverService) Upload(ctx context.Context, bookId string, req *payload.ImageRequest) (*payload.CoverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, bookId, req)
+	ret0, _ := ret[0].(*payload.CoverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockCoverServiceMockRecorder) Upload(ctx, bookId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockCoverService)(nil).Upload), ctx, bookId, req)
+}
+
+// MockPhotoService is a mock of PhotoService interface.
+type MockPhotoService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPhotoServiceMockRecorder
+}
+
+// MockPhotoServiceMockRecorder is the mock recorder for MockPhotoService.
+type MockPhotoServiceMockRecorder struct {
+	mock *MockPhotoService
+}
+
+// NewMockPhotoService creates a new mock instance.
+func NewMockPhotoService(ctrl *gomock.Controller) *MockPhotoService {
+	mock := &MockPhotoService{ctrl: ctrl}
+	mock.recorder = &MockPhotoServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPhotoService) EXPECT() *MockPhotoServiceMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockPhotoService) Find(ctx context.Context, authorId, size string) (*payload.ImageFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, authorId, size)
+	ret0, _ := ret[0].(*payload.ImageFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockPhotoServiceMockRecorder) Find(ctx, authorId, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockPhotoService)(nil).Find), ctx, authorId, size)
+}
+
+// Upload mocks base method.
+func (m *MockPhotoService) Upload(ctx context.Context, authorId string, req *payload.ImageRequest) (*payload.PhotoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, authorId, req)
+	ret0, _ := ret[0].(*payload.PhotoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockPhotoServiceMockRecorder) Upload(ctx, authorId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockPhotoService)(nil).Upload), ctx, authorId, req)
+}
+
+// MockPublisherService is a mock of PublisherService interface.
+type MockPublisherService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherServiceMockRecorder
+}
+
+// MockPublisherServiceMockRecorder is the mock recorder for MockPublisherService.
+type MockPublisherServiceMockRecorder struct {
+	mock *MockPublisherService
+}
+
+// NewMockPublisherService creates a new mock instance.
+func NewMockPublisherService(ctrl *gomock.Controller) *MockPublisherService {
+	mock := &MockPublisherService{ctrl: ctrl}
+	mock.recorder = &MockPublisherServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisherService) EXPECT() *MockPublisherServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPublisherService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPublisherServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPublisherService)(nil).Delete), ctx, id)
+}
+
+// Find mocks base method.
+func (m *MockPublisherService) Find(ctx context.Context, id string) (*payload.PublisherResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, id)
+	ret0, _ := ret[0].(*payload.PublisherResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockPublisherServiceMockRecorder) Find(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockPublisherService)(nil).Find), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockPublisherService) FindAll(ctx context.Context) ([]*payload.PublisherResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*payload.PublisherResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPublisherServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPublisherService)(nil).FindAll), ctx)
+}
+
+// Store mocks base method.
+func (m *MockPublisherService) Store(ctx context.Context, publisher *payload.PublisherRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, publisher)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockPublisherServiceMockRecorder) Store(ctx, publisher interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockPublisherService)(nil).Store), ctx, publisher)
+}
+
+// Update mocks base method.
+func (m *MockPublisherService) Update(ctx context.Context, id string, publisher *payload.PublisherRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, publisher)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPublisherServiceMockRecorder) Update(ctx, id, publisher interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPublisherService)(nil).Update), ctx, id, publisher)
+}
+
+// MockSeriesService is a mock of SeriesService interface.
+type MockSeriesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesServiceMockRecorder
+}
+
+// MockSeriesServiceMockRecorder is the mock recorder for MockSeriesService.
+type MockSeriesServiceMockRecorder struct {
+	mock *MockSeriesService
+}
+
+// NewMockSeriesService creates a new mock instance.
+func NewMockSeriesService(ctrl *gomock.Controller) *MockSeriesService {
+	mock := &MockSeriesService{ctrl: ctrl}
+	mock.recorder = &MockSeriesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesService) EXPECT() *MockSeriesServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSeriesService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeriesServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeriesService)(nil).Delete), ctx, id)
+}
+
+// Find mocks base method.
+func (m *MockSeriesService) Find(ctx context.Context, id string) (*payload.SeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, id)
+	ret0, _ := ret[0].(*payload.SeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockSeriesServiceMockRecorder) Find(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockSeriesService)(nil).Find), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockSeriesService) FindAll(ctx context.Context) ([]*payload.SeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*payload.SeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSeriesServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSeriesService)(nil).FindAll), ctx)
+}
+
+// FindBooks mocks base method.
+func (m *MockSeriesService) FindBooks(ctx context.Context, id string) ([]*payload.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBooks", ctx, id)
+	ret0, _ := ret[0].([]*payload.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBooks indicates an expected call of FindBooks.
+func (mr *MockSeriesServiceMockRecorder) FindBooks(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBooks", reflect.TypeOf((*MockSeriesService)(nil).FindBooks), ctx, id)
+}
+
+// Store mocks base method.
+func (m *MockSeriesService) Store(ctx context.Context, series *payload.SeriesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, series)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockSeriesServiceMockRecorder) Store(ctx, series interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockSeriesService)(nil).Store), ctx, series)
+}
+
+// Update mocks base method.
+func (m *MockSeriesService) Update(ctx context.Context, id string, series *payload.SeriesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, series)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesServiceMockRecorder) Update(ctx, id, series interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeriesService)(nil).Update), ctx, id, series)
+}
+
+// MockCategoryService is a mock of CategoryService interface.
+type MockCategoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryServiceMockRecorder
+}
+
+// MockCategoryServiceMockRecorder is the mock recorder for MockCategoryService.
+type MockCategoryServiceMockRecorder struct {
+	mock *MockCategoryService
+}
+
+// NewMockCategoryService creates a new mock instance.
+func NewMockCategoryService(ctrl *gomock.Controller) *MockCategoryService {
+	mock := &MockCategoryService{ctrl: ctrl}
+	mock.recorder = &MockCategoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryService) EXPECT() *MockCategoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCategoryService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryService)(nil).Delete), ctx, id)
+}
+
+// Find mocks base method.
+func (m *MockCategoryService) Find(ctx context.Context, id string) (*payload.CategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, id)
+	ret0, _ := ret[0].(*payload.CategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockCategoryServiceMockRecorder) Find(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCategoryService)(nil).Find), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockCategoryService) FindAll(ctx context.Context) ([]*payload.CategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*payload.CategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCategoryServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryService)(nil).FindAll), ctx)
+}
+
+// FindBooks mocks base method.
+func (m *MockCategoryService) FindBooks(ctx context.Context, id string) ([]*payload.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBooks", ctx, id)
+	ret0, _ := ret[0].([]*payload.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBooks indicates an expected call of FindBooks.
+func (mr *MockCategoryServiceMockRecorder) FindBooks(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBooks", reflect.TypeOf((*MockCategoryService)(nil).FindBooks), ctx, id)
+}
+
+// Store mocks base method.
+func (m *MockCategoryService) Store(ctx context.Context, category *payload.CategoryRequest) (*payload.CategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, category)
+	ret0, _ := ret[0].(*payload.CategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockCategoryServiceMockRecorder) Store(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockCategoryService)(nil).Store), ctx, category)
+}
+
+// Update mocks base method.
+func (m *MockCategoryService) Update(ctx context.Context, id string, category *payload.CategoryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryServiceMockRecorder) Update(ctx, id, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryService)(nil).Update), ctx, id, category)
+}
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// Login mocks base method.
+func (m *MockUserService) Login(ctx context.Context, user *payload.LoginRequest) (*payload.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, user)
+	ret0, _ := ret[0].(*payload.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockUserServiceMockRecorder) Login(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserService)(nil).Login), ctx, user)
+}
+
+// Register mocks base method.
+func (m *MockUserService) Register(ctx context.Context, user *payload.RegisterRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockUserServiceMockRecorder) Register(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserService)(nil).Register), ctx, user)
+}
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWebhookService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookService)(nil).Delete), ctx, id)
+}
+
+// Find mocks base method.
+func (m *MockWebhookService) Find(ctx context.Context, id string) (*payload.WebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, id)
+	ret0, _ := ret[0].(*payload.WebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockWebhookServiceMockRecorder) Find(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockWebhookService)(nil).Find), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockWebhookService) FindAll(ctx context.Context) ([]*payload.WebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*payload.WebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockWebhookServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockWebhookService)(nil).FindAll), ctx)
+}
+
+// FindDeliveries mocks base method.
+func (m *MockWebhookService) FindDeliveries(ctx context.Context, id string) ([]*payload.WebhookDeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveries", ctx, id)
+	ret0, _ := ret[0].([]*payload.WebhookDeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveries indicates an expected call of FindDeliveries.
+func (mr *MockWebhookServiceMockRecorder) FindDeliveries(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveries", reflect.TypeOf((*MockWebhookService)(nil).FindDeliveries), ctx, id)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookService) Redeliver(ctx context.Context, id, deliveryId string) (*payload.WebhookDeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, id, deliveryId)
+	ret0, _ := ret[0].(*payload.WebhookDeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookServiceMockRecorder) Redeliver(ctx, id, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookService)(nil).Redeliver), ctx, id, deliveryId)
+}
+
+// Store mocks base method.
+func (m *MockWebhookService) Store(ctx context.Context, webhook *payload.WebhookRequest) (*payload.WebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, webhook)
+	ret0, _ := ret[0].(*payload.WebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockWebhookServiceMockRecorder) Store(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockWebhookService)(nil).Store), ctx, webhook)
+}
+
+// Update mocks base method.
+func (m *MockWebhookService) Update(ctx context.Context, id string, webhook *payload.WebhookRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookServiceMockRecorder) Update(ctx, id, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookService)(nil).Update), ctx, id, webhook)
+}
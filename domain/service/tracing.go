@@ -0,0 +1,450 @@
+package service
+
+import (
+	"context"
+
+	"bookstore.com/port/payload"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("bookstore.com/domain/service")
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+type tracedAuthorService struct {
+	next AuthorService
+}
+
+// NewTracedAuthorService wraps an AuthorService so that every call is
+// recorded as a span.
+func NewTracedAuthorService(next AuthorService) AuthorService {
+	return &tracedAuthorService{next: next}
+}
+
+func (s *tracedAuthorService) Find(ctx context.Context, id string) (res *payload.AuthorResponse, err error) {
+	ctx, span := startSpan(ctx, "AuthorService.Find", attribute.String("author.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Find(ctx, id)
+}
+
+func (s *tracedAuthorService) Store(ctx context.Context, req *payload.AuthorRequest) (err error) {
+	ctx, span := startSpan(ctx, "AuthorService.Store")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Store(ctx, req)
+}
+
+func (s *tracedAuthorService) Update(ctx context.Context, id string, req *payload.AuthorRequest) (err error) {
+	ctx, span := startSpan(ctx, "AuthorService.Update", attribute.String("author.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Update(ctx, id, req)
+}
+
+func (s *tracedAuthorService) FindAll(ctx context.Context) (res []*payload.AuthorResponse, err error) {
+	ctx, span := startSpan(ctx, "AuthorService.FindAll")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindAll(ctx)
+}
+
+func (s *tracedAuthorService) Search(ctx context.Context, query string) (res []*payload.AuthorResponse, err error) {
+	ctx, span := startSpan(ctx, "AuthorService.Search", attribute.String("author.query", query))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Search(ctx, query)
+}
+
+func (s *tracedAuthorService) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "AuthorService.Delete", attribute.String("author.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Delete(ctx, id)
+}
+
+type tracedBookService struct {
+	next BookService
+}
+
+// NewTracedBookService wraps a BookService so that every call is recorded as
+// a span.
+func NewTracedBookService(next BookService) BookService {
+	return &tracedBookService{next: next}
+}
+
+func (s *tracedBookService) Find(ctx context.Context, id string) (res *payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "BookService.Find", attribute.String("book.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Find(ctx, id)
+}
+
+func (s *tracedBookService) FindByISBN(ctx context.Context, isbn string) (res *payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "BookService.FindByISBN", attribute.String("book.isbn", isbn))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindByISBN(ctx, isbn)
+}
+
+func (s *tracedBookService) Store(ctx context.Context, req *payload.BookRequest) (err error) {
+	ctx, span := startSpan(ctx, "BookService.Store", attribute.String("author.id", req.AuthorId))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Store(ctx, req)
+}
+
+func (s *tracedBookService) Update(ctx context.Context, id string, req *payload.BookRequest) (err error) {
+	ctx, span := startSpan(ctx, "BookService.Update", attribute.String("book.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Update(ctx, id, req)
+}
+
+func (s *tracedBookService) FindAll(ctx context.Context) (res []*payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "BookService.FindAll")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindAll(ctx)
+}
+
+func (s *tracedBookService) FindTranslations(ctx context.Context, id string) (res []*payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "BookService.FindTranslations", attribute.String("book.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindTranslations(ctx, id)
+}
+
+func (s *tracedBookService) FindByTags(ctx context.Context, tags []string, all bool) (res []*payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "BookService.FindByTags",
+		attribute.StringSlice("book.tags", tags), attribute.Bool("book.tags.all", all))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindByTags(ctx, tags, all)
+}
+
+func (s *tracedBookService) AddTags(ctx context.Context, id string, req *payload.TagsRequest) (res *payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "BookService.AddTags", attribute.String("book.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.AddTags(ctx, id, req)
+}
+
+func (s *tracedBookService) RemoveTag(ctx context.Context, id, tag string) (res *payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "BookService.RemoveTag", attribute.String("book.id", id), attribute.String("book.tag", tag))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.RemoveTag(ctx, id, tag)
+}
+
+func (s *tracedBookService) CountTags(ctx context.Context) (res []*payload.TagCountResponse, err error) {
+	ctx, span := startSpan(ctx, "BookService.CountTags")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.CountTags(ctx)
+}
+
+func (s *tracedBookService) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "BookService.Delete", attribute.String("book.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Delete(ctx, id)
+}
+
+type tracedCoverService struct {
+	next CoverService
+}
+
+// NewTracedCoverService wraps a CoverService so that every call is recorded
+// as a span.
+func NewTracedCoverService(next CoverService) CoverService {
+	return &tracedCoverService{next: next}
+}
+
+func (s *tracedCoverService) Upload(ctx context.Context, bookId string, req *payload.ImageRequest) (res *payload.CoverResponse, err error) {
+	ctx, span := startSpan(ctx, "CoverService.Upload",
+		attribute.String("book.id", bookId), attribute.String("cover.content_type", req.ContentType), attribute.Int("cover.bytes", len(req.Data)))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Upload(ctx, bookId, req)
+}
+
+func (s *tracedCoverService) Find(ctx context.Context, bookId, size string) (res *payload.ImageFile, err error) {
+	ctx, span := startSpan(ctx, "CoverService.Find", attribute.String("book.id", bookId), attribute.String("cover.size", size))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Find(ctx, bookId, size)
+}
+
+type tracedPhotoService struct {
+	next PhotoService
+}
+
+// NewTracedPhotoService wraps a PhotoService so that every call is recorded
+// as a span.
+func NewTracedPhotoService(next PhotoService) PhotoService {
+	return &tracedPhotoService{next: next}
+}
+
+func (s *tracedPhotoService) Upload(ctx context.Context, authorId string, req *payload.ImageRequest) (res *payload.PhotoResponse, err error) {
+	ctx, span := startSpan(ctx, "PhotoService.Upload",
+		attribute.String("author.id", authorId), attribute.String("photo.content_type", req.ContentType), attribute.Int("photo.bytes", len(req.Data)))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Upload(ctx, authorId, req)
+}
+
+func (s *tracedPhotoService) Find(ctx context.Context, authorId, size string) (res *payload.ImageFile, err error) {
+	ctx, span := startSpan(ctx, "PhotoService.Find", attribute.String("author.id", authorId), attribute.String("photo.size", size))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Find(ctx, authorId, size)
+}
+
+type tracedPublisherService struct {
+	next PublisherService
+}
+
+// NewTracedPublisherService wraps a PublisherService so that every call is
+// recorded as a span.
+func NewTracedPublisherService(next PublisherService) PublisherService {
+	return &tracedPublisherService{next: next}
+}
+
+func (s *tracedPublisherService) Find(ctx context.Context, id string) (res *payload.PublisherResponse, err error) {
+	ctx, span := startSpan(ctx, "PublisherService.Find", attribute.String("publisher.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Find(ctx, id)
+}
+
+func (s *tracedPublisherService) Store(ctx context.Context, req *payload.PublisherRequest) (err error) {
+	ctx, span := startSpan(ctx, "PublisherService.Store")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Store(ctx, req)
+}
+
+func (s *tracedPublisherService) Update(ctx context.Context, id string, req *payload.PublisherRequest) (err error) {
+	ctx, span := startSpan(ctx, "PublisherService.Update", attribute.String("publisher.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Update(ctx, id, req)
+}
+
+func (s *tracedPublisherService) FindAll(ctx context.Context) (res []*payload.PublisherResponse, err error) {
+	ctx, span := startSpan(ctx, "PublisherService.FindAll")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindAll(ctx)
+}
+
+func (s *tracedPublisherService) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "PublisherService.Delete", attribute.String("publisher.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Delete(ctx, id)
+}
+
+type tracedSeriesService struct {
+	next SeriesService
+}
+
+// NewTracedSeriesService wraps a SeriesService so that every call is recorded
+// as a span.
+func NewTracedSeriesService(next SeriesService) SeriesService {
+	return &tracedSeriesService{next: next}
+}
+
+func (s *tracedSeriesService) Find(ctx context.Context, id string) (res *payload.SeriesResponse, err error) {
+	ctx, span := startSpan(ctx, "SeriesService.Find", attribute.String("series.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Find(ctx, id)
+}
+
+func (s *tracedSeriesService) Store(ctx context.Context, req *payload.SeriesRequest) (err error) {
+	ctx, span := startSpan(ctx, "SeriesService.Store")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Store(ctx, req)
+}
+
+func (s *tracedSeriesService) Update(ctx context.Context, id string, req *payload.SeriesRequest) (err error) {
+	ctx, span := startSpan(ctx, "SeriesService.Update", attribute.String("series.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Update(ctx, id, req)
+}
+
+func (s *tracedSeriesService) FindAll(ctx context.Context) (res []*payload.SeriesResponse, err error) {
+	ctx, span := startSpan(ctx, "SeriesService.FindAll")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindAll(ctx)
+}
+
+func (s *tracedSeriesService) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "SeriesService.Delete", attribute.String("series.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Delete(ctx, id)
+}
+
+func (s *tracedSeriesService) FindBooks(ctx context.Context, id string) (res []*payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "SeriesService.FindBooks", attribute.String("series.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindBooks(ctx, id)
+}
+
+type tracedCategoryService struct {
+	next CategoryService
+}
+
+// NewTracedCategoryService wraps a CategoryService so that every call is
+// recorded as a span.
+func NewTracedCategoryService(next CategoryService) CategoryService {
+	return &tracedCategoryService{next: next}
+}
+
+func (s *tracedCategoryService) Find(ctx context.Context, id string) (res *payload.CategoryResponse, err error) {
+	ctx, span := startSpan(ctx, "CategoryService.Find", attribute.String("category.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Find(ctx, id)
+}
+
+func (s *tracedCategoryService) Store(ctx context.Context, req *payload.CategoryRequest) (res *payload.CategoryResponse, err error) {
+	ctx, span := startSpan(ctx, "CategoryService.Store")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Store(ctx, req)
+}
+
+func (s *tracedCategoryService) Update(ctx context.Context, id string, req *payload.CategoryRequest) (err error) {
+	ctx, span := startSpan(ctx, "CategoryService.Update", attribute.String("category.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Update(ctx, id, req)
+}
+
+func (s *tracedCategoryService) FindAll(ctx context.Context) (res []*payload.CategoryResponse, err error) {
+	ctx, span := startSpan(ctx, "CategoryService.FindAll")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindAll(ctx)
+}
+
+func (s *tracedCategoryService) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "CategoryService.Delete", attribute.String("category.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Delete(ctx, id)
+}
+
+func (s *tracedCategoryService) FindBooks(ctx context.Context, id string) (res []*payload.BookResponse, err error) {
+	ctx, span := startSpan(ctx, "CategoryService.FindBooks", attribute.String("category.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindBooks(ctx, id)
+}
+
+type tracedUserService struct {
+	next UserService
+}
+
+// NewTracedUserService wraps a UserService so that every call is recorded as
+// a span.
+func NewTracedUserService(next UserService) UserService {
+	return &tracedUserService{next: next}
+}
+
+func (s *tracedUserService) Register(ctx context.Context, req *payload.RegisterRequest) (err error) {
+	ctx, span := startSpan(ctx, "UserService.Register", attribute.String("user.name", req.Username))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Register(ctx, req)
+}
+
+func (s *tracedUserService) Login(ctx context.Context, req *payload.LoginRequest) (res *payload.LoginResponse, err error) {
+	ctx, span := startSpan(ctx, "UserService.Login", attribute.String("user.name", req.Username))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Login(ctx, req)
+}
+
+type tracedWebhookService struct {
+	next WebhookService
+}
+
+// NewTracedWebhookService wraps a WebhookService so that every call is
+// recorded as a span.
+func NewTracedWebhookService(next WebhookService) WebhookService {
+	return &tracedWebhookService{next: next}
+}
+
+func (s *tracedWebhookService) Find(ctx context.Context, id string) (res *payload.WebhookResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Find", attribute.String("webhook.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Find(ctx, id)
+}
+
+func (s *tracedWebhookService) Store(ctx context.Context, req *payload.WebhookRequest) (res *payload.WebhookResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Store")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Store(ctx, req)
+}
+
+func (s *tracedWebhookService) Update(ctx context.Context, id string, req *payload.WebhookRequest) (err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Update", attribute.String("webhook.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Update(ctx, id, req)
+}
+
+func (s *tracedWebhookService) FindAll(ctx context.Context) (res []*payload.WebhookResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.FindAll")
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindAll(ctx)
+}
+
+func (s *tracedWebhookService) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Delete", attribute.String("webhook.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Delete(ctx, id)
+}
+
+func (s *tracedWebhookService) FindDeliveries(ctx context.Context, id string) (res []*payload.WebhookDeliveryResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.FindDeliveries", attribute.String("webhook.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.next.FindDeliveries(ctx, id)
+}
+
+func (s *tracedWebhookService) Redeliver(ctx context.Context, id string, deliveryId string) (res *payload.WebhookDeliveryResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Redeliver",
+		attribute.String("webhook.id", id),
+		attribute.String("webhook.delivery_id", deliveryId),
+	)
+	defer func() { endSpan(span, err) }()
+
+	return s.next.Redeliver(ctx, id, deliveryId)
+}
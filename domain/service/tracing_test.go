@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bookstore.com/test"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_tracedBookService_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	bookService := NewMockBookService(ctrl)
+	bookService.EXPECT().Find(gomock.Any(), test.BookId1).Return(nil, errors.New("error occur"))
+
+	s := NewTracedBookService(bookService)
+	if _, err := s.Find(context.TODO(), test.BookId1); err == nil {
+		t.Fatalf("tracedBookService.Find() expected error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("tracedBookService.Find() recorded %d spans, want 1", len(spans))
+	}
+
+	if spans[0].Name != "BookService.Find" {
+		t.Errorf("span name = %s, want %s", spans[0].Name, "BookService.Find")
+	}
+
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("span status = %v, want %v", spans[0].Status.Code, codes.Error)
+	}
+}
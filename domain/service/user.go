@@ -37,6 +37,7 @@ func (s *userService) Register(ctx context.Context, req *payload.RegisterRequest
 		return portError.NewBadRequestError("User is exist.", nil)
 	}
 
+	user.Role = entity.RoleUser
 	user.Password, err = Hash(user.Password)
 	if err != nil {
 		return err
@@ -66,6 +67,7 @@ func (s *userService) Login(ctx context.Context, req *payload.LoginRequest) (*pa
 
 	claims := &entity.Claims{
 		Username: req.Username,
+		Role:     user_tmp.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 		},
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/tools/mapper"
+	"github.com/google/uuid"
+)
+
+type webhookService struct {
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, deliveryRepo repository.WebhookDeliveryRepository) WebhookService {
+	return &webhookService{webhookRepo: webhookRepo, deliveryRepo: deliveryRepo}
+}
+
+func (s *webhookService) Find(ctx context.Context, id string) (*payload.WebhookResponse, error) {
+	if id == "" {
+		return nil, portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	webhook, err := s.webhookRepo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &payload.WebhookResponse{}
+	if err := mapper.MapStructsWithJSONTags(webhook, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *webhookService) Store(ctx context.Context, req *payload.WebhookRequest) (*payload.WebhookResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	webhook := &entity.Webhook{}
+	if err := mapper.MapStructsWithJSONTags(req, webhook); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Store(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	res := &payload.WebhookResponse{}
+	if err := mapper.MapStructsWithJSONTags(webhook, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *webhookService) Update(ctx context.Context, id string, req *payload.WebhookRequest) error {
+	if id == "" {
+		return portError.NewBadRequestError("Id is empty.", nil)
+	}
+
+	if err := req.Validate(); err != nil {
+		return portError.NewBadRequestError(err.Error(), nil)
+	}
+
+	webhook, err := s.webhookRepo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := mapper.MapStructsWithJSONTags(req, webhook); err != nil {
+		return err
+	}
+
+	webhook.Id = id
+
+	return s.webhookRepo.Update(ctx, webhook)
+}
+
+func (s *webhookService) FindAll(ctx context.Context) ([]*payload.WebhookResponse, error) {
+	webhooks, err := s.webhookRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.WebhookResponse{}
+	for _, webhook := range webhooks {
+		webhookRes := &payload.WebhookResponse{}
+		if err := mapper.MapStructsWithJSONTags(webhook, webhookRes); err != nil {
+			return nil, err
+		}
+		list = append(list, webhookRes)
+	}
+
+	return list, nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, id string) error {
+	_, err := s.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.webhookRepo.Delete(ctx, id)
+}
+
+func (s *webhookService) FindDeliveries(ctx context.Context, id string) ([]*payload.WebhookDeliveryResponse, error) {
+	_, err := s.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.deliveryRepo.FindByWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*payload.WebhookDeliveryResponse{}
+	for _, delivery := range deliveries {
+		deliveryRes := &payload.WebhookDeliveryResponse{}
+		if err := mapper.MapStructsWithJSONTags(delivery, deliveryRes); err != nil {
+			return nil, err
+		}
+		list = append(list, deliveryRes)
+	}
+
+	return list, nil
+}
+
+// Redeliver queues a new delivery of the event sent by deliveryId. The
+// original delivery and its attempts are kept.
+func (s *webhookService) Redeliver(ctx context.Context, id string, deliveryId string) (*payload.WebhookDeliveryResponse, error) {
+	if deliveryId == "" {
+		return nil, portError.NewBadRequestError("Delivery id is empty.", nil)
+	}
+
+	_, err := s.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.deliveryRepo.Find(ctx, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if original.WebhookId != id {
+		return nil, portError.NewNotFoundError("Webhook delivery not found.", nil)
+	}
+
+	delivery := &entity.WebhookDelivery{
+		Id:           uuid.NewString(),
+		WebhookId:    original.WebhookId,
+		EventId:      original.EventId,
+		EventType:    original.EventType,
+		Payload:      original.Payload,
+		RedeliveryOf: original.Id,
+	}
+	if err := s.deliveryRepo.Store(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	res := &payload.WebhookDeliveryResponse{}
+	if err := mapper.MapStructsWithJSONTags(delivery, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type webhookDispatcher struct {
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+}
+
+// NewWebhookDispatcher creates a Publisher queuing a delivery of every event
+// to each webhook subscribed to its type. The deliveries are sent by the
+// WebhookWorker.
+func NewWebhookDispatcher(webhookRepo repository.WebhookRepository, deliveryRepo repository.WebhookDeliveryRepository) event.Publisher {
+	return &webhookDispatcher{webhookRepo: webhookRepo, deliveryRepo: deliveryRepo}
+}
+
+func (d *webhookDispatcher) Publish(ctx context.Context, e *event.Event) error {
+	webhooks, err := d.webhookRepo.FindByEventType(ctx, string(e.Type))
+	if err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		delivery := &entity.WebhookDelivery{
+			Id:        e.Id + ":" + webhook.Id,
+			WebhookId: webhook.Id,
+			EventId:   e.Id,
+			EventType: string(e.Type),
+			Payload:   string(raw),
+		}
+		if err := d.deliveryRepo.Store(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/port/payload"
+	"bookstore.com/repository"
+	"bookstore.com/test"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	webhookId1     = "64fbf00fc3a88d3a02b96901"
+	webhookURL1    = "https://hooks.example.com/bookstore"
+	webhookSecret1 = "0123456789abcdef"
+)
+
+func newWebhookRequest(url string) *payload.WebhookRequest {
+	return &payload.WebhookRequest{
+		URL:        url,
+		EventTypes: []string{"book.created"},
+		Secret:     webhookSecret1,
+	}
+}
+
+func Test_webhookService_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name        string
+		webhookRepo func() repository.WebhookRepository
+		id          string
+		want        *payload.WebhookResponse
+		wantStatus  int
+	}{
+		{
+			name: "find webhook successfully",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().Find(gomock.Any(), webhookId1).Return(&entity.Webhook{
+					Id:         webhookId1,
+					URL:        webhookURL1,
+					EventTypes: []string{"book.created"},
+					Secret:     webhookSecret1,
+					CreatedAt:  test.CreatedAt,
+					UpdatedAt:  test.UpdatedAt,
+				}, nil)
+
+				return webhookRepo
+			},
+			id: webhookId1,
+			want: &payload.WebhookResponse{
+				Id:         webhookId1,
+				URL:        webhookURL1,
+				EventTypes: []string{"book.created"},
+				CreatedAt:  test.CreatedAtStr,
+				UpdatedAt:  test.UpdatedAtStr,
+			},
+		},
+		{
+			name: "id empty",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			id:         "",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "webhook not found",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().Find(gomock.Any(), webhookId1).Return(nil, portError.NewNotFoundError("Webhook not found.", nil))
+
+				return webhookRepo
+			},
+			id:         webhookId1,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewWebhookService(tt.webhookRepo(), repository.NewMockWebhookDeliveryRepository(ctrl))
+			got, err := s.Find(context.TODO(), tt.id)
+			if status := apiStatus(err); status != tt.wantStatus {
+				t.Errorf("webhookService.Find() error = %v, want status %d", err, tt.wantStatus)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("webhookService.Find() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookService_Store(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name        string
+		webhookRepo func() repository.WebhookRepository
+		req         *payload.WebhookRequest
+		wantStatus  int
+	}{
+		{
+			name: "store webhook successfully",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().Store(gomock.Any(), &entity.Webhook{
+					URL:        webhookURL1,
+					EventTypes: []string{"book.created"},
+					Secret:     webhookSecret1,
+				}).Return(nil)
+
+				return webhookRepo
+			},
+			req: newWebhookRequest(webhookURL1),
+		},
+		{
+			name: "store webhook failed because the scheme is not http",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			req:        newWebhookRequest("ftp://hooks.example.com/bookstore"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store webhook failed because the host is loopback",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			req:        newWebhookRequest("http://127.0.0.1:8080/hook"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store webhook failed because the host is localhost",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			req:        newWebhookRequest("http://localhost/hook"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store webhook failed because the host is link-local",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			req:        newWebhookRequest("http://169.254.169.254/latest/meta-data"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store webhook failed because the host is private",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			req:        newWebhookRequest("https://10.0.0.1/hook"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store webhook failed because the host is private IPv6",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			req:        newWebhookRequest("https://[fd00::1]/hook"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store webhook failed",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(portError.NewConflictError("Webhook already exists.", nil))
+
+				return webhookRepo
+			},
+			req:        newWebhookRequest(webhookURL1),
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewWebhookService(tt.webhookRepo(), repository.NewMockWebhookDeliveryRepository(ctrl))
+			if _, err := s.Store(context.TODO(), tt.req); apiStatus(err) != tt.wantStatus {
+				t.Errorf("webhookService.Store() error = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_webhookService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name        string
+		webhookRepo func() repository.WebhookRepository
+		id          string
+		req         *payload.WebhookRequest
+		wantStatus  int
+	}{
+		{
+			name: "update webhook successfully",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().Find(gomock.Any(), webhookId1).Return(&entity.Webhook{
+					Id:         webhookId1,
+					URL:        "https://old.example.com/hook",
+					EventTypes: []string{"author.created"},
+					Secret:     webhookSecret1,
+				}, nil)
+				webhookRepo.EXPECT().Update(gomock.Any(), &entity.Webhook{
+					Id:         webhookId1,
+					URL:        webhookURL1,
+					EventTypes: []string{"book.created"},
+					Secret:     webhookSecret1,
+				}).Return(nil)
+
+				return webhookRepo
+			},
+			id:  webhookId1,
+			req: newWebhookRequest(webhookURL1),
+		},
+		{
+			name: "id empty",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			req:        newWebhookRequest(webhookURL1),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "update webhook failed because the host is loopback",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			id:         webhookId1,
+			req:        newWebhookRequest("http://[::1]/hook"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "webhook not found",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().Find(gomock.Any(), webhookId1).Return(nil, portError.NewNotFoundError("Webhook not found.", nil))
+
+				return webhookRepo
+			},
+			id:         webhookId1,
+			req:        newWebhookRequest(webhookURL1),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewWebhookService(tt.webhookRepo(), repository.NewMockWebhookDeliveryRepository(ctrl))
+			if err := s.Update(context.TODO(), tt.id, tt.req); apiStatus(err) != tt.wantStatus {
+				t.Errorf("webhookService.Update() error = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_webhookService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name        string
+		webhookRepo func() repository.WebhookRepository
+		want        []*payload.WebhookResponse
+		wantErr     bool
+	}{
+		{
+			name: "find all webhooks successfully",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().FindAll(gomock.Any()).Return([]*entity.Webhook{{
+					Id:         webhookId1,
+					URL:        webhookURL1,
+					EventTypes: []string{"book.created"},
+					Secret:     webhookSecret1,
+					CreatedAt:  test.CreatedAt,
+					UpdatedAt:  test.UpdatedAt,
+				}}, nil)
+
+				return webhookRepo
+			},
+			want: []*payload.WebhookResponse{{
+				Id:         webhookId1,
+				URL:        webhookURL1,
+				EventTypes: []string{"book.created"},
+				CreatedAt:  test.CreatedAtStr,
+				UpdatedAt:  test.UpdatedAtStr,
+			}},
+		},
+		{
+			name: "no webhooks",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().FindAll(gomock.Any()).Return(nil, nil)
+
+				return webhookRepo
+			},
+			want: []*payload.WebhookResponse{},
+		},
+		{
+			name: "find all webhooks failed",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("error occur"))
+
+				return webhookRepo
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewWebhookService(tt.webhookRepo(), repository.NewMockWebhookDeliveryRepository(ctrl))
+			got, err := s.FindAll(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookService.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("webhookService.FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := []struct {
+		name        string
+		webhookRepo func() repository.WebhookRepository
+		id          string
+		wantStatus  int
+	}{
+		{
+			name: "delete webhook successfully",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().Find(gomock.Any(), webhookId1).Return(&entity.Webhook{Id: webhookId1}, nil)
+				webhookRepo.EXPECT().Delete(gomock.Any(), webhookId1).Return(nil)
+
+				return webhookRepo
+			},
+			id: webhookId1,
+		},
+		{
+			name: "id empty",
+			webhookRepo: func() repository.WebhookRepository {
+				return repository.NewMockWebhookRepository(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "webhook not found",
+			webhookRepo: func() repository.WebhookRepository {
+				webhookRepo := repository.NewMockWebhookRepository(ctrl)
+				webhookRepo.EXPECT().Find(gomock.Any(), webhookId1).Return(nil, portError.NewNotFoundError("Webhook not found.", nil))
+
+				return webhookRepo
+			},
+			id:         webhookId1,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewWebhookService(tt.webhookRepo(), repository.NewMockWebhookDeliveryRepository(ctrl))
+			if err := s.Delete(context.TODO(), tt.id); apiStatus(err) != tt.wantStatus {
+				t.Errorf("webhookService.Delete() error = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
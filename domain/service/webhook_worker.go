@@ -0,0 +1,165 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"bookstore.com/tools/logger"
+	"bookstore.com/tools/signature"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	HeaderWebhookEvent    = "X-Bookstore-Event"
+	HeaderWebhookDelivery = "X-Bookstore-Delivery"
+)
+
+// WebhookWorker sends the queued webhook deliveries. Every request is signed
+// with the webhook secret, see package signature. Deliveries not answered
+// with a 2xx status are retried with an exponential backoff and given up
+// after MaxAttempts.
+type WebhookWorker struct {
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	client       *http.Client
+	opts         RelayOptions
+}
+
+func NewWebhookWorker(
+	webhookRepo repository.WebhookRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	client *http.Client,
+	opts RelayOptions,
+) *WebhookWorker {
+	opts.setDefaults()
+	return &WebhookWorker{webhookRepo: webhookRepo, deliveryRepo: deliveryRepo, client: client, opts: opts}
+}
+
+// Run sends the deliveries until ctx is done.
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Drain(ctx); err != nil {
+			logger.FromContext(ctx).Error("webhook worker failed", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain sends every delivery due.
+func (w *WebhookWorker) Drain(ctx context.Context) error {
+	for ctx.Err() == nil {
+		delivery, err := w.deliveryRepo.Claim(ctx, w.opts.Lease)
+		if err != nil {
+			return err
+		}
+		if delivery == nil {
+			return nil
+		}
+
+		if err := w.deliver(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *WebhookWorker) deliver(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	attempts := len(delivery.Attempts) + 1
+
+	webhook, err := w.webhookRepo.Find(ctx, delivery.WebhookId)
+	if err != nil {
+		var apiErr *portError.ApiError
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+			attempt := &entity.DeliveryAttempt{At: time.Now(), Error: "webhook deleted"}
+			return w.deliveryRepo.RecordAttempt(ctx, delivery.Id, attempt, entity.DeliveryDead, attempt.At)
+		}
+		return err
+	}
+
+	attempt := w.send(ctx, webhook, delivery)
+	if attempt.Error == "" {
+		return w.deliveryRepo.RecordAttempt(ctx, delivery.Id, attempt, entity.DeliverySucceeded, attempt.At)
+	}
+
+	l := logger.FromContext(ctx).With(
+		slog.String("deliveryId", delivery.Id),
+		slog.String("webhookId", webhook.Id),
+		slog.Int("attempts", attempts),
+		slog.String("error", attempt.Error),
+	)
+
+	if attempts >= w.opts.MaxAttempts {
+		l.Error("webhook delivery given up")
+		return w.deliveryRepo.RecordAttempt(ctx, delivery.Id, attempt, entity.DeliveryDead, attempt.At)
+	}
+
+	retryAt := time.Now().Add(w.opts.backoff(attempts))
+	l.Warn("webhook delivery failed", slog.Time("retryAt", retryAt))
+	return w.deliveryRepo.RecordAttempt(ctx, delivery.Id, attempt, entity.DeliveryPending, retryAt)
+}
+
+func (w *WebhookWorker) send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (attempt *entity.DeliveryAttempt) {
+	ctx, span := startSpan(ctx, "WebhookWorker.Send",
+		attribute.String("webhook.id", webhook.Id),
+		attribute.String("webhook.delivery_id", delivery.Id),
+		attribute.String("event.type", delivery.EventType),
+	)
+
+	start := time.Now()
+	attempt = &entity.DeliveryAttempt{At: start}
+	defer func() {
+		attempt.Duration = time.Since(start).Milliseconds()
+		var err error
+		if attempt.Error != "" {
+			err = errors.New(attempt.Error)
+		}
+		endSpan(span, err)
+	}()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(HeaderWebhookDelivery, delivery.Id)
+	req.Header.Set(signature.HeaderTimestamp, strconv.FormatInt(start.Unix(), 10))
+	req.Header.Set(signature.HeaderSignature, signature.Sign(webhook.Secret, start, body))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	attempt.StatusCode = res.StatusCode
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("unexpected status %d", res.StatusCode)
+	}
+
+	return attempt
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"bookstore.com/tools/signature"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookWorker_Drain(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	ctrl := gomock.NewController(t)
+	opts := RelayOptions{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: time.Minute}
+	delivery := func(attempts int) *entity.WebhookDelivery {
+		return &entity.WebhookDelivery{
+			Id:        "event-1:webhook-1",
+			WebhookId: "webhook-1",
+			EventId:   "event-1",
+			EventType: "book.created",
+			Payload:   `{"id":"event-1"}`,
+			Attempts:  make([]*entity.DeliveryAttempt, attempts),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		status     int
+		attempts   int
+		webhookErr error
+		wantStatus string
+	}{
+		{name: "delivered", status: http.StatusNoContent, wantStatus: entity.DeliverySucceeded},
+		{name: "retry on error status", status: http.StatusInternalServerError, attempts: 1, wantStatus: entity.DeliveryPending},
+		{name: "give up after max attempts", status: http.StatusBadGateway, attempts: 2, wantStatus: entity.DeliveryDead},
+		{name: "webhook deleted", webhookErr: portError.NewNotFoundError("Webhook not found.", nil), wantStatus: entity.DeliveryDead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				err := signature.Verify(secret, r.Header.Get(signature.HeaderSignature), r.Header.Get(signature.HeaderTimestamp), body, time.Minute)
+				if err != nil {
+					t.Errorf("signature.Verify() error = %v", err)
+				}
+				if got := r.Header.Get(HeaderWebhookDelivery); got != "event-1:webhook-1" {
+					t.Errorf("%s = %q", HeaderWebhookDelivery, got)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			webhookRepo := repository.NewMockWebhookRepository(ctrl)
+			if tt.webhookErr != nil {
+				webhookRepo.EXPECT().Find(gomock.Any(), "webhook-1").Return(nil, tt.webhookErr)
+			} else {
+				webhookRepo.EXPECT().Find(gomock.Any(), "webhook-1").
+					Return(&entity.Webhook{Id: "webhook-1", URL: srv.URL, Secret: secret}, nil)
+			}
+
+			deliveryRepo := repository.NewMockWebhookDeliveryRepository(ctrl)
+			gomock.InOrder(
+				deliveryRepo.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(delivery(tt.attempts), nil),
+				deliveryRepo.EXPECT().RecordAttempt(gomock.Any(), "event-1:webhook-1", gomock.Any(), tt.wantStatus, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id string, attempt *entity.DeliveryAttempt, status string, next time.Time) error {
+						if tt.webhookErr == nil && attempt.StatusCode != tt.status {
+							t.Errorf("attempt.StatusCode = %d, want %d", attempt.StatusCode, tt.status)
+						}
+						if status == entity.DeliveryPending && time.Until(next) < time.Second {
+							t.Errorf("retry at %v, want a backoff", next)
+						}
+						return nil
+					}),
+				deliveryRepo.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(nil, nil),
+			)
+
+			w := NewWebhookWorker(webhookRepo, deliveryRepo, srv.Client(), opts)
+			if err := w.Drain(context.Background()); err != nil {
+				t.Errorf("Drain() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcher_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	webhookRepo := repository.NewMockWebhookRepository(ctrl)
+	webhookRepo.EXPECT().FindByEventType(gomock.Any(), "book.created").
+		Return([]*entity.Webhook{{Id: "webhook-1"}, {Id: "webhook-2"}}, nil)
+
+	deliveryRepo := repository.NewMockWebhookDeliveryRepository(ctrl)
+	for _, id := range []string{"webhook-1", "webhook-2"} {
+		id := id
+		deliveryRepo.EXPECT().Store(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, d *entity.WebhookDelivery) error {
+				if d.Id != "event-1:"+id || d.WebhookId != id || d.EventType != "book.created" {
+					t.Errorf("unexpected delivery %+v", d)
+				}
+				return nil
+			})
+	}
+
+	d := NewWebhookDispatcher(webhookRepo, deliveryRepo)
+	if err := d.Publish(context.Background(), &event.Event{Id: "event-1", Type: event.BookCreated}); err != nil {
+		t.Errorf("Publish() error = %v", err)
+	}
+}
@@ -1,17 +1,35 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 
 	"bookstore.com/api"
 	"bookstore.com/config"
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
 	"bookstore.com/domain/service"
+	"bookstore.com/repository"
+	filerepo "bookstore.com/repository/file"
 	google "bookstore.com/repository/google"
+	memoryrepo "bookstore.com/repository/memory"
 	mongorepo "bookstore.com/repository/mongo"
+	"bookstore.com/tools/logger"
+	"bookstore.com/tools/migrate"
+	"bookstore.com/tools/netguard"
+	"bookstore.com/tools/ratelimit"
+	"bookstore.com/tools/telemetry"
+	"bookstore.com/tools/tlsconfig"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/cors"
 	"github.com/go-chi/jwtauth"
 )
 
@@ -27,61 +45,380 @@ func main() {
 		panic(err)
 	}
 
-	authorRepo, err := mongorepo.NewAuthorRepository(conf.DB.URL, conf.DB.Name, conf.DB.Timeout)
+	log, err := logger.New(os.Stdout, conf.Log.Level, conf.Log.Format)
 	if err != nil {
 		panic(err)
 	}
+	slog.SetDefault(log)
 
-	bookRepo, err := mongorepo.NewBookRepository(conf.DB.URL, conf.DB.Name, conf.DB.Timeout)
+	repos, err := newRepositories(conf.DB)
 	if err != nil {
 		panic(err)
 	}
 
-	notificationRepo := google.FirebaseDB()
-	notificationRepo.Connect()
+	if flag.Arg(0) == "migrate" {
+		if err := migrateCommand(repos.migrator, flag.Args()[1:]); err != nil {
+			log.Error("migration failed", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return
+	}
 
-	authorSvc := service.NewAuthorService(authorRepo, notificationRepo)
-	bookSvc := service.NewBookService(bookRepo, authorRepo, notificationRepo)
+	if conf.DB.Migrate {
+		if err := migrateCommand(repos.migrator, []string{"up"}); err != nil {
+			panic(err)
+		}
+	}
 
-	authorHandler := api.NewAuthorHandler(authorSvc)
-	bookHandler := api.NewBookHandler(bookSvc)
+	shutdownTracing, err := telemetry.Setup(context.Background(), conf.Tracing)
+	if err != nil {
+		panic(err)
+	}
+	defer shutdownTracing(context.Background())
+
+	publisher, err := newPublisher(conf.Events)
+	if err != nil {
+		panic(err)
+	}
+
+	broker := event.NewBroker(conf.Events.Stream.ReplaySize, conf.Events.Stream.Buffer)
+
+	relay := service.NewOutboxRelay(
+		repos.outbox,
+		event.NewMultiPublisher(publisher, service.NewWebhookDispatcher(repos.webhook, repos.delivery), broker),
+		newRelayOptions(conf.Events.Outbox),
+	)
+	go relay.Run(logger.NewContext(context.Background(), log))
+
+	webhookWorker := service.NewWebhookWorker(
+		repos.webhook,
+		repos.delivery,
+		&http.Client{Timeout: time.Duration(conf.Events.Webhooks.Timeout) * time.Second, Transport: newWebhookTransport()},
+		newRelayOptions(conf.Events.Webhooks.Relay),
+	)
+	go webhookWorker.Run(logger.NewContext(context.Background(), log))
 
-	repoUser, err := mongorepo.NewUserRepository(conf.DB.URL, conf.DB.Name, conf.DB.Timeout)
+	blobs, err := newBlobStore(conf.Covers, conf.DB)
 	if err != nil {
 		panic(err)
 	}
 
-	userSvc := service.NewUserService(repoUser)
+	authorSvc := service.NewTracedAuthorService(service.NewAuthorService(repos.author, blobs, repos.outbox, repos.transactor))
+	bookSvc := service.NewTracedBookService(service.NewBookService(
+		repos.book, repos.author, repos.category, repos.publisher, repos.series, blobs, repos.outbox, repos.transactor,
+	))
+	publisherSvc := service.NewTracedPublisherService(
+		service.NewPublisherService(repos.publisher, repos.book, repos.outbox, repos.transactor),
+	)
+	seriesSvc := service.NewTracedSeriesService(
+		service.NewSeriesService(repos.series, repos.book, repos.outbox, repos.transactor),
+	)
+	categorySvc := service.NewTracedCategoryService(service.NewCategoryService(repos.category, repos.book, repos.outbox, repos.transactor))
+	coverSvc := service.NewTracedCoverService(service.NewCoverService(repos.book, blobs, conf.Covers.MaxSize))
+	photoSvc := service.NewTracedPhotoService(service.NewPhotoService(repos.author, blobs, conf.Covers.MaxSize))
+
+	webhookSvc := service.NewTracedWebhookService(service.NewWebhookService(repos.webhook, repos.delivery))
+
+	authorHandler := api.NewAuthorHandler(authorSvc)
+	bookHandler := api.NewBookHandler(bookSvc)
+	categoryHandler := api.NewCategoryHandler(categorySvc)
+	coverHandler := api.NewCoverHandler(coverSvc)
+	photoHandler := api.NewPhotoHandler(photoSvc)
+	publisherHandler := api.NewPublisherHandler(publisherSvc)
+	seriesHandler := api.NewSeriesHandler(seriesSvc)
+	webhookHandler := api.NewWebhookHandler(webhookSvc)
+	eventHandler := api.NewEventHandler(
+		broker,
+		time.Duration(conf.Events.Stream.Heartbeat)*time.Second,
+		conf.Server.CORS.AllowedOrigins,
+	)
+
+	userSvc := service.NewTracedUserService(service.NewUserService(repos.user))
 
 	handlerUser := api.NewUserHandler(userSvc)
 
+	var rateLimitStore ratelimit.Store
+	switch conf.RateLimit.Backend {
+	case "mongo":
+		rateLimitStore, err = mongorepo.NewRateLimitStore(conf.DB.URL, conf.DB.Name, conf.DB.Timeout)
+		if err != nil {
+			panic(err)
+		}
+	default:
+		rateLimitStore = ratelimit.NewMemoryStore()
+	}
+
+	rateLimit := func(group string) func(http.Handler) http.Handler {
+		if !conf.RateLimit.Enabled {
+			return func(next http.Handler) http.Handler { return next }
+		}
+		return api.RateLimit(rateLimitStore, group, newRateLimitPolicy(conf.RateLimit.Groups[group]))
+	}
+
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(api.Tracing)
+	r.Use(api.RequestLogger(log))
 	r.Use(middleware.Recoverer)
+	r.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   conf.Server.CORS.AllowedOrigins,
+		AllowedMethods:   conf.Server.CORS.AllowedMethods,
+		AllowedHeaders:   conf.Server.CORS.AllowedHeaders,
+		ExposedHeaders:   conf.Server.CORS.ExposedHeaders,
+		AllowCredentials: conf.Server.CORS.AllowCredentials,
+		MaxAge:           conf.Server.CORS.MaxAge,
+	}))
+	r.Use(api.SecureHeaders(conf.Server.HSTSMaxAge))
+	r.Use(api.MaxBodySize(conf.Server.MaxBodySize))
 
-	r.Post("/register", handlerUser.Register)
-	r.Post("/login", handlerUser.Login)
+	r.Group(func(r chi.Router) {
+		r.Use(rateLimit("auth"))
+		r.Post("/register", handlerUser.Register)
+		r.Post("/login", handlerUser.Login)
+	})
 
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Use(jwtauth.Verifier(tokenAuth))
 		r.Use(jwtauth.Authenticator)
+		r.Use(api.UserLogger)
+		r.Use(api.Actor)
+		r.Use(api.Language)
 		r.Route("/authors", func(r chi.Router) {
+			r.Use(rateLimit("authors"))
 			r.Get("/{id}", authorHandler.Get)
 			r.Post("/", authorHandler.Post)
 			r.Put("/{id}", authorHandler.Put)
 			r.Delete("/{id}", authorHandler.Delete)
 			r.Get("/", authorHandler.GetAll)
 		})
+		r.Route("/authors/{id}/photo", func(r chi.Router) {
+			r.Use(rateLimit("authors"))
+			r.Get("/", photoHandler.Get)
+			r.With(api.MaxBodySize(conf.Covers.MaxSize+imageFormOverhead)).Post("/", photoHandler.Post)
+		})
 		r.Route("/books", func(r chi.Router) {
+			r.Use(rateLimit("books"))
+			r.Get("/isbn/{isbn}", bookHandler.GetByISBN)
 			r.Get("/{id}", bookHandler.Get)
+			r.Get("/{id}/translations", bookHandler.GetTranslations)
+			r.Post("/{id}/tags", bookHandler.PostTags)
+			r.Delete("/{id}/tags/{tag}", bookHandler.DeleteTag)
 			r.Post("/", bookHandler.Post)
 			r.Put("/{id}", bookHandler.Put)
 			r.Delete("/{id}", bookHandler.Delete)
 			r.Get("/", bookHandler.GetAll)
 		})
+		r.Route("/tags", func(r chi.Router) {
+			r.Use(rateLimit("books"))
+			r.Get("/", bookHandler.GetTags)
+		})
+		r.Route("/books/{id}/cover", func(r chi.Router) {
+			r.Use(rateLimit("books"))
+			r.Get("/", coverHandler.Get)
+			r.With(api.MaxBodySize(conf.Covers.MaxSize+imageFormOverhead)).Post("/", coverHandler.Post)
+		})
+		r.Route("/publishers", func(r chi.Router) {
+			r.Use(rateLimit("publishers"))
+			r.Get("/{id}", publisherHandler.Get)
+			r.Post("/", publisherHandler.Post)
+			r.Put("/{id}", publisherHandler.Put)
+			r.Delete("/{id}", publisherHandler.Delete)
+			r.Get("/", publisherHandler.GetAll)
+		})
+		r.Route("/series", func(r chi.Router) {
+			r.Use(rateLimit("series"))
+			r.Get("/{id}", seriesHandler.Get)
+			r.Get("/{id}/books", seriesHandler.GetBooks)
+			r.Post("/", seriesHandler.Post)
+			r.Put("/{id}", seriesHandler.Put)
+			r.Delete("/{id}", seriesHandler.Delete)
+			r.Get("/", seriesHandler.GetAll)
+		})
+		r.Route("/categories", func(r chi.Router) {
+			r.Use(rateLimit("categories"))
+			r.Get("/{id}", categoryHandler.Get)
+			r.Get("/{id}/books", categoryHandler.GetBooks)
+			r.Post("/", categoryHandler.Post)
+			r.Put("/{id}", categoryHandler.Put)
+			r.Delete("/{id}", categoryHandler.Delete)
+			r.Get("/", categoryHandler.GetAll)
+		})
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(api.RequireRole(entity.RoleAdmin))
+			r.Use(rateLimit("webhooks"))
+			r.Get("/{id}", webhookHandler.Get)
+			r.Post("/", webhookHandler.Post)
+			r.Put("/{id}", webhookHandler.Put)
+			r.Delete("/{id}", webhookHandler.Delete)
+			r.Get("/", webhookHandler.GetAll)
+			r.Get("/{id}/deliveries", webhookHandler.GetDeliveries)
+			r.Post("/{id}/deliveries/{deliveryId}/redeliver", webhookHandler.Redeliver)
+		})
+		r.Route("/events", func(r chi.Router) {
+			r.Get("/", eventHandler.Stream)
+			r.Get("/ws", eventHandler.WebSocket)
+		})
 	})
 
-	log.Fatal(http.ListenAndServe(conf.Server.Port, r))
+	srv := &http.Server{Addr: conf.Server.Port, Handler: r}
+	if conf.Server.TLS.Enabled {
+		err = serveTLS(srv, conf.Server.TLS, log)
+	} else {
+		log.Info("server started", slog.String("addr", conf.Server.Port))
+		err = srv.ListenAndServe()
+	}
+	if err != nil {
+		log.Error("server stopped", slog.Any("error", err))
+		shutdownTracing(context.Background())
+		os.Exit(1)
+	}
+}
+
+func newRateLimitPolicy(conf config.RateLimitGroup) ratelimit.Policy {
+	newLimit := func(rule config.RateLimitRule) ratelimit.Limit {
+		return ratelimit.Limit{
+			Requests: rule.Requests,
+			Period:   time.Duration(rule.Period) * time.Second,
+			Burst:    rule.Burst,
+		}
+	}
+
+	policy := ratelimit.Policy{
+		Default: newLimit(conf.Default),
+		Roles:   map[string]ratelimit.Limit{},
+	}
+	for role, rule := range conf.Roles {
+		policy.Roles[role] = newLimit(rule)
+	}
+
+	return policy
+}
+
+func newRelayOptions(conf config.Relay) service.RelayOptions {
+	return service.RelayOptions{
+		PollInterval: time.Duration(conf.PollInterval) * time.Second,
+		Lease:        time.Duration(conf.Lease) * time.Second,
+		MaxAttempts:  conf.MaxAttempts,
+		Backoff:      time.Duration(conf.Backoff) * time.Second,
+		MaxBackoff:   time.Duration(conf.MaxBackoff) * time.Second,
+	}
+}
+
+// migrateCommand runs `migrate up`, `migrate down [steps]` or `migrate status`.
+func migrateCommand(migrator *migrate.Migrator, args []string) error {
+	var err error
+	ctx := context.Background()
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		done, err := migrator.Up(ctx)
+		for _, m := range done {
+			slog.Info("migration applied", slog.Int("version", m.Version), slog.String("description", m.Description))
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		done, err := migrator.Down(ctx, steps)
+		for _, m := range done {
+			slog.Info("migration reverted", slog.Int("version", m.Version), slog.String("description", m.Description))
+		}
+		return err
+	case "status":
+		list, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range list {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%4d  %-25s  %s\n", status.Version, applied, status.Description)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q, want up, down or status", command)
+	}
+}
+
+// imageFormOverhead is allowed on top of the image size for the rest of the
+// multipart form.
+const imageFormOverhead = 64 << 10
+
+func newBlobStore(conf config.Covers, db config.Database) (repository.BlobStore, error) {
+	switch conf.Store {
+	case "", "file":
+		return filerepo.NewBlobStore(conf.Dir)
+	case "gridfs":
+		return mongorepo.NewBlobStore(db.URL, db.Name, db.Timeout)
+	default:
+		return nil, fmt.Errorf("invalid image store %q", conf.Store)
+	}
+}
+
+func newPublisher(conf config.Events) (event.Publisher, error) {
+	switch conf.Publisher {
+	case "memory":
+		return memoryrepo.NewPublisher(), nil
+	case "file":
+		return filerepo.NewPublisher(conf.File)
+	case "", "firebase":
+		return google.NewFireDB(context.Background(), conf.Firebase.CredentialFile, conf.Firebase.DatabaseURL, conf.Firebase.Path)
+	case "firebase-rest":
+		return google.NewRestDB(context.Background(), conf.Firebase.CredentialFile, conf.Firebase.DatabaseURL, conf.Firebase.Path)
+	default:
+		return nil, fmt.Errorf("invalid event publisher %q", conf.Publisher)
+	}
+}
+
+func serveTLS(srv *http.Server, conf config.TLS, log *slog.Logger) error {
+	tlsConf, reloader, err := tlsconfig.New(conf)
+	if err != nil {
+		return err
+	}
+	srv.TLSConfig = tlsConf
+
+	if conf.ReloadInterval > 0 {
+		go reloader.Watch(context.Background(), time.Duration(conf.ReloadInterval)*time.Second, func(err error) {
+			log.Error("certificate reload failed", slog.Any("error", err))
+		})
+	}
+
+	if conf.RedirectPort != "" {
+		go func() {
+			log.Info("https redirect started", slog.String("addr", conf.RedirectPort))
+			if err := http.ListenAndServe(conf.RedirectPort, api.RedirectHTTPS(srv.Addr)); err != nil {
+				log.Error("https redirect stopped", slog.Any("error", err))
+			}
+		}()
+	}
+
+	log.Info("server started", slog.String("addr", srv.Addr), slog.Bool("tls", true))
+	return srv.ListenAndServeTLS("", "")
+}
+
+// newWebhookTransport returns the transport of the webhook deliveries. It
+// only connects to public addresses, so that a webhook whose host resolves to
+// an internal address cannot reach it, and does not go through a proxy, which
+// would connect in its place.
+func newWebhookTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   netguard.Control,
+	}).DialContext
+
+	return transport
 }
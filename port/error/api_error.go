@@ -59,3 +59,39 @@ func NewForbiddenError(message string, cause error) *ApiError {
 		Cause:   cause,
 	}
 }
+
+func NewTooManyRequestsError(message string, cause error) *ApiError {
+	if message == "" {
+		message = "Too many requests, please try again later."
+	}
+
+	return &ApiError{
+		Status:  http.StatusTooManyRequests,
+		Message: message,
+		Cause:   cause,
+	}
+}
+
+func NewRequestEntityTooLargeError(message string, cause error) *ApiError {
+	if message == "" {
+		message = "Request body is too large."
+	}
+
+	return &ApiError{
+		Status:  http.StatusRequestEntityTooLarge,
+		Message: message,
+		Cause:   cause,
+	}
+}
+
+func NewConflictError(message string, cause error) *ApiError {
+	if message == "" {
+		message = "The resource already exists."
+	}
+
+	return &ApiError{
+		Status:  http.StatusConflict,
+		Message: message,
+		Cause:   cause,
+	}
+}
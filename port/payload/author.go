@@ -2,15 +2,34 @@ package payload
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"bookstore.com/tools/datetime"
+	"bookstore.com/tools/isni"
 )
 
+// maxBiographyLength is the longest biography in characters.
+const maxBiographyLength = 20000
+
 type AuthorRequest struct {
-	FirstName   string `json:"firstName"`
-	LastName    string `json:"lastName"`
-	BirthDate   string `json:"birthDate"`
-	Nationality string `json:"nationality"`
+	FirstName    string                               `json:"firstName"`
+	LastName     string                               `json:"lastName"`
+	BirthDate    string                               `json:"birthDate"`
+	DeathDate    string                               `json:"deathDate"`
+	Nationality  string                               `json:"nationality"`
+	Biography    string                               `json:"biography"`
+	Aliases      []string                             `json:"aliases"`
+	ISNI         string                               `json:"isni"`
+	VIAF         string                               `json:"viaf"`
+	Translations map[string]*AuthorTranslationRequest `json:"translations"`
+}
+
+// AuthorTranslationRequest is the name of the author in the language keying
+// it.
+type AuthorTranslationRequest struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
 }
 
 func (r *AuthorRequest) Validate() error {
@@ -26,24 +45,104 @@ func (r *AuthorRequest) Validate() error {
 		return fmt.Errorf("birthDate: field required")
 	}
 
-	_, err := datetime.ParseDate(r.BirthDate)
+	birthDate, err := datetime.ParseDate(r.BirthDate)
 	if err != nil {
 		return fmt.Errorf("birthDate: %s", err)
 	}
 
+	if r.DeathDate != "" {
+		deathDate, err := datetime.ParseDate(r.DeathDate)
+		if err != nil {
+			return fmt.Errorf("deathDate: %s", err)
+		}
+		if !deathDate.After(birthDate) {
+			return fmt.Errorf("deathDate: must be after birthDate")
+		}
+	}
+
 	if r.Nationality == "" {
 		return fmt.Errorf("nationality: field required")
 	}
 
+	if utf8.RuneCountInString(r.Biography) > maxBiographyLength {
+		return fmt.Errorf("biography: longer than %d characters", maxBiographyLength)
+	}
+
+	if err := r.validateAliases(); err != nil {
+		return err
+	}
+
+	if r.ISNI != "" {
+		if r.ISNI, err = isni.Normalize(r.ISNI); err != nil {
+			return fmt.Errorf("isni: %s", err)
+		}
+	}
+
+	if r.VIAF != "" {
+		r.VIAF = strings.TrimSpace(r.VIAF)
+		if len(r.VIAF) > 22 || strings.Trim(r.VIAF, "0123456789") != "" {
+			return fmt.Errorf("viaf: invalid, want up to 22 digits")
+		}
+	}
+
+	r.Translations, err = normalizeTranslations(r.Translations, "", func(t *AuthorTranslationRequest) error {
+		if t.FirstName == "" {
+			return fmt.Errorf("firstName: field required")
+		}
+		if t.LastName == "" {
+			return fmt.Errorf("lastName: field required")
+		}
+		return nil
+	})
+
+	return err
+}
+
+// validateAliases trims the aliases. An alias is a single line, and the same
+// alias may not be given twice whatever its case.
+func (r *AuthorRequest) validateAliases() error {
+	aliases := []string{}
+	seen := map[string]bool{}
+	for i, alias := range r.Aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			return fmt.Errorf("aliases[%d]: field required", i)
+		}
+		if strings.ContainsAny(alias, "\r\n") {
+			return fmt.Errorf("aliases[%d]: must be a single line", i)
+		}
+		if seen[strings.ToLower(alias)] {
+			return fmt.Errorf("aliases[%d]: duplicate", i)
+		}
+		seen[strings.ToLower(alias)] = true
+		aliases = append(aliases, alias)
+	}
+	r.Aliases = aliases
+
 	return nil
 }
 
+// AuthorResponse has the names in ContentLanguage, the language negotiated
+// for the request, empty for the untranslated names.
 type AuthorResponse struct {
-	Id          string `json:"id"`
-	FirstName   string `json:"firstName"`
-	LastName    string `json:"lastName"`
-	BirthDate   string `json:"birthDate"`
-	Nationality string `json:"nationality"`
-	CreatedAt   string `json:"createdAt"`
-	UpdatedAt   string `json:"updatedAt"`
+	Id              string                                `json:"id"`
+	FirstName       string                                `json:"firstName"`
+	LastName        string                                `json:"lastName"`
+	BirthDate       string                                `json:"birthDate"`
+	DeathDate       string                                `json:"deathDate"`
+	Nationality     string                                `json:"nationality"`
+	Biography       string                                `json:"biography"`
+	Aliases         []string                              `json:"aliases"`
+	ISNI            string                                `json:"isni"`
+	VIAF            string                                `json:"viaf"`
+	PhotoUrl        string                                `json:"photoUrl"`
+	ContentLanguage string                                `json:"contentLanguage"`
+	Translations    map[string]*AuthorTranslationResponse `json:"translations"`
+	CreatedAt       string                                `json:"createdAt"`
+	UpdatedAt       string                                `json:"updatedAt"`
+}
+
+type AuthorTranslationResponse struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
 }
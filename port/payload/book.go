@@ -2,18 +2,73 @@ package payload
 
 import (
 	"fmt"
+	"strings"
 
+	"bookstore.com/domain/entity"
 	"bookstore.com/tools/datetime"
+	"bookstore.com/tools/isbn"
+	"bookstore.com/tools/locale"
 )
 
 type BookRequest struct {
-	AuthorId        string  `json:"authorId"`
-	Name            string  `json:"name"`
-	Description     string  `json:"description"`
-	PublicationDate string  `json:"publicationDate"`
+	AuthorId        string                             `json:"authorId"`
+	Contributors    []*ContributorRequest              `json:"contributors"`
+	CategoryIds     []string                           `json:"categoryIds"`
+	Tags            []string                           `json:"tags"`
+	PublisherId     string                             `json:"publisherId"`
+	Editions        []*EditionRequest                  `json:"editions"`
+	SeriesId        string                             `json:"seriesId"`
+	SeriesPosition  int                                `json:"seriesPosition"`
+	Name            string                             `json:"name"`
+	Description     string                             `json:"description"`
+	PublicationDate string                             `json:"publicationDate"`
+	Price           float64                            `json:"price"`
+	ISBN10          string                             `json:"isbn10"`
+	ISBN13          string                             `json:"isbn13"`
+	Language        string                             `json:"language"`
+	OriginalId      string                             `json:"originalId"`
+	Translations    map[string]*BookTranslationRequest `json:"translations"`
+}
+
+// BookTranslationRequest is the name and description of the book in the
+// language keying it.
+type BookTranslationRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// EditionRequest is an edition of the book. Id is set to keep an existing
+// edition and empty for a new one.
+type EditionRequest struct {
+	Id              string  `json:"id"`
+	Format          string  `json:"format"`
+	ISBN10          string  `json:"isbn10"`
+	ISBN13          string  `json:"isbn13"`
+	PageCount       int     `json:"pageCount"`
+	DurationMinutes int     `json:"durationMinutes"`
 	Price           float64 `json:"price"`
+	PublicationDate string  `json:"publicationDate"`
+}
+
+type ContributorRequest struct {
+	AuthorId string `json:"authorId"`
+	Role     string `json:"role"`
 }
 
+// Validate checks the request. A request with authorId only is a book with
+// that single author; otherwise authorId is the first contributor and is
+// filled in from it. A contributor role defaults to author.
+//
+// The ISBNs are optional; when one is given it is normalized to bare digits
+// and the other one is filled in from it. A 979 ISBN-13 has no ISBN-10. The
+// ISBNs of the editions are normalized the same way.
+//
+// A book of a series has a position in it, starting at 1.
+//
+// Tags are normalized, sorted and deduplicated.
+//
+// The language and the keys of the translations are normalized BCP 47 tags.
+// A translation of another book has a language.
 func (r *BookRequest) Validate() error {
 	if r.Name == "" {
 		return fmt.Errorf("name: field required")
@@ -40,20 +95,242 @@ func (r *BookRequest) Validate() error {
 		return fmt.Errorf("price: invalid")
 	}
 
+	if err := r.validateContributors(); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for i, id := range r.CategoryIds {
+		if id == "" {
+			return fmt.Errorf("categoryIds[%d]: field required", i)
+		}
+		if seen[id] {
+			return fmt.Errorf("categoryIds[%d]: duplicate", i)
+		}
+		seen[id] = true
+	}
+
+	if r.Tags, err = NormalizeTags(r.Tags); err != nil {
+		return err
+	}
+
+	if r.SeriesId == "" && r.SeriesPosition != 0 {
+		return fmt.Errorf("seriesId: field required")
+	}
+
+	if r.SeriesId != "" && r.SeriesPosition <= 0 {
+		return fmt.Errorf("seriesPosition: field required")
+	}
+
+	if err := normalizeISBN(&r.ISBN10, &r.ISBN13, ""); err != nil {
+		return err
+	}
+
+	if err := r.validateEditions(); err != nil {
+		return err
+	}
+
+	if r.Language != "" {
+		if r.Language, err = locale.Normalize(r.Language); err != nil {
+			return fmt.Errorf("language: %s", err)
+		}
+	}
+
+	if r.OriginalId != "" && r.Language == "" {
+		return fmt.Errorf("language: field required")
+	}
+
+	r.Translations, err = normalizeTranslations(r.Translations, r.Language, func(t *BookTranslationRequest) error {
+		if t.Name == "" {
+			return fmt.Errorf("name: field required")
+		}
+		if t.Description == "" {
+			return fmt.Errorf("description: field required")
+		}
+		return nil
+	})
+
+	return err
+}
+
+// validateEditions checks the editions. Audiobooks have a duration and the
+// other formats a page count. No two ISBNs of the book may be the same.
+func (r *BookRequest) validateEditions() error {
+	isbns := map[string]bool{}
+	if r.ISBN13 != "" {
+		isbns[r.ISBN13] = true
+	}
+
+	ids := map[string]bool{}
+	for i, e := range r.Editions {
+		field := fmt.Sprintf("editions[%d].", i)
+		if e == nil {
+			return fmt.Errorf("editions[%d]: field required", i)
+		}
+
+		if e.Id != "" {
+			if ids[e.Id] {
+				return fmt.Errorf("%sid: duplicate", field)
+			}
+			ids[e.Id] = true
+		}
+
+		if !entity.IsFormat(e.Format) {
+			return fmt.Errorf("%sformat: invalid, want one of %s", field, strings.Join(entity.Formats, ", "))
+		}
+
+		if e.Format == entity.FormatAudiobook {
+			if e.DurationMinutes <= 0 {
+				return fmt.Errorf("%sdurationMinutes: field required", field)
+			}
+			if e.PageCount != 0 {
+				return fmt.Errorf("%spageCount: not allowed for an audiobook", field)
+			}
+		} else {
+			if e.PageCount <= 0 {
+				return fmt.Errorf("%spageCount: field required", field)
+			}
+			if e.DurationMinutes != 0 {
+				return fmt.Errorf("%sdurationMinutes: only allowed for an audiobook", field)
+			}
+		}
+
+		if e.Price <= 0 {
+			return fmt.Errorf("%sprice: field required", field)
+		}
+
+		if e.PublicationDate == "" {
+			return fmt.Errorf("%spublicationDate: field required", field)
+		}
+		if _, err := datetime.ParseDate(e.PublicationDate); err != nil {
+			return fmt.Errorf("%spublicationDate: %s", field, err)
+		}
+
+		if err := normalizeISBN(&e.ISBN10, &e.ISBN13, field); err != nil {
+			return err
+		}
+		if e.ISBN13 != "" {
+			if isbns[e.ISBN13] {
+				return fmt.Errorf("%sisbn13: duplicate", field)
+			}
+			isbns[e.ISBN13] = true
+		}
+	}
+
+	return nil
+}
+
+func (r *BookRequest) validateContributors() error {
+	if len(r.Contributors) == 0 {
+		if r.AuthorId == "" {
+			return fmt.Errorf("authorId: field required")
+		}
+		r.Contributors = []*ContributorRequest{{AuthorId: r.AuthorId, Role: entity.ContributorAuthor}}
+		return nil
+	}
+
+	seen := map[ContributorRequest]bool{}
+	for i, c := range r.Contributors {
+		if c == nil || c.AuthorId == "" {
+			return fmt.Errorf("contributors[%d].authorId: field required", i)
+		}
+		if c.Role == "" {
+			c.Role = entity.ContributorAuthor
+		}
+		if !entity.IsContributorRole(c.Role) {
+			return fmt.Errorf("contributors[%d].role: invalid, want one of %s", i, strings.Join(entity.ContributorRoles, ", "))
+		}
+		if seen[*c] {
+			return fmt.Errorf("contributors[%d]: duplicate", i)
+		}
+		seen[*c] = true
+	}
+
 	if r.AuthorId == "" {
-		return fmt.Errorf("authorId: field required")
+		r.AuthorId = r.Contributors[0].AuthorId
+	} else if r.AuthorId != r.Contributors[0].AuthorId {
+		return fmt.Errorf("authorId: must be the first contributor")
 	}
 
 	return nil
 }
 
+// normalizeISBN normalizes the optional ISBNs and fills in the missing one.
+// field prefixes the error messages.
+func normalizeISBN(isbn10, isbn13 *string, field string) error {
+	var err error
+	if *isbn10 != "" {
+		if *isbn10, err = isbn.Normalize10(*isbn10); err != nil {
+			return fmt.Errorf("%sisbn10: %s", field, err)
+		}
+	}
+
+	if *isbn13 != "" {
+		if *isbn13, err = isbn.Normalize13(*isbn13); err != nil {
+			return fmt.Errorf("%sisbn13: %s", field, err)
+		}
+	}
+
+	switch {
+	case *isbn10 != "" && *isbn13 == "":
+		*isbn13, _ = isbn.To13(*isbn10)
+	case *isbn10 == "" && *isbn13 != "":
+		*isbn10, _ = isbn.To10(*isbn13)
+	case *isbn10 != "":
+		if converted, _ := isbn.To13(*isbn10); converted != *isbn13 {
+			return fmt.Errorf("%sisbn10: does not match isbn13", field)
+		}
+	}
+
+	return nil
+}
+
+// BookResponse has the name and description in ContentLanguage, the language
+// negotiated for the request, and all the translations of the book.
 type BookResponse struct {
-	Id              string          `json:"id"`
-	Author          *AuthorResponse `json:"author"`
-	Name            string          `json:"name"`
-	Description     string          `json:"description"`
-	PublicationDate string          `json:"publicationDate"`
-	Price           float64         `json:"price"`
-	CreatedAt       string          `json:"createdAt"`
-	UpdatedAt       string          `json:"updatedAt"`
+	Id              string                              `json:"id"`
+	Author          *AuthorResponse                     `json:"author"`
+	Contributors    []*ContributorResponse              `json:"contributors"`
+	CategoryIds     []string                            `json:"categoryIds"`
+	Tags            []string                            `json:"tags"`
+	PublisherId     string                              `json:"publisherId"`
+	Publisher       *PublisherResponse                  `json:"publisher"`
+	Editions        []*EditionResponse                  `json:"editions"`
+	SeriesId        string                              `json:"seriesId"`
+	SeriesPosition  int                                 `json:"seriesPosition"`
+	Name            string                              `json:"name"`
+	Description     string                              `json:"description"`
+	PublicationDate string                              `json:"publicationDate"`
+	Price           float64                             `json:"price"`
+	ISBN10          string                              `json:"isbn10"`
+	ISBN13          string                              `json:"isbn13"`
+	CoverUrl        string                              `json:"coverUrl"`
+	Language        string                              `json:"language"`
+	OriginalId      string                              `json:"originalId"`
+	ContentLanguage string                              `json:"contentLanguage"`
+	Translations    map[string]*BookTranslationResponse `json:"translations"`
+	CreatedAt       string                              `json:"createdAt"`
+	UpdatedAt       string                              `json:"updatedAt"`
+}
+
+type BookTranslationResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ContributorResponse struct {
+	AuthorId string          `json:"authorId"`
+	Role     string          `json:"role"`
+	Author   *AuthorResponse `json:"author"`
+}
+
+type EditionResponse struct {
+	Id              string  `json:"id"`
+	Format          string  `json:"format"`
+	ISBN10          string  `json:"isbn10"`
+	ISBN13          string  `json:"isbn13"`
+	PageCount       int     `json:"pageCount"`
+	DurationMinutes int     `json:"durationMinutes"`
+	Price           float64 `json:"price"`
+	PublicationDate string  `json:"publicationDate"`
 }
@@ -0,0 +1,24 @@
+package payload
+
+import "fmt"
+
+type CategoryRequest struct {
+	Name     string `json:"name"`
+	ParentId string `json:"parentId"`
+}
+
+func (r *CategoryRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name: field required")
+	}
+
+	return nil
+}
+
+type CategoryResponse struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	ParentId  string `json:"parentId"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
@@ -0,0 +1,28 @@
+package payload
+
+import "time"
+
+// ImageRequest is an uploaded image. ContentType is the type declared by the
+// client, the service checks it against the data.
+type ImageRequest struct {
+	ContentType string
+	Data        []byte
+}
+
+// ImageFile is a stored image. Version changes with every upload.
+type ImageFile struct {
+	Version     string
+	ContentType string
+	Data        []byte
+	UpdatedAt   time.Time
+}
+
+type CoverResponse struct {
+	CoverUrl string            `json:"coverUrl"`
+	Sizes    map[string]string `json:"sizes"`
+}
+
+type PhotoResponse struct {
+	PhotoUrl string            `json:"photoUrl"`
+	Sizes    map[string]string `json:"sizes"`
+}
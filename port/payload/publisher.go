@@ -0,0 +1,40 @@
+package payload
+
+import (
+	"fmt"
+	"net/url"
+)
+
+type PublisherRequest struct {
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	Website string `json:"website"`
+}
+
+func (r *PublisherRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name: field required")
+	}
+
+	if r.Country == "" {
+		return fmt.Errorf("country: field required")
+	}
+
+	if r.Website != "" {
+		u, err := url.Parse(r.Website)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("website: invalid URL")
+		}
+	}
+
+	return nil
+}
+
+type PublisherResponse struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Country   string `json:"country"`
+	Website   string `json:"website"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
@@ -0,0 +1,26 @@
+package payload
+
+import (
+	"fmt"
+)
+
+type SeriesRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (r *SeriesRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name: field required")
+	}
+
+	return nil
+}
+
+type SeriesResponse struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
@@ -0,0 +1,74 @@
+package payload
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const maxTagLength = 50
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// TagsRequest is the tags to add to a book.
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// Validate checks that the request has tags and normalizes them.
+func (r *TagsRequest) Validate() error {
+	if len(r.Tags) == 0 {
+		return fmt.Errorf("tags: field required")
+	}
+
+	var err error
+	r.Tags, err = NormalizeTags(r.Tags)
+	return err
+}
+
+type TagCountResponse struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// normalizeTag trims and lower cases a tag, which is then made of letters
+// and digits in words separated by single hyphens, like "summer-2026".
+func normalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("field required")
+	}
+	if len(tag) > maxTagLength {
+		return "", fmt.Errorf("must be at most %d characters", maxTagLength)
+	}
+	if !tagPattern.MatchString(tag) {
+		return "", fmt.Errorf("invalid, want lower case letters and digits separated by hyphens")
+	}
+
+	return tag, nil
+}
+
+// NormalizeTags normalizes the tags and returns them sorted without
+// duplicates, nil for no tags.
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	normalized := []string{}
+	for i, tag := range tags {
+		tag, err := normalizeTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("tags[%d]: %s", i, err)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	sort.Strings(normalized)
+
+	return normalized, nil
+}
@@ -0,0 +1,49 @@
+package payload
+
+import (
+	"fmt"
+	"sort"
+
+	"bookstore.com/tools/locale"
+)
+
+// normalizeTranslations normalizes the language tags keying translations and
+// checks each translation with check. language is the tag of the
+// untranslated fields, which has no translation. No translations are nil.
+func normalizeTranslations[T any](translations map[string]*T, language string, check func(*T) error) (map[string]*T, error) {
+	if len(translations) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(translations))
+	for key := range translations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	normalized := map[string]*T{}
+	for _, key := range keys {
+		field := "translations." + key
+		tag, err := locale.Normalize(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", field, err)
+		}
+		if tag == language {
+			return nil, fmt.Errorf("%s: same as language", field)
+		}
+		if _, ok := normalized[tag]; ok {
+			return nil, fmt.Errorf("%s: duplicate", field)
+		}
+
+		t := translations[key]
+		if t == nil {
+			return nil, fmt.Errorf("%s: field required", field)
+		}
+		if err := check(t); err != nil {
+			return nil, fmt.Errorf("%s.%s", field, err)
+		}
+		normalized[tag] = t
+	}
+
+	return normalized, nil
+}
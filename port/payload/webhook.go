@@ -0,0 +1,78 @@
+package payload
+
+import (
+	"fmt"
+	"net/url"
+
+	"bookstore.com/domain/event"
+	"bookstore.com/tools/netguard"
+)
+
+const minSecretLength = 16
+
+type WebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Secret     string   `json:"secret"`
+}
+
+// Validate checks the request. The URL is an http or https URL whose host is
+// not on the loopback, link-local or private networks of the server.
+func (r *WebhookRequest) Validate() error {
+	if r.URL == "" {
+		return fmt.Errorf("url: field required")
+	}
+
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url: invalid")
+	}
+
+	if err := netguard.CheckHost(u.Hostname()); err != nil {
+		return fmt.Errorf("url: host not allowed, want a public address")
+	}
+
+	if len(r.EventTypes) == 0 {
+		return fmt.Errorf("eventTypes: field required")
+	}
+
+	for _, t := range r.EventTypes {
+		if !event.Type(t).Valid() {
+			return fmt.Errorf("eventTypes: unknown event type %s", t)
+		}
+	}
+
+	if len(r.Secret) < minSecretLength {
+		return fmt.Errorf("secret: must be at least %d characters", minSecretLength)
+	}
+
+	return nil
+}
+
+type WebhookResponse struct {
+	Id         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	CreatedAt  string   `json:"createdAt"`
+	UpdatedAt  string   `json:"updatedAt"`
+}
+
+type DeliveryAttemptResponse struct {
+	At         string `json:"at"`
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	Duration   int64  `json:"duration"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id            string                     `json:"id"`
+	WebhookId     string                     `json:"webhookId"`
+	EventId       string                     `json:"eventId"`
+	EventType     string                     `json:"eventType"`
+	RedeliveryOf  string                     `json:"redeliveryOf"`
+	Status        string                     `json:"status"`
+	Attempts      []*DeliveryAttemptResponse `json:"attempts"`
+	NextAttemptAt string                     `json:"nextAttemptAt"`
+	CreatedAt     string                     `json:"createdAt"`
+	UpdatedAt     string                     `json:"updatedAt"`
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+
+	"bookstore.com/config"
+	"bookstore.com/repository"
+	memoryrepo "bookstore.com/repository/memory"
+	mongorepo "bookstore.com/repository/mongo"
+	sqlrepo "bookstore.com/repository/sql"
+	"bookstore.com/tools/migrate"
+)
+
+// repositories are the storage of the service, all backed by the database
+// driver selected in the config.
+type repositories struct {
+	author     repository.AuthorRepository
+	book       repository.BookRepository
+	category   repository.CategoryRepository
+	publisher  repository.PublisherRepository
+	series     repository.SeriesRepository
+	user       repository.UserRepository
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+	webhook    repository.WebhookRepository
+	delivery   repository.WebhookDeliveryRepository
+	migrator   *migrate.Migrator
+}
+
+func newRepositories(conf config.Database) (*repositories, error) {
+	switch conf.Driver {
+	case "", "mongo":
+		return newMongoRepositories(conf)
+	case "postgres", "sqlite":
+		return newSQLRepositories(conf)
+	case "memory":
+		return newMemoryRepositories()
+	default:
+		return nil, fmt.Errorf("invalid database driver %q", conf.Driver)
+	}
+}
+
+func newMongoRepositories(conf config.Database) (*repositories, error) {
+	var err error
+	repos := &repositories{}
+
+	if repos.author, err = mongorepo.NewAuthorRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.book, err = mongorepo.NewBookRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.category, err = mongorepo.NewCategoryRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.publisher, err = mongorepo.NewPublisherRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.series, err = mongorepo.NewSeriesRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.user, err = mongorepo.NewUserRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.outbox, err = mongorepo.NewOutboxRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.transactor, err = mongorepo.NewTransactor(conf.URL, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.webhook, err = mongorepo.NewWebhookRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.delivery, err = mongorepo.NewWebhookDeliveryRepository(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+	if repos.migrator, err = mongorepo.NewMigrator(conf.URL, conf.Name, conf.Timeout); err != nil {
+		return nil, err
+	}
+
+	return repos, nil
+}
+
+// newSQLRepositories connects to the database at conf.URL, a DSN of the
+// conf.Driver SQL driver.
+func newSQLRepositories(conf config.Database) (*repositories, error) {
+	db, err := sqlrepo.Open(conf.Driver, conf.URL, conf.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := sqlrepo.NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories{
+		author:     sqlrepo.NewAuthorRepository(db),
+		book:       sqlrepo.NewBookRepository(db),
+		category:   sqlrepo.NewCategoryRepository(db),
+		publisher:  sqlrepo.NewPublisherRepository(db),
+		series:     sqlrepo.NewSeriesRepository(db),
+		user:       sqlrepo.NewUserRepository(db),
+		outbox:     sqlrepo.NewOutboxRepository(db),
+		transactor: sqlrepo.NewTransactor(db),
+		webhook:    sqlrepo.NewWebhookRepository(db),
+		delivery:   sqlrepo.NewWebhookDeliveryRepository(db),
+		migrator:   migrator,
+	}, nil
+}
+
+// newMemoryRepositories keeps everything in memory: the data is lost on exit
+// and there is no schema to migrate.
+func newMemoryRepositories() (*repositories, error) {
+	db := memoryrepo.NewDB()
+	migrator, err := migrate.New(migrate.NewMemoryStore())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories{
+		author:     memoryrepo.NewAuthorRepository(db),
+		book:       memoryrepo.NewBookRepository(db),
+		category:   memoryrepo.NewCategoryRepository(db),
+		publisher:  memoryrepo.NewPublisherRepository(db),
+		series:     memoryrepo.NewSeriesRepository(db),
+		user:       memoryrepo.NewUserRepository(db),
+		outbox:     memoryrepo.NewOutboxRepository(db),
+		transactor: memoryrepo.NewTransactor(db),
+		webhook:    memoryrepo.NewWebhookRepository(db),
+		delivery:   memoryrepo.NewWebhookDeliveryRepository(db),
+		migrator:   migrator,
+	}, nil
+}
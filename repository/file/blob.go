@@ -0,0 +1,147 @@
+package filerepo
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+// blobStore keeps every blob as a file under dir, named by its key, next to a
+// JSON file with its content type.
+type blobStore struct {
+	dir string
+}
+
+type blobMeta struct {
+	ContentType string `json:"contentType"`
+}
+
+// NewBlobStore stores the blobs under dir, creating it if needed.
+func NewBlobStore(dir string) (repository.BlobStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create blob directory")
+	}
+
+	return &blobStore{dir: dir}, nil
+}
+
+func (s *blobStore) Put(ctx context.Context, blob *entity.Blob) error {
+	path, err := s.path(blob.Key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "blobStore.Put")
+	}
+
+	meta, err := json.Marshal(blobMeta{ContentType: blob.ContentType})
+	if err != nil {
+		return errors.Wrap(err, "blobStore.Put")
+	}
+
+	// The data is written last so a blob is only found once it is complete.
+	if err := writeFile(path+".json", meta); err != nil {
+		return errors.Wrap(err, "blobStore.Put")
+	}
+	if err := writeFile(path, blob.Data); err != nil {
+		return errors.Wrap(err, "blobStore.Put")
+	}
+
+	return nil
+}
+
+func (s *blobStore) Get(ctx context.Context, key string) (*entity.Blob, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, portError.NewNotFoundError("Blob not found.", err)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "blobStore.Get")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "blobStore.Get")
+	}
+
+	meta := blobMeta{}
+	raw, err := os.ReadFile(path + ".json")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "blobStore.Get")
+	}
+	if err == nil {
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, errors.Wrap(err, "blobStore.Get")
+		}
+	}
+
+	return &entity.Blob{
+		Key:         key,
+		ContentType: meta.ContentType,
+		Data:        data,
+		UpdatedAt:   info.ModTime(),
+	}, nil
+}
+
+func (s *blobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range []string{path, path + ".json"} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "blobStore.Delete")
+		}
+	}
+
+	return nil
+}
+
+// path maps key to a file under the store directory. Keys are slash separated
+// and may not leave the directory.
+func (s *blobStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, ".json") {
+		return "", portError.NewBadRequestError("Invalid blob key.", nil)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", portError.NewBadRequestError("Invalid blob key.", nil)
+		}
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// writeFile replaces path atomically through a temporary file in the same
+// directory.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
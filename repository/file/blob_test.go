@@ -0,0 +1,70 @@
+package filerepo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+)
+
+func TestBlobStore(t *testing.T) {
+	ctx := context.TODO()
+	store, err := NewBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewBlobStore() error = %v", err)
+	}
+
+	key := "covers/book/small"
+	if _, err := store.Get(ctx, key); blobStatus(err) != http.StatusNotFound {
+		t.Errorf("blobStore.Get() missing blob error = %v, want not found", err)
+	}
+
+	for _, data := range [][]byte{[]byte("first"), []byte("second")} {
+		if err := store.Put(ctx, &entity.Blob{Key: key, ContentType: "image/png", Data: data}); err != nil {
+			t.Fatalf("blobStore.Put() error = %v", err)
+		}
+		got, err := store.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("blobStore.Get() error = %v", err)
+		}
+		if !bytes.Equal(got.Data, data) || got.ContentType != "image/png" || got.Key != key || got.UpdatedAt.IsZero() {
+			t.Errorf("blobStore.Get() = %q %q %q %v", got.Key, got.ContentType, got.Data, got.UpdatedAt)
+		}
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("blobStore.Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, key); blobStatus(err) != http.StatusNotFound {
+		t.Errorf("blobStore.Get() after Delete() error = %v, want not found", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("blobStore.Delete() missing blob error = %v", err)
+	}
+}
+
+func TestBlobStore_invalidKey(t *testing.T) {
+	store, err := NewBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewBlobStore() error = %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "covers/../../outside", "covers//small", "covers/small.json"} {
+		err := store.Put(context.TODO(), &entity.Blob{Key: key, Data: []byte("data")})
+		if blobStatus(err) != http.StatusBadRequest {
+			t.Errorf("blobStore.Put(%q) error = %v, want bad request", key, err)
+		}
+	}
+}
+
+func blobStatus(err error) int {
+	var apiErr *portError.ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+package filerepo
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"bookstore.com/domain/event"
+	"github.com/pkg/errors"
+)
+
+// Publisher appends every event as a JSON line to a file.
+type Publisher struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewPublisher opens path for appending, creating it if needed.
+func NewPublisher(path string) (*Publisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open event file")
+	}
+
+	return &Publisher{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+func (p *Publisher) Publish(ctx context.Context, e *event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.enc.Encode(e); err != nil {
+		return errors.Wrap(err, "filePublisher.Publish")
+	}
+
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	return p.file.Close()
+}
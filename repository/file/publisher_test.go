@@ -0,0 +1,48 @@
+package filerepo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bookstore.com/domain/event"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	p, err := NewPublisher(path)
+	if err != nil {
+		t.Fatalf("NewPublisher() error = %v", err)
+	}
+	defer p.Close()
+
+	for _, typ := range []event.Type{event.BookCreated, event.BookDeleted} {
+		e, _ := event.New(context.TODO(), typ, "id", map[string]string{"name": "book name 1"})
+		if err := p.Publish(context.TODO(), e); err != nil {
+			t.Fatalf("Publisher.Publish() error = %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var types []event.Type
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		e := &event.Event{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			t.Fatalf("invalid json line %s", scanner.Text())
+		}
+		types = append(types, e.Type)
+	}
+
+	if len(types) != 2 || types[0] != event.BookCreated || types[1] != event.BookDeleted {
+		t.Errorf("Publisher.Publish() wrote %v", types)
+	}
+}
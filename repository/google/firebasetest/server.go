@@ -0,0 +1,153 @@
+// Package firebasetest provides an in-memory server speaking the subset of the
+// Firebase Realtime Database REST protocol used by the bookstore, so that the
+// Firebase publishers can be exercised without network access.
+package firebasetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Server is a running emulator. The data is a JSON tree held in memory.
+type Server struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	root map[string]any
+	seq  int
+}
+
+// NewServer starts an emulator. Callers should Close it when done.
+func NewServer() *Server {
+	s := &Server{root: map[string]any{}}
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// Get returns the value stored at path decoded as JSON, or nil.
+func (s *Server) Get(path string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lookup(segments(path))
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, ".json") {
+		writeError(w, http.StatusNotFound, "Path must end with .json")
+		return
+	}
+	keys := segments(strings.TrimSuffix(r.URL.Path, ".json"))
+
+	var value any
+	if r.Method == http.MethodPut || r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		if err := json.NewDecoder(r.Body).Decode(&value); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid data; couldn't parse JSON object.")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, s.lookup(keys))
+	case http.MethodPut:
+		s.set(keys, value)
+		writeJSON(w, value)
+	case http.MethodPost:
+		s.seq++
+		name := fmt.Sprintf("-N%019d", s.seq)
+		s.set(append(keys, name), value)
+		writeJSON(w, map[string]string{"name": name})
+	case http.MethodPatch:
+		children, ok := value.(map[string]any)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid data; expected an object.")
+			return
+		}
+		for key, child := range children {
+			s.set(append(keys, segments(key)...), child)
+		}
+		writeJSON(w, value)
+	case http.MethodDelete:
+		s.set(keys, nil)
+		writeJSON(w, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+	}
+}
+
+func (s *Server) lookup(keys []string) any {
+	var node any = s.root
+	for _, key := range keys {
+		children, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = children[key]
+	}
+
+	return node
+}
+
+// set stores value at keys, creating the parents as needed. A nil value
+// deletes the node.
+func (s *Server) set(keys []string, value any) {
+	if len(keys) == 0 {
+		root, _ := value.(map[string]any)
+		if root == nil {
+			root = map[string]any{}
+		}
+		s.root = root
+		return
+	}
+
+	node := s.root
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			if value == nil {
+				return
+			}
+			child = map[string]any{}
+			node[key] = child
+		}
+		node = child
+	}
+
+	last := keys[len(keys)-1]
+	if value == nil {
+		delete(node, last)
+		return
+	}
+	node[last] = value
+}
+
+func segments(path string) []string {
+	keys := []string{}
+	for _, key := range strings.Split(path, "/") {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
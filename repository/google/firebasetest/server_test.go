@@ -0,0 +1,52 @@
+package firebasetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func do(t *testing.T, srv *Server, method, path, body string) (int, any) {
+	t.Helper()
+
+	req, _ := http.NewRequest(method, srv.URL+path, bytes.NewBufferString(body))
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var v any
+	json.NewDecoder(res.Body).Decode(&v)
+	return res.StatusCode, v
+}
+
+func TestServer(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	do(t, srv, http.MethodPut, "/books/1.json", `{"name":"book name 1","author":{"id":"a1"}}`)
+	do(t, srv, http.MethodPatch, "/books/1.json", `{"price":10,"author/name":"first"}`)
+	_, pushed := do(t, srv, http.MethodPost, "/books.json", `{"name":"book name 2"}`)
+	do(t, srv, http.MethodDelete, "/books/1/price.json", "")
+
+	_, got := do(t, srv, http.MethodGet, "/books/1.json", "")
+	want := map[string]any{"name": "book name 1", "author": map[string]any{"id": "a1", "name": "first"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GET /books/1 = %v, want %v", got, want)
+	}
+
+	name := pushed.(map[string]any)["name"].(string)
+	if got := srv.Get("books/" + name + "/name"); got != "book name 2" {
+		t.Errorf("pushed child = %v", got)
+	}
+
+	if status, _ := do(t, srv, http.MethodPut, "/books/3.json", `{`); status != http.StatusBadRequest {
+		t.Errorf("invalid JSON status = %d", status)
+	}
+	if status, _ := do(t, srv, http.MethodGet, "/books", ""); status != http.StatusNotFound {
+		t.Errorf("path without .json status = %d", status)
+	}
+}
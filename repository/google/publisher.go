@@ -0,0 +1,50 @@
+package google
+
+import (
+	"context"
+	"fmt"
+
+	"bookstore.com/domain/event"
+	firebase "firebase.google.com/go"
+	"firebase.google.com/go/db"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+)
+
+const DefaultPath = "books"
+
+// FireDB publishes events to a Firebase Realtime Database, one child per
+// event under path.
+type FireDB struct {
+	client *db.Client
+	path   string
+}
+
+// NewFireDB connects to the Realtime Database at databaseURL with the service
+// account found in credentialFile.
+func NewFireDB(ctx context.Context, credentialFile, databaseURL, path string) (*FireDB, error) {
+	opt := option.WithCredentialsFile(credentialFile)
+	config := &firebase.Config{DatabaseURL: databaseURL}
+	app, err := firebase.NewApp(ctx, config, opt)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing app: %v", err)
+	}
+	client, err := app.Database(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing database: %v", err)
+	}
+
+	if path == "" {
+		path = DefaultPath
+	}
+
+	return &FireDB{client: client, path: path}, nil
+}
+
+func (db *FireDB) Publish(ctx context.Context, e *event.Event) error {
+	if err := db.client.NewRef(db.path+"/"+e.Id).Set(ctx, e); err != nil {
+		return errors.Wrap(err, "FireDB.Publish")
+	}
+
+	return nil
+}
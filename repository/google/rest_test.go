@@ -0,0 +1,57 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"bookstore.com/domain/event"
+	"bookstore.com/repository/google/firebasetest"
+)
+
+func TestRestDB_Publish(t *testing.T) {
+	srv := firebasetest.NewServer()
+	defer srv.Close()
+
+	db, err := NewRestDB(context.TODO(), "", srv.URL+"/?ns=bookstore", "")
+	if err != nil {
+		t.Fatalf("NewRestDB() error = %v", err)
+	}
+
+	e, _ := event.New(context.TODO(), event.BookCreated, "book-1", map[string]string{"name": "book name 1"})
+	if err := db.Publish(context.TODO(), e); err != nil {
+		t.Fatalf("RestDB.Publish() error = %v", err)
+	}
+
+	raw, _ := json.Marshal(srv.Get(DefaultPath + "/" + e.Id))
+	got := &event.Event{}
+	if err := json.Unmarshal(raw, got); err != nil {
+		t.Fatal(err)
+	}
+	got.Timestamp = e.Timestamp
+	if !reflect.DeepEqual(got, e) {
+		t.Errorf("stored event = %s, want %+v", raw, e)
+	}
+}
+
+func TestRestDB_Publish_error(t *testing.T) {
+	srv := firebasetest.NewServer()
+	srv.Close()
+
+	db, err := NewRestDB(context.TODO(), "", srv.URL, "events")
+	if err != nil {
+		t.Fatalf("NewRestDB() error = %v", err)
+	}
+
+	e, _ := event.New(context.TODO(), event.BookDeleted, "book-1", nil)
+	if err := db.Publish(context.TODO(), e); err == nil {
+		t.Errorf("RestDB.Publish() error = nil on closed server")
+	}
+}
+
+func TestNewRestDB_invalidURL(t *testing.T) {
+	if _, err := NewRestDB(context.TODO(), "", "book-store.firebaseio.com", ""); err == nil {
+		t.Errorf("NewRestDB() error = nil for URL without scheme")
+	}
+}
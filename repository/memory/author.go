@@ -0,0 +1,167 @@
+package memoryrepo
+
+import (
+	"context"
+	"strings"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+)
+
+type authorRepository struct {
+	db *DB
+}
+
+func NewAuthorRepository(db *DB) repository.AuthorRepository {
+	return &authorRepository{db: db}
+}
+
+func (r *authorRepository) Store(ctx context.Context, author *entity.Author) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	author.Id = newObjectId()
+	author.CreatedAt = now
+	author.UpdatedAt = now
+
+	doc := *author
+	doc.Aliases = append([]string{}, author.Aliases...)
+	doc.Translations = copyTranslations(author.Translations)
+	r.db.authors.insert(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *authorRepository) Update(ctx context.Context, author *entity.Author) error {
+	if err := validObjectId(author.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.authors.get(author.Id)
+	if !ok {
+		return nil
+	}
+
+	doc := *old
+	doc.FirstName = author.FirstName
+	doc.LastName = author.LastName
+	doc.BirthDate = author.BirthDate
+	doc.DeathDate = author.DeathDate
+	doc.Nationality = author.Nationality
+	doc.Biography = author.Biography
+	doc.Aliases = append([]string{}, author.Aliases...)
+	doc.Translations = copyTranslations(author.Translations)
+	doc.ISNI = author.ISNI
+	doc.VIAF = author.VIAF
+	doc.UpdatedAt = now()
+	r.db.authors.replace(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *authorRepository) Find(ctx context.Context, id string) (*entity.Author, error) {
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	doc, ok := r.db.authors.get(id)
+	if !ok {
+		return nil, portError.NewNotFoundError("Author not found.", nil)
+	}
+
+	author := *doc
+	return &author, nil
+}
+
+func (r *authorRepository) FindAll(ctx context.Context) ([]*entity.Author, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	authors := []*entity.Author{}
+	for _, doc := range r.db.authors.all() {
+		author := *doc
+		authors = append(authors, &author)
+	}
+
+	return authors, nil
+}
+
+func (r *authorRepository) Search(ctx context.Context, query string) ([]*entity.Author, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	query = strings.ToLower(query)
+	authors := []*entity.Author{}
+	for _, doc := range r.db.authors.all() {
+		if matchAuthor(doc, query) {
+			author := *doc
+			authors = append(authors, &author)
+		}
+	}
+
+	return authors, nil
+}
+
+// matchAuthor tells whether a name of author contains the lower case query.
+func matchAuthor(author *entity.Author, query string) bool {
+	names := append([]string{author.FirstName, author.LastName, author.FirstName + " " + author.LastName}, author.Aliases...)
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(name), query) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *authorRepository) SetPhoto(ctx context.Context, id, photoId string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.authors.get(id)
+	if !ok {
+		return nil
+	}
+
+	doc := *old
+	doc.PhotoId = photoId
+	doc.UpdatedAt = now()
+	r.db.authors.replace(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *authorRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse author ID to ObjectID", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, book := range r.db.books.all() {
+		if book.AuthorId == id {
+			return errAuthorHasBooks(nil)
+		}
+	}
+
+	r.db.authors.delete(id)
+
+	return nil
+}
+
+func errAuthorHasBooks(err error) error {
+	return portError.NewConflictError("Author has books.", err)
+}
@@ -0,0 +1,591 @@
+package memoryrepo
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+)
+
+type bookRepository struct {
+	db *DB
+}
+
+// NewBookRepository creates a BookRepository returning the books joined with
+// their author and publisher, found in db. Like the Mongo $lookup and
+// $unwind, a book whose author does not exist is not returned.
+func NewBookRepository(db *DB) repository.BookRepository {
+	return &bookRepository{db: db}
+}
+
+func (r *bookRepository) Store(ctx context.Context, book *entity.Book) (*entity.Book, error) {
+	contributors, err := copyContributors(book)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIds, err := copyCategoryIds(book)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validPublisherId(book.PublisherId); err != nil {
+		return nil, err
+	}
+
+	if err := validSeriesId(book.SeriesId); err != nil {
+		return nil, err
+	}
+
+	if err := validOriginalId(book.OriginalId); err != nil {
+		return nil, err
+	}
+
+	editions, err := copyEditions(book)
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if err := r.checkISBN(book, editions); err != nil {
+		return nil, err
+	}
+
+	if err := r.checkSeriesPosition(book); err != nil {
+		return nil, err
+	}
+
+	now := now()
+	stored := *book
+	stored.Id = newObjectId()
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+
+	stored.Contributors = contributors
+	stored.CategoryIds = categoryIds
+	stored.Tags = append([]string{}, book.Tags...)
+	stored.Editions = editions
+	stored.Translations = copyTranslations(book.Translations)
+
+	doc := stored
+	doc.Author = nil
+	doc.Publisher = nil
+	r.db.books.insert(doc.Id, &doc)
+
+	return &stored, nil
+}
+
+func (r *bookRepository) Update(ctx context.Context, book *entity.Book) error {
+	if err := validObjectId(book.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	contributors, err := copyContributors(book)
+	if err != nil {
+		return err
+	}
+
+	categoryIds, err := copyCategoryIds(book)
+	if err != nil {
+		return err
+	}
+
+	if err := validPublisherId(book.PublisherId); err != nil {
+		return err
+	}
+
+	if err := validSeriesId(book.SeriesId); err != nil {
+		return err
+	}
+
+	if err := validOriginalId(book.OriginalId); err != nil {
+		return err
+	}
+
+	editions, err := copyEditions(book)
+	if err != nil {
+		return err
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.books.get(book.Id)
+	if !ok {
+		return nil
+	}
+
+	if err := r.checkISBN(book, editions); err != nil {
+		return err
+	}
+
+	if err := r.checkSeriesPosition(book); err != nil {
+		return err
+	}
+
+	doc := *old
+	doc.AuthorId = book.AuthorId
+	doc.Contributors = contributors
+	doc.CategoryIds = categoryIds
+	doc.Tags = append([]string{}, book.Tags...)
+	doc.PublisherId = book.PublisherId
+	doc.Editions = editions
+	doc.SeriesId = book.SeriesId
+	doc.SeriesPosition = book.SeriesPosition
+	doc.Name = book.Name
+	doc.Description = book.Description
+	doc.PublicationDate = book.PublicationDate
+	doc.Price = book.Price
+	doc.ISBN10 = book.ISBN10
+	doc.ISBN13 = book.ISBN13
+	doc.Language = book.Language
+	doc.OriginalId = book.OriginalId
+	doc.Translations = copyTranslations(book.Translations)
+	doc.UpdatedAt = now()
+	r.db.books.replace(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *bookRepository) Find(ctx context.Context, id string) (*entity.Book, error) {
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	doc, ok := r.db.books.get(id)
+	if !ok {
+		return nil, portError.NewNotFoundError("Book not found.", nil)
+	}
+
+	book, ok := r.join(doc)
+	if !ok {
+		return nil, portError.NewNotFoundError("Book not found.", nil)
+	}
+
+	return book, nil
+}
+
+func (r *bookRepository) FindByISBN(ctx context.Context, isbn13 string) (*entity.Book, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, doc := range r.db.books.all() {
+		if !hasISBN(doc, isbn13) {
+			continue
+		}
+		if book, ok := r.join(doc); ok {
+			return book, nil
+		}
+	}
+
+	return nil, portError.NewNotFoundError("Book not found.", nil)
+}
+
+func (r *bookRepository) FindAll(ctx context.Context) ([]*entity.Book, error) {
+	return r.find(func(*entity.Book) bool { return true }), nil
+}
+
+func (r *bookRepository) FindPage(ctx context.Context, after string, limit int) ([]*entity.Book, error) {
+	if after != "" {
+		if err := validObjectId(after); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+		}
+	}
+
+	books := r.find(func(doc *entity.Book) bool { return doc.Id > after })
+	if len(books) > limit {
+		books = books[:limit]
+	}
+
+	return books, nil
+}
+
+func (r *bookRepository) FindByCategories(ctx context.Context, categoryIds []string) ([]*entity.Book, error) {
+	wanted := map[string]bool{}
+	for _, id := range categoryIds {
+		if err := validObjectId(id); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+		}
+		wanted[id] = true
+	}
+
+	return r.find(func(doc *entity.Book) bool {
+		for _, id := range doc.CategoryIds {
+			if wanted[id] {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *bookRepository) FindByPublisher(ctx context.Context, publisherId string) ([]*entity.Book, error) {
+	if err := validObjectId(publisherId); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	return r.find(func(doc *entity.Book) bool { return doc.PublisherId == publisherId }), nil
+}
+
+func (r *bookRepository) SetCover(ctx context.Context, id, coverId string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.books.get(id)
+	if !ok {
+		return nil
+	}
+
+	doc := *old
+	doc.CoverId = coverId
+	doc.UpdatedAt = now()
+	r.db.books.replace(doc.Id, &doc)
+
+	return nil
+}
+
+// FindBySeries returns the books of the series sorted by position.
+func (r *bookRepository) FindBySeries(ctx context.Context, seriesId string) ([]*entity.Book, error) {
+	if err := validObjectId(seriesId); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	books := r.find(func(doc *entity.Book) bool { return doc.SeriesId == seriesId })
+	sort.SliceStable(books, func(i, j int) bool { return books[i].SeriesPosition < books[j].SeriesPosition })
+
+	return books, nil
+}
+
+func (r *bookRepository) FindByOriginal(ctx context.Context, originalId string) ([]*entity.Book, error) {
+	if err := validObjectId(originalId); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	return r.find(func(doc *entity.Book) bool { return doc.OriginalId == originalId }), nil
+}
+
+func (r *bookRepository) FindByTags(ctx context.Context, tags []string, all bool) ([]*entity.Book, error) {
+	return r.find(func(doc *entity.Book) bool {
+		has := map[string]bool{}
+		for _, tag := range doc.Tags {
+			has[tag] = true
+		}
+		matched := 0
+		for _, tag := range tags {
+			if has[tag] {
+				matched++
+			}
+		}
+		if all {
+			return len(tags) > 0 && matched == len(tags)
+		}
+		return matched > 0
+	}), nil
+}
+
+func (r *bookRepository) AddTags(ctx context.Context, id string, tags []string) error {
+	return r.setTags(id, func(doc *entity.Book) []string {
+		added := append([]string{}, doc.Tags...)
+		for _, tag := range tags {
+			if !slices.Contains(added, tag) {
+				added = append(added, tag)
+			}
+		}
+		sort.Strings(added)
+		return added
+	})
+}
+
+func (r *bookRepository) RemoveTags(ctx context.Context, id string, tags []string) error {
+	removed := map[string]bool{}
+	for _, tag := range tags {
+		removed[tag] = true
+	}
+
+	return r.setTags(id, func(doc *entity.Book) []string {
+		kept := []string{}
+		for _, tag := range doc.Tags {
+			if !removed[tag] {
+				kept = append(kept, tag)
+			}
+		}
+		return kept
+	})
+}
+
+// setTags replaces the tags of the book id with the ones returned by tags.
+func (r *bookRepository) setTags(id string, tags func(doc *entity.Book) []string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.books.get(id)
+	if !ok {
+		return nil
+	}
+
+	doc := *old
+	doc.Tags = tags(old)
+	doc.UpdatedAt = now()
+	r.db.books.replace(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *bookRepository) CountTags(ctx context.Context) ([]*entity.TagCount, error) {
+	counts := map[string]int{}
+	for _, book := range r.find(func(*entity.Book) bool { return true }) {
+		for _, tag := range book.Tags {
+			counts[tag]++
+		}
+	}
+
+	list := []*entity.TagCount{}
+	for tag, count := range counts {
+		list = append(list, &entity.TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Tag < list[j].Tag
+	})
+
+	return list, nil
+}
+
+func (r *bookRepository) find(match func(*entity.Book) bool) []*entity.Book {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	books := []*entity.Book{}
+	for _, doc := range r.db.books.all() {
+		if !match(doc) {
+			continue
+		}
+		if book, ok := r.join(doc); ok {
+			books = append(books, book)
+		}
+	}
+
+	return books
+}
+
+func (r *bookRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse book ID to ObjectID", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.books.delete(id)
+
+	return nil
+}
+
+// checkISBN enforces the unique ISBN indexes of the other backends, across
+// books and editions.
+func (r *bookRepository) checkISBN(book *entity.Book, editions []*entity.Edition) error {
+	isbns := []string{book.ISBN13}
+	for _, e := range editions {
+		isbns = append(isbns, e.ISBN13)
+	}
+
+	for _, doc := range r.db.books.all() {
+		if doc.Id == book.Id {
+			continue
+		}
+		for _, isbn13 := range isbns {
+			if hasISBN(doc, isbn13) {
+				return portError.NewConflictError("A book with this ISBN already exists.", nil)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSeriesPosition enforces the unique series position index of the other
+// backends.
+func (r *bookRepository) checkSeriesPosition(book *entity.Book) error {
+	if book.SeriesId == "" {
+		return nil
+	}
+
+	for _, doc := range r.db.books.all() {
+		if doc.Id != book.Id && doc.SeriesId == book.SeriesId && doc.SeriesPosition == book.SeriesPosition {
+			return portError.NewConflictError("A book already has this position in the series.", nil)
+		}
+	}
+
+	return nil
+}
+
+// hasISBN reports whether the book or one of its editions has isbn13.
+func hasISBN(doc *entity.Book, isbn13 string) bool {
+	if isbn13 == "" {
+		return false
+	}
+
+	if doc.ISBN13 == isbn13 {
+		return true
+	}
+	for _, e := range doc.Editions {
+		if e.ISBN13 == isbn13 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// join returns a copy of doc with its authors and publisher, or false when its
+// first author does not exist. Contributors whose author was deleted are left
+// out.
+func (r *bookRepository) join(doc *entity.Book) (*entity.Book, bool) {
+	authorDoc, ok := r.db.authors.get(doc.AuthorId)
+	if !ok {
+		return nil, false
+	}
+
+	book := *doc
+	author := *authorDoc
+	book.Author = &author
+	book.CategoryIds = append([]string{}, doc.CategoryIds...)
+	book.Tags = append([]string{}, doc.Tags...)
+	book.Translations = copyTranslations(doc.Translations)
+
+	book.Editions = []*entity.Edition{}
+	for _, e := range doc.Editions {
+		edition := *e
+		book.Editions = append(book.Editions, &edition)
+	}
+
+	if publisherDoc, ok := r.db.publishers.get(doc.PublisherId); ok {
+		publisher := *publisherDoc
+		book.Publisher = &publisher
+	}
+
+	book.Contributors = []*entity.Contributor{}
+	for _, c := range doc.Contributors {
+		authorDoc, ok := r.db.authors.get(c.AuthorId)
+		if !ok {
+			continue
+		}
+		author := *authorDoc
+		book.Contributors = append(book.Contributors, &entity.Contributor{AuthorId: c.AuthorId, Role: c.Role, Author: &author})
+	}
+
+	return &book, true
+}
+
+// copyContributors validates the author IDs of book and returns a copy of its
+// contributors without their authors.
+func copyContributors(book *entity.Book) ([]*entity.Contributor, error) {
+	if err := validObjectId(book.AuthorId); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	contributors := []*entity.Contributor{}
+	for _, c := range book.ContributorsOrAuthor() {
+		if err := validObjectId(c.AuthorId); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+		}
+		contributors = append(contributors, &entity.Contributor{AuthorId: c.AuthorId, Role: c.Role})
+	}
+
+	return contributors, nil
+}
+
+// copyCategoryIds validates the category IDs of book and returns a copy.
+func copyCategoryIds(book *entity.Book) ([]string, error) {
+	categoryIds := []string{}
+	for _, id := range book.CategoryIds {
+		if err := validObjectId(id); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+		}
+		categoryIds = append(categoryIds, id)
+	}
+
+	return categoryIds, nil
+}
+
+// copyEditions validates the edition IDs of book and returns a copy of its
+// editions, with an ID for the new ones.
+func copyEditions(book *entity.Book) ([]*entity.Edition, error) {
+	editions := []*entity.Edition{}
+	for _, e := range book.Editions {
+		edition := *e
+		if edition.Id == "" {
+			edition.Id = newObjectId()
+		} else if err := validObjectId(edition.Id); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse edition ID to ObjectID.", err)
+		}
+		editions = append(editions, &edition)
+	}
+
+	return editions, nil
+}
+
+func validSeriesId(id string) error {
+	if id == "" {
+		return nil
+	}
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	return nil
+}
+
+func validOriginalId(id string) error {
+	if id == "" {
+		return nil
+	}
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse original book ID to ObjectID.", err)
+	}
+
+	return nil
+}
+
+func validPublisherId(id string) error {
+	if id == "" {
+		return nil
+	}
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	return nil
+}
+
+// copyTranslations returns a copy of translations, never nil.
+func copyTranslations[T any](translations map[string]*T) map[string]*T {
+	copied := map[string]*T{}
+	for tag, t := range translations {
+		c := *t
+		copied[tag] = &c
+	}
+
+	return copied
+}
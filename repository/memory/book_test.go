@@ -0,0 +1,65 @@
+package memoryrepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/test"
+)
+
+func apiStatus(err error) int {
+	var apiErr *portError.ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+
+	return 0
+}
+
+func TestBookRepository(t *testing.T) {
+	ctx := context.Background()
+	db := NewDB()
+	authorRepo := NewAuthorRepository(db)
+	bookRepo := NewBookRepository(db)
+
+	author := &entity.Author{FirstName: test.AuthorFirstName1, LastName: test.AuthorLastName1}
+	if err := authorRepo.Store(ctx, author); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := bookRepo.Store(ctx, &entity.Book{AuthorId: author.Id, Name: test.BookName1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book, err := bookRepo.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if book.Author == nil || book.Author.Id != author.Id || book.Author.FirstName != test.AuthorFirstName1 {
+		t.Errorf("Find() author = %+v, want joined author", book.Author)
+	}
+
+	book.Author.FirstName = "changed"
+	if again, _ := bookRepo.Find(ctx, stored.Id); again.Author.FirstName != test.AuthorFirstName1 {
+		t.Errorf("returned book shares memory with the stored one")
+	}
+
+	if _, err := bookRepo.Find(ctx, "invalid"); apiStatus(err) != http.StatusBadRequest {
+		t.Errorf("Find(invalid) error = %v, want bad request", err)
+	}
+	if _, err := bookRepo.Find(ctx, author.Id); apiStatus(err) != http.StatusNotFound {
+		t.Errorf("Find(unknown) error = %v, want not found", err)
+	}
+
+	if err := authorRepo.Delete(ctx, author.Id); apiStatus(err) != http.StatusConflict {
+		t.Errorf("Delete() of an author with books error = %v, want conflict", err)
+	}
+	if books, _ := bookRepo.FindAll(ctx); len(books) != 1 {
+		t.Errorf("FindAll() = %d books, want the book of the kept author", len(books))
+	}
+}
@@ -0,0 +1,117 @@
+package memoryrepo
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+)
+
+type categoryRepository struct {
+	db *DB
+}
+
+func NewCategoryRepository(db *DB) repository.CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+func (r *categoryRepository) Store(ctx context.Context, category *entity.Category) error {
+	if err := validParentId(category.ParentId); err != nil {
+		return err
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	category.Id = newObjectId()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	doc := *category
+	r.db.categories.insert(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	if err := validObjectId(category.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+	}
+
+	if err := validParentId(category.ParentId); err != nil {
+		return err
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.categories.get(category.Id)
+	if !ok {
+		return nil
+	}
+
+	doc := *old
+	doc.Name = category.Name
+	doc.ParentId = category.ParentId
+	doc.UpdatedAt = now()
+	r.db.categories.replace(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *categoryRepository) Find(ctx context.Context, id string) (*entity.Category, error) {
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	doc, ok := r.db.categories.get(id)
+	if !ok {
+		return nil, portError.NewNotFoundError("Category not found.", nil)
+	}
+
+	category := *doc
+	return &category, nil
+}
+
+func (r *categoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	categories := []*entity.Category{}
+	for _, doc := range r.db.categories.all() {
+		category := *doc
+		categories = append(categories, &category)
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse category ID to ObjectID", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.categories.delete(id)
+
+	return nil
+}
+
+func validParentId(id string) error {
+	if id == "" {
+		return nil
+	}
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse parent category ID to ObjectID.", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package memoryrepo
+
+import (
+	"testing"
+
+	"bookstore.com/repository/repositorytest"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db := NewDB()
+		return repositorytest.Repositories{
+			Author:    NewAuthorRepository(db),
+			Book:      NewBookRepository(db),
+			Category:  NewCategoryRepository(db),
+			Publisher: NewPublisherRepository(db),
+			Series:    NewSeriesRepository(db),
+			User:      NewUserRepository(db),
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package memoryrepo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DB holds the data of the in-memory repositories. Repositories created from
+// the same DB see each other's data, like the Mongo collections of one
+// database. It is meant for local development and tests.
+//
+// Documents are never modified in place: a write stores a new copy. This
+// keeps the documents handed out by the repositories isolated from later
+// writes and makes a snapshot of the DB as cheap as copying its maps.
+type DB struct {
+	mu         sync.RWMutex
+	txMu       sync.Mutex
+	authors    *collection[entity.Author]
+	books      *collection[entity.Book]
+	categories *collection[entity.Category]
+	publishers *collection[entity.Publisher]
+	series     *collection[entity.Series]
+	users      *collection[entity.User]
+	outbox     *collection[entity.OutboxMessage]
+	webhooks   *collection[entity.Webhook]
+	deliveries *collection[entity.WebhookDelivery]
+}
+
+func NewDB() *DB {
+	return &DB{
+		authors:    newCollection[entity.Author](),
+		books:      newCollection[entity.Book](),
+		categories: newCollection[entity.Category](),
+		publishers: newCollection[entity.Publisher](),
+		series:     newCollection[entity.Series](),
+		users:      newCollection[entity.User](),
+		outbox:     newCollection[entity.OutboxMessage](),
+		webhooks:   newCollection[entity.Webhook](),
+		deliveries: newCollection[entity.WebhookDelivery](),
+	}
+}
+
+func (db *DB) snapshot() *DB {
+	return &DB{
+		authors:    db.authors.clone(),
+		books:      db.books.clone(),
+		categories: db.categories.clone(),
+		publishers: db.publishers.clone(),
+		series:     db.series.clone(),
+		users:      db.users.clone(),
+		outbox:     db.outbox.clone(),
+		webhooks:   db.webhooks.clone(),
+		deliveries: db.deliveries.clone(),
+	}
+}
+
+func (db *DB) restore(s *DB) {
+	db.authors = s.authors
+	db.books = s.books
+	db.categories = s.categories
+	db.publishers = s.publishers
+	db.series = s.series
+	db.users = s.users
+	db.outbox = s.outbox
+	db.webhooks = s.webhooks
+	db.deliveries = s.deliveries
+}
+
+// collection keeps documents by ID in insertion order, the natural order of
+// a Mongo collection.
+type collection[T any] struct {
+	docs  map[string]*T
+	order []string
+}
+
+func newCollection[T any]() *collection[T] {
+	return &collection[T]{docs: map[string]*T{}}
+}
+
+func (c *collection[T]) get(id string) (*T, bool) {
+	doc, ok := c.docs[id]
+	return doc, ok
+}
+
+// insert adds doc unless id is taken, and reports whether it did.
+func (c *collection[T]) insert(id string, doc *T) bool {
+	if _, ok := c.docs[id]; ok {
+		return false
+	}
+
+	c.docs[id] = doc
+	c.order = append(c.order, id)
+	return true
+}
+
+// replace swaps the document id for doc if it exists.
+func (c *collection[T]) replace(id string, doc *T) {
+	if _, ok := c.docs[id]; ok {
+		c.docs[id] = doc
+	}
+}
+
+func (c *collection[T]) delete(id string) {
+	if _, ok := c.docs[id]; !ok {
+		return
+	}
+
+	delete(c.docs, id)
+	for i, docId := range c.order {
+		if docId == id {
+			c.order = append(c.order[:i:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (c *collection[T]) all() []*T {
+	list := make([]*T, 0, len(c.order))
+	for _, id := range c.order {
+		list = append(list, c.docs[id])
+	}
+
+	return list
+}
+
+func (c *collection[T]) clone() *collection[T] {
+	docs := make(map[string]*T, len(c.docs))
+	for id, doc := range c.docs {
+		docs[id] = doc
+	}
+
+	return &collection[T]{docs: docs, order: append([]string(nil), c.order...)}
+}
+
+// now returns the current time as Mongo stores it: in UTC with millisecond
+// precision.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+func newObjectId() string {
+	return primitive.NewObjectID().Hex()
+}
+
+func validObjectId(id string) error {
+	_, err := primitive.ObjectIDFromHex(id)
+	return err
+}
+
+type txCtxKey struct{}
+
+type transactor struct {
+	db *DB
+}
+
+// NewTransactor creates a Transactor for the repositories of db. Transactions
+// run one at a time and are rolled back by restoring a snapshot of db, so a
+// write made outside the transaction while it runs is lost on rollback too.
+func NewTransactor(db *DB) repository.Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txCtxKey{}) != nil {
+		return fn(ctx)
+	}
+
+	t.db.txMu.Lock()
+	defer t.db.txMu.Unlock()
+
+	t.db.mu.RLock()
+	snapshot := t.db.snapshot()
+	t.db.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txCtxKey{}, t)); err != nil {
+		t.db.mu.Lock()
+		t.db.restore(snapshot)
+		t.db.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package memoryrepo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+)
+
+func TestTransactor_WithinTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := NewDB()
+	tx := NewTransactor(db)
+	authorRepo := NewAuthorRepository(db)
+	outboxRepo := NewOutboxRepository(db)
+
+	err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := authorRepo.Store(ctx, &entity.Author{FirstName: "kept"}); err != nil {
+			return err
+		}
+		return outboxRepo.Publish(ctx, &event.Event{Id: "event-1"})
+	})
+	if err != nil {
+		t.Fatalf("WithinTransaction() error = %v", err)
+	}
+
+	err = tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := authorRepo.Store(ctx, &entity.Author{FirstName: "rolled back"}); err != nil {
+			return err
+		}
+		return errors.New("error occur")
+	})
+	if err == nil {
+		t.Fatalf("WithinTransaction() error = nil")
+	}
+
+	authors, _ := authorRepo.FindAll(ctx)
+	if len(authors) != 1 || authors[0].FirstName != "kept" {
+		t.Errorf("authors after rollback = %+v", authors)
+	}
+
+	msg, _ := outboxRepo.Claim(ctx, 0)
+	if msg == nil || msg.Id != "event-1" || msg.Attempts != 1 {
+		t.Errorf("Claim() = %+v, want the committed event", msg)
+	}
+}
@@ -0,0 +1,104 @@
+package memoryrepo
+
+import (
+	"context"
+	"time"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	"bookstore.com/repository"
+)
+
+type outboxRepository struct {
+	db *DB
+}
+
+func NewOutboxRepository(db *DB) repository.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Publish(ctx context.Context, e *event.Event) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	copied := *e
+	r.db.outbox.insert(e.Id, &entity.OutboxMessage{
+		Id:            e.Id,
+		Event:         &copied,
+		Status:        entity.OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+
+	return nil
+}
+
+// Claim returns the pending message due first and hides it from other relays
+// for lease. It returns nil when nothing is due.
+func (r *outboxRepository) Claim(ctx context.Context, lease time.Duration) (*entity.OutboxMessage, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	var due *entity.OutboxMessage
+	for _, doc := range r.db.outbox.all() {
+		if doc.Status != entity.OutboxPending || doc.NextAttemptAt.After(now) {
+			continue
+		}
+		if due == nil || doc.NextAttemptAt.Before(due.NextAttemptAt) {
+			due = doc
+		}
+	}
+	if due == nil {
+		return nil, nil
+	}
+
+	doc := *due
+	doc.Attempts++
+	doc.NextAttemptAt = now.Add(lease)
+	doc.UpdatedAt = now
+	r.db.outbox.replace(doc.Id, &doc)
+
+	msg := doc
+	return &msg, nil
+}
+
+func (r *outboxRepository) MarkDelivered(ctx context.Context, id string) error {
+	return r.update(id, func(msg *entity.OutboxMessage) {
+		msg.Status = entity.OutboxDelivered
+		msg.LastError = ""
+	})
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	return r.update(id, func(msg *entity.OutboxMessage) {
+		msg.NextAttemptAt = retryAt
+		msg.LastError = cause.Error()
+	})
+}
+
+func (r *outboxRepository) MarkDead(ctx context.Context, id string, cause error) error {
+	return r.update(id, func(msg *entity.OutboxMessage) {
+		msg.Status = entity.OutboxDead
+		msg.LastError = cause.Error()
+	})
+}
+
+func (r *outboxRepository) update(id string, set func(msg *entity.OutboxMessage)) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.outbox.get(id)
+	if !ok {
+		return nil
+	}
+
+	doc := *old
+	set(&doc)
+	doc.UpdatedAt = now()
+	r.db.outbox.replace(id, &doc)
+
+	return nil
+}
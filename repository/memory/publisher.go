@@ -0,0 +1,37 @@
+package memoryrepo
+
+import (
+	"context"
+	"sync"
+
+	"bookstore.com/domain/event"
+)
+
+// Publisher keeps the published events in memory. It is meant for local
+// development and tests.
+type Publisher struct {
+	mu     sync.RWMutex
+	events []*event.Event
+}
+
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+func (p *Publisher) Publish(ctx context.Context, e *event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, e)
+	return nil
+}
+
+// Events returns the events published so far, oldest first.
+func (p *Publisher) Events() []*event.Event {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	events := make([]*event.Event, len(p.events))
+	copy(events, p.events)
+	return events
+}
@@ -0,0 +1,98 @@
+package memoryrepo
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+)
+
+type publisherRepository struct {
+	db *DB
+}
+
+func NewPublisherRepository(db *DB) repository.PublisherRepository {
+	return &publisherRepository{db: db}
+}
+
+func (r *publisherRepository) Store(ctx context.Context, publisher *entity.Publisher) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	publisher.Id = newObjectId()
+	publisher.CreatedAt = now
+	publisher.UpdatedAt = now
+
+	doc := *publisher
+	r.db.publishers.insert(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *publisherRepository) Update(ctx context.Context, publisher *entity.Publisher) error {
+	if err := validObjectId(publisher.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.publishers.get(publisher.Id)
+	if !ok {
+		return nil
+	}
+
+	doc := *old
+	doc.Name = publisher.Name
+	doc.Country = publisher.Country
+	doc.Website = publisher.Website
+	doc.UpdatedAt = now()
+	r.db.publishers.replace(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *publisherRepository) Find(ctx context.Context, id string) (*entity.Publisher, error) {
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	doc, ok := r.db.publishers.get(id)
+	if !ok {
+		return nil, portError.NewNotFoundError("Publisher not found.", nil)
+	}
+
+	publisher := *doc
+	return &publisher, nil
+}
+
+func (r *publisherRepository) FindAll(ctx context.Context) ([]*entity.Publisher, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	publishers := []*entity.Publisher{}
+	for _, doc := range r.db.publishers.all() {
+		publisher := *doc
+		publishers = append(publishers, &publisher)
+	}
+
+	return publishers, nil
+}
+
+func (r *publisherRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse publisher ID to ObjectID", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.publishers.delete(id)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package memoryrepo
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+)
+
+type seriesRepository struct {
+	db *DB
+}
+
+func NewSeriesRepository(db *DB) repository.SeriesRepository {
+	return &seriesRepository{db: db}
+}
+
+func (r *seriesRepository) Store(ctx context.Context, series *entity.Series) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	series.Id = newObjectId()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	doc := *series
+	r.db.series.insert(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *seriesRepository) Update(ctx context.Context, series *entity.Series) error {
+	if err := validObjectId(series.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.series.get(series.Id)
+	if !ok {
+		return nil
+	}
+
+	doc := *old
+	doc.Name = series.Name
+	doc.Description = series.Description
+	doc.UpdatedAt = now()
+	r.db.series.replace(doc.Id, &doc)
+
+	return nil
+}
+
+func (r *seriesRepository) Find(ctx context.Context, id string) (*entity.Series, error) {
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	doc, ok := r.db.series.get(id)
+	if !ok {
+		return nil, portError.NewNotFoundError("Series not found.", nil)
+	}
+
+	series := *doc
+	return &series, nil
+}
+
+func (r *seriesRepository) FindAll(ctx context.Context) ([]*entity.Series, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	list := []*entity.Series{}
+	for _, doc := range r.db.series.all() {
+		series := *doc
+		list = append(list, &series)
+	}
+
+	return list, nil
+}
+
+func (r *seriesRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse series ID to ObjectID", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.series.delete(id)
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package memoryrepo
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+)
+
+type userRepository struct {
+	db *DB
+}
+
+func NewUserRepository(db *DB) repository.UserRepository {
+	return &userRepository{db: db}
+}
+
+// Store adds user. Usernames are unique, as enforced by the Mongo index.
+func (r *userRepository) Store(ctx context.Context, user *entity.User) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, doc := range r.db.users.all() {
+		if doc.Username == user.Username {
+			return portError.NewBadRequestError("User is exist.", nil)
+		}
+	}
+
+	now := now()
+	doc := *user
+	doc.Id = newObjectId()
+	doc.CreatedAt = now
+	doc.UpdatedAt = now
+	r.db.users.insert(doc.Id, &doc)
+
+	return nil
+}
+
+// Find returns the user named username, or nil when there is none.
+func (r *userRepository) Find(ctx context.Context, username string) (*entity.User, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, doc := range r.db.users.all() {
+		if doc.Username == username {
+			user := *doc
+			return &user, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+package memoryrepo
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+)
+
+type webhookRepository struct {
+	db *DB
+}
+
+func NewWebhookRepository(db *DB) repository.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func copyWebhook(doc *entity.Webhook) *entity.Webhook {
+	webhook := *doc
+	webhook.EventTypes = append([]string(nil), doc.EventTypes...)
+
+	return &webhook
+}
+
+func (r *webhookRepository) Store(ctx context.Context, webhook *entity.Webhook) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	webhook.Id = newObjectId()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+	r.db.webhooks.insert(webhook.Id, copyWebhook(webhook))
+
+	return nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	if err := validObjectId(webhook.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse webhook ID to ObjectID.", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.webhooks.get(webhook.Id)
+	if !ok {
+		return nil
+	}
+
+	doc := copyWebhook(old)
+	doc.URL = webhook.URL
+	doc.EventTypes = append([]string(nil), webhook.EventTypes...)
+	doc.Secret = webhook.Secret
+	doc.UpdatedAt = now()
+	r.db.webhooks.replace(doc.Id, doc)
+
+	return nil
+}
+
+func (r *webhookRepository) Find(ctx context.Context, id string) (*entity.Webhook, error) {
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse webhook ID to ObjectID.", err)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	doc, ok := r.db.webhooks.get(id)
+	if !ok {
+		return nil, portError.NewNotFoundError("Webhook not found.", nil)
+	}
+
+	return copyWebhook(doc), nil
+}
+
+func (r *webhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	return r.find(func(*entity.Webhook) bool { return true }), nil
+}
+
+func (r *webhookRepository) FindByEventType(ctx context.Context, eventType string) ([]*entity.Webhook, error) {
+	return r.find(func(webhook *entity.Webhook) bool {
+		for _, t := range webhook.EventTypes {
+			if t == eventType {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *webhookRepository) find(match func(*entity.Webhook) bool) []*entity.Webhook {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	webhooks := []*entity.Webhook{}
+	for _, doc := range r.db.webhooks.all() {
+		if match(doc) {
+			webhooks = append(webhooks, copyWebhook(doc))
+		}
+	}
+
+	return webhooks
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse webhook ID to ObjectID", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.webhooks.delete(id)
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package memoryrepo
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+)
+
+type webhookDeliveryRepository struct {
+	db *DB
+}
+
+func NewWebhookDeliveryRepository(db *DB) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func copyDelivery(doc *entity.WebhookDelivery) *entity.WebhookDelivery {
+	delivery := *doc
+	delivery.Attempts = make([]*entity.DeliveryAttempt, 0, len(doc.Attempts))
+	for _, attempt := range doc.Attempts {
+		copied := *attempt
+		delivery.Attempts = append(delivery.Attempts, &copied)
+	}
+
+	return &delivery
+}
+
+func (r *webhookDeliveryRepository) Store(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	delivery.Status = entity.DeliveryPending
+	delivery.Attempts = []*entity.DeliveryAttempt{}
+	delivery.NextAttemptAt = now
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+	r.db.deliveries.insert(delivery.Id, copyDelivery(delivery))
+
+	return nil
+}
+
+func (r *webhookDeliveryRepository) Find(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	doc, ok := r.db.deliveries.get(id)
+	if !ok {
+		return nil, portError.NewNotFoundError("Webhook delivery not found.", nil)
+	}
+
+	return copyDelivery(doc), nil
+}
+
+// FindByWebhook returns the deliveries to webhookId, newest first.
+func (r *webhookDeliveryRepository) FindByWebhook(ctx context.Context, webhookId string) ([]*entity.WebhookDelivery, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	deliveries := []*entity.WebhookDelivery{}
+	for _, doc := range r.db.deliveries.all() {
+		if doc.WebhookId == webhookId {
+			deliveries = append(deliveries, copyDelivery(doc))
+		}
+	}
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+
+	return deliveries, nil
+}
+
+// Claim returns the pending delivery due first and hides it from other
+// workers for lease. It returns nil when nothing is due.
+func (r *webhookDeliveryRepository) Claim(ctx context.Context, lease time.Duration) (*entity.WebhookDelivery, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := now()
+	var due *entity.WebhookDelivery
+	for _, doc := range r.db.deliveries.all() {
+		if doc.Status != entity.DeliveryPending || doc.NextAttemptAt.After(now) {
+			continue
+		}
+		if due == nil || doc.NextAttemptAt.Before(due.NextAttemptAt) {
+			due = doc
+		}
+	}
+	if due == nil {
+		return nil, nil
+	}
+
+	doc := copyDelivery(due)
+	doc.NextAttemptAt = now.Add(lease)
+	doc.UpdatedAt = now
+	r.db.deliveries.replace(doc.Id, doc)
+
+	return copyDelivery(doc), nil
+}
+
+func (r *webhookDeliveryRepository) RecordAttempt(
+	ctx context.Context,
+	id string,
+	attempt *entity.DeliveryAttempt,
+	status string,
+	nextAttemptAt time.Time,
+) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	old, ok := r.db.deliveries.get(id)
+	if !ok {
+		return nil
+	}
+
+	doc := copyDelivery(old)
+	copied := *attempt
+	doc.Attempts = append(doc.Attempts, &copied)
+	doc.Status = status
+	doc.NextAttemptAt = nextAttemptAt
+	doc.UpdatedAt = now()
+	r.db.deliveries.replace(id, doc)
+
+	return nil
+}
@@ -2,7 +2,7 @@ package mongorepo
 
 import (
 	"context"
-	"log"
+	"regexp"
 	"time"
 
 	entities "bookstore.com/domain/entity"
@@ -12,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const AuthorCollectionName = "authors"
@@ -41,23 +42,34 @@ func (r *authorRepository) Store(ctx context.Context, author *entities.Author) e
 	defer cancel()
 	collection := r.client.Database(r.db).Collection(AuthorCollectionName)
 
+	authorId := primitive.NewObjectID()
 	now := time.Now()
 	_, err := collection.InsertOne(
 		ctx,
 		bson.M{
-			"_id":         primitive.NewObjectID(),
-			"firstName":   author.FirstName,
-			"lastName":    author.LastName,
-			"birthDate":   author.BirthDate,
-			"nationality": author.Nationality,
-			"createdAt":   now,
-			"updatedAt":   now,
+			"_id":          authorId,
+			"firstName":    author.FirstName,
+			"lastName":     author.LastName,
+			"birthDate":    author.BirthDate,
+			"deathDate":    author.DeathDate,
+			"nationality":  author.Nationality,
+			"biography":    author.Biography,
+			"aliases":      append([]string{}, author.Aliases...),
+			"isni":         author.ISNI,
+			"viaf":         author.VIAF,
+			"translations": translationsDoc(author.Translations),
+			"createdAt":    now,
+			"updatedAt":    now,
 		},
 	)
 	if err != nil {
 		return errors.Wrap(err, "authorRepository.Store")
 	}
 
+	author.Id = authorId.Hex()
+	author.CreatedAt = now
+	author.UpdatedAt = now
+
 	return nil
 }
 
@@ -81,7 +93,13 @@ func (r *authorRepository) Update(ctx context.Context, author *entities.Author)
 					{Key: "firstName", Value: author.FirstName},
 					{Key: "lastName", Value: author.LastName},
 					{Key: "birthDate", Value: author.BirthDate},
+					{Key: "deathDate", Value: author.DeathDate},
 					{Key: "nationality", Value: author.Nationality},
+					{Key: "biography", Value: author.Biography},
+					{Key: "aliases", Value: append([]string{}, author.Aliases...)},
+					{Key: "isni", Value: author.ISNI},
+					{Key: "viaf", Value: author.VIAF},
+					{Key: "translations", Value: translationsDoc(author.Translations)},
 					{Key: "updatedAt", Value: now},
 				},
 			},
@@ -120,11 +138,11 @@ func (r *authorRepository) Find(ctx context.Context, id string) (*entities.Autho
 }
 
 func (r *authorRepository) FindAll(ctx context.Context) ([]*entities.Author, error) {
-	var authors []*entities.Author
+	authors := []*entities.Author{}
 	collection := r.client.Database(r.db).Collection(AuthorCollectionName)
-	cur, err := collection.Find(ctx, bson.D{})
+	cur, err := collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
 	if err != nil {
-		log.Fatal(err)
+		return nil, errors.Wrap(err, "authorRepository.FindAll")
 	}
 	defer cur.Close(ctx)
 
@@ -135,6 +153,52 @@ func (r *authorRepository) FindAll(ctx context.Context) ([]*entities.Author, err
 	return authors, nil
 }
 
+func (r *authorRepository) Search(ctx context.Context, query string) ([]*entities.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	pattern := regexp.QuoteMeta(query)
+	regex := primitive.Regex{Pattern: pattern, Options: "i"}
+	filter := bson.M{"$or": bson.A{
+		bson.M{"firstName": regex},
+		bson.M{"lastName": regex},
+		bson.M{"aliases": regex},
+		bson.M{"$expr": bson.M{"$regexMatch": bson.M{
+			"input":   bson.M{"$concat": bson.A{"$firstName", " ", "$lastName"}},
+			"regex":   pattern,
+			"options": "i",
+		}}},
+	}}
+
+	authors := []*entities.Author{}
+	collection := r.client.Database(r.db).Collection(AuthorCollectionName)
+	cur, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "authorRepository.Search")
+	}
+	defer cur.Close(ctx)
+
+	if err := cur.All(ctx, &authors); err != nil {
+		return nil, errors.Wrap(err, "authorRepository.Search")
+	}
+
+	return authors, nil
+}
+
+func (r *authorRepository) SetPhoto(ctx context.Context, id, photoId string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(AuthorCollectionName)
+	_, err = collection.UpdateByID(ctx, _id, bson.M{"$set": bson.M{"photoId": photoId, "updatedAt": time.Now()}})
+	return errors.Wrap(err, "authorRepository.SetPhoto")
+}
+
 func (r *authorRepository) Delete(ctx context.Context, id string) error {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
@@ -144,6 +208,15 @@ func (r *authorRepository) Delete(ctx context.Context, id string) error {
 		return portError.NewBadRequestError("unable to parse author ID to ObjectID", err)
 	}
 
+	books := r.client.Database(r.db).Collection(BookCollectionName)
+	count, err := books.CountDocuments(ctx, bson.M{"authorId": _id}, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errAuthorHasBooks(nil)
+	}
+
 	filter := bson.M{"_id": _id}
 	collection := r.client.Database(r.db).Collection(AuthorCollectionName)
 	_, err = collection.DeleteOne(ctx, filter)
@@ -153,3 +226,7 @@ func (r *authorRepository) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func errAuthorHasBooks(err error) error {
+	return portError.NewConflictError("Author has books.", err)
+}
@@ -0,0 +1,169 @@
+package mongorepo
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const BlobBucketName = "blobs"
+
+// blobStore keeps every blob as a GridFS file named by its key, with the
+// content type in the file metadata.
+type blobStore struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+type blobFile struct {
+	Id         interface{} `bson:"_id"`
+	UploadDate time.Time   `bson:"uploadDate"`
+	Metadata   struct {
+		ContentType string `bson:"contentType"`
+	} `bson:"metadata"`
+}
+
+func NewBlobStore(mongoServerURL, mongoDb string, timeout int) (repository.BlobStore, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new blob mongo store")
+	}
+
+	return &blobStore{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}, nil
+}
+
+// bucket opens the blob bucket. Uploads and downloads take no context, so the
+// bucket gets the deadline of ctx instead.
+func (s *blobStore) bucket(ctx context.Context) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(s.client.Database(s.db), options.GridFSBucket().SetName(BlobBucketName))
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := bucket.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		if err := bucket.SetWriteDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	return bucket, nil
+}
+
+// Put uploads the new file before removing the older ones, so a reader always
+// finds a complete version.
+func (s *blobStore) Put(ctx context.Context, blob *entities.Blob) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	bucket, err := s.bucket(ctx)
+	if err != nil {
+		return errors.Wrap(err, "blobStore.Put")
+	}
+
+	old, err := s.files(ctx, bucket, blob.Key)
+	if err != nil {
+		return errors.Wrap(err, "blobStore.Put")
+	}
+
+	opts := options.GridFSUpload().SetMetadata(bson.M{"contentType": blob.ContentType})
+	if _, err := bucket.UploadFromStream(blob.Key, bytes.NewReader(blob.Data), opts); err != nil {
+		return errors.Wrap(err, "blobStore.Put")
+	}
+
+	for _, file := range old {
+		if err := bucket.DeleteContext(ctx, file.Id); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return errors.Wrap(err, "blobStore.Put")
+		}
+	}
+
+	return nil
+}
+
+func (s *blobStore) Get(ctx context.Context, key string) (*entities.Blob, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	bucket, err := s.bucket(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "blobStore.Get")
+	}
+
+	files, err := s.files(ctx, bucket, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "blobStore.Get")
+	}
+	if len(files) == 0 {
+		return nil, portError.NewNotFoundError("Blob not found.", nil)
+	}
+
+	latest := files[len(files)-1]
+	var buf bytes.Buffer
+	if _, err := bucket.DownloadToStream(latest.Id, &buf); err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, portError.NewNotFoundError("Blob not found.", err)
+		}
+		return nil, errors.Wrap(err, "blobStore.Get")
+	}
+
+	return &entities.Blob{
+		Key:         key,
+		ContentType: latest.Metadata.ContentType,
+		Data:        buf.Bytes(),
+		UpdatedAt:   latest.UploadDate,
+	}, nil
+}
+
+func (s *blobStore) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	bucket, err := s.bucket(ctx)
+	if err != nil {
+		return errors.Wrap(err, "blobStore.Delete")
+	}
+
+	files, err := s.files(ctx, bucket, key)
+	if err != nil {
+		return errors.Wrap(err, "blobStore.Delete")
+	}
+
+	for _, file := range files {
+		if err := bucket.DeleteContext(ctx, file.Id); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return errors.Wrap(err, "blobStore.Delete")
+		}
+	}
+
+	return nil
+}
+
+// files returns the stored versions of key, oldest first.
+func (s *blobStore) files(ctx context.Context, bucket *gridfs.Bucket, key string) ([]*blobFile, error) {
+	cursor, err := bucket.FindContext(ctx, bson.M{"filename": key}, options.GridFSFind().SetSort(bson.D{{Key: "uploadDate", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	files := []*blobFile{}
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
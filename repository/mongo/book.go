@@ -2,6 +2,7 @@ package mongorepo
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	entities "bookstore.com/domain/entity"
@@ -15,6 +16,9 @@ import (
 
 const BookCollectionName = "books"
 
+// seriesPositionIndex is the unique index of the books by series position.
+const seriesPositionIndex = "seriesId_1_seriesPosition_1"
+
 type bookRepository struct {
 	client  *mongo.Client
 	db      string
@@ -39,33 +43,94 @@ func (r *bookRepository) Store(ctx context.Context, book *entities.Book) (*entit
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	authorId, err := primitive.ObjectIDFromHex(book.AuthorId)
+	authorId, contributors, err := contributorDocs(book)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIds, err := categoryIdDocs(book)
 	if err != nil {
-		return nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+		return nil, err
 	}
 
+	publisherId, err := publisherIdDoc(book)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesId, err := seriesIdDoc(book)
+	if err != nil {
+		return nil, err
+	}
+
+	originalId, err := originalIdDoc(book)
+	if err != nil {
+		return nil, err
+	}
+
+	editionsDoc, editions, err := editionDocs(book)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := book.Tags
+	if tags == nil {
+		tags = []string{}
+	}
 	collection := r.client.Database(r.db).Collection(BookCollectionName)
 
 	bookId := primitive.NewObjectID()
+	if err := r.checkISBN(ctx, bookId, book, editions); err != nil {
+		return nil, err
+	}
+	if err := r.checkSeriesPosition(ctx, bookId, book); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	_, err = collection.InsertOne(
 		ctx,
 		bson.M{
 			"_id":             bookId,
 			"authorId":        authorId,
+			"contributors":    contributors,
+			"categoryIds":     categoryIds,
+			"tags":            tags,
+			"publisherId":     publisherId,
+			"editions":        editionsDoc,
+			"seriesId":        seriesId,
+			"seriesPosition":  book.SeriesPosition,
 			"name":            book.Name,
 			"description":     book.Description,
 			"publicationDate": book.PublicationDate,
 			"price":           book.Price,
+			"isbn10":          book.ISBN10,
+			"isbn13":          book.ISBN13,
+			"language":        book.Language,
+			"originalId":      originalId,
+			"translations":    translationsDoc(book.Translations),
 			"createdAt":       now,
 			"updatedAt":       now,
 		},
 	)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, duplicateKeyError(err)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "bookRepository.Store")
 	}
 
-	return &entities.Book{}, nil
+	stored := *book
+	stored.Id = bookId.Hex()
+	stored.Contributors = book.ContributorsOrAuthor()
+	stored.CategoryIds = append([]string{}, book.CategoryIds...)
+	stored.Tags = tags
+	stored.Editions = editions
+	stored.Translations = translationsDoc(book.Translations)
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+
+	return &stored, nil
 }
 
 func (r *bookRepository) Update(ctx context.Context, book *entities.Book) error {
@@ -77,9 +142,46 @@ func (r *bookRepository) Update(ctx context.Context, book *entities.Book) error
 		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
 	}
 
-	authorId, err := primitive.ObjectIDFromHex(book.AuthorId)
+	authorId, contributors, err := contributorDocs(book)
+	if err != nil {
+		return err
+	}
+
+	categoryIds, err := categoryIdDocs(book)
+	if err != nil {
+		return err
+	}
+
+	publisherId, err := publisherIdDoc(book)
 	if err != nil {
-		return portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+		return err
+	}
+
+	seriesId, err := seriesIdDoc(book)
+	if err != nil {
+		return err
+	}
+
+	originalId, err := originalIdDoc(book)
+	if err != nil {
+		return err
+	}
+
+	editionsDoc, editions, err := editionDocs(book)
+	if err != nil {
+		return err
+	}
+
+	if err := r.checkISBN(ctx, _id, book, editions); err != nil {
+		return err
+	}
+	if err := r.checkSeriesPosition(ctx, _id, book); err != nil {
+		return err
+	}
+
+	tags := book.Tags
+	if tags == nil {
+		tags = []string{}
 	}
 
 	collection := r.client.Database(r.db).Collection(BookCollectionName)
@@ -91,15 +193,30 @@ func (r *bookRepository) Update(ctx context.Context, book *entities.Book) error
 			{
 				Key: "$set", Value: bson.D{
 					{Key: "authorId", Value: authorId},
+					{Key: "contributors", Value: contributors},
+					{Key: "categoryIds", Value: categoryIds},
+					{Key: "tags", Value: tags},
+					{Key: "publisherId", Value: publisherId},
+					{Key: "editions", Value: editionsDoc},
+					{Key: "seriesId", Value: seriesId},
+					{Key: "seriesPosition", Value: book.SeriesPosition},
 					{Key: "name", Value: book.Name},
 					{Key: "description", Value: book.Description},
 					{Key: "publicationDate", Value: book.PublicationDate},
 					{Key: "price", Value: book.Price},
+					{Key: "isbn10", Value: book.ISBN10},
+					{Key: "isbn13", Value: book.ISBN13},
+					{Key: "language", Value: book.Language},
+					{Key: "originalId", Value: originalId},
+					{Key: "translations", Value: translationsDoc(book.Translations)},
 					{Key: "updatedAt", Value: now},
 				},
 			},
 		},
 	)
+	if mongo.IsDuplicateKeyError(err) {
+		return duplicateKeyError(err)
+	}
 	if err != nil {
 		return errors.Wrap(err, "bookRepository.Update")
 	}
@@ -108,43 +225,37 @@ func (r *bookRepository) Update(ctx context.Context, book *entities.Book) error
 }
 
 func (r *bookRepository) Find(ctx context.Context, id string) (*entities.Book, error) {
-	ctx, cancel := context.WithTimeout(ctx, r.timeout)
-	defer cancel()
-
 	_id, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+		return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
 	}
 
+	return r.findOne(ctx, bson.M{"_id": _id})
+}
+
+func (r *bookRepository) FindByISBN(ctx context.Context, isbn13 string) (*entities.Book, error) {
+	return r.findOne(ctx, bson.M{"$or": bson.A{
+		bson.M{"isbn13": isbn13},
+		bson.M{"editions.isbn13": isbn13},
+	}})
+}
+
+func (r *bookRepository) findOne(ctx context.Context, match bson.M) (*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
 	var books []*entities.Book
 	collection := r.client.Database(r.db).Collection(BookCollectionName)
-	pipeline := []bson.M{
-		{
-			"$lookup": bson.M{
-				"from":         "authors",
-				"localField":   "authorId",
-				"foreignField": "_id",
-				"as":           "author",
-			},
-		},
-		{
-			"$unwind": "$author",
-		},
-		{
-			"$match": bson.M{
-				"_id": _id,
-			},
-		},
-	}
+	pipeline := append([]bson.M{{"$match": match}}, joinBook()...)
 
 	cursor, err := collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, errors.Wrap(err, "bookRepository.FindAll")
+		return nil, errors.Wrap(err, "bookRepository.Find")
 	}
 
 	err = cursor.All(ctx, &books)
 	if err != nil {
-		return nil, errors.Wrap(err, "bookRepository.FindAll")
+		return nil, errors.Wrap(err, "bookRepository.Find")
 	}
 
 	if len(books) == 0 {
@@ -156,9 +267,317 @@ func (r *bookRepository) Find(ctx context.Context, id string) (*entities.Book, e
 }
 
 func (r *bookRepository) FindAll(ctx context.Context) ([]*entities.Book, error) {
-	var books []*entities.Book
+	books, err := r.find(ctx, joinBook())
+	return books, errors.Wrap(err, "bookRepository.FindAll")
+}
+
+func (r *bookRepository) FindPage(ctx context.Context, after string, limit int) ([]*entities.Book, error) {
+	match := bson.M{}
+	if after != "" {
+		_id, err := primitive.ObjectIDFromHex(after)
+		if err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+		}
+		match["_id"] = bson.M{"$gt": _id}
+	}
+
+	// The _id index serves the sort right after the match. The books of
+	// deleted authors are left out by joinAuthor, so the page is cut right
+	// after it: only the books up to the end of the page are joined with
+	// their author, and the rest of the join runs on the page alone.
+	pipeline := append([]bson.M{{"$match": match}, {"$sort": bson.M{"_id": 1}}}, joinAuthor()...)
+	pipeline = append(pipeline, bson.M{"$limit": limit})
+	pipeline = append(pipeline, joinDetails()...)
+	books, err := r.aggregate(ctx, pipeline)
+	return books, errors.Wrap(err, "bookRepository.FindPage")
+}
+
+func (r *bookRepository) FindByCategories(ctx context.Context, categoryIds []string) ([]*entities.Book, error) {
+	ids := bson.A{}
+	for _, id := range categoryIds {
+		_id, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+		}
+		ids = append(ids, _id)
+	}
+
+	pipeline := append([]bson.M{{"$match": bson.M{"categoryIds": bson.M{"$in": ids}}}}, joinBook()...)
+	books, err := r.find(ctx, pipeline)
+	return books, errors.Wrap(err, "bookRepository.FindByCategories")
+}
+
+func (r *bookRepository) FindByPublisher(ctx context.Context, publisherId string) ([]*entities.Book, error) {
+	_id, err := primitive.ObjectIDFromHex(publisherId)
+	if err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	pipeline := append([]bson.M{{"$match": bson.M{"publisherId": _id}}}, joinBook()...)
+	books, err := r.find(ctx, pipeline)
+	return books, errors.Wrap(err, "bookRepository.FindByPublisher")
+}
+
+func (r *bookRepository) SetCover(ctx context.Context, id, coverId string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(BookCollectionName)
+	_, err = collection.UpdateByID(ctx, _id, bson.M{"$set": bson.M{"coverId": coverId, "updatedAt": time.Now()}})
+	return errors.Wrap(err, "bookRepository.SetCover")
+}
+
+// FindBySeries returns the books of the series sorted by position.
+func (r *bookRepository) FindBySeries(ctx context.Context, seriesId string) ([]*entities.Book, error) {
+	_id, err := primitive.ObjectIDFromHex(seriesId)
+	if err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	pipeline := append([]bson.M{{"$match": bson.M{"seriesId": _id}}}, joinBook()...)
+	books, err := r.findSorted(ctx, pipeline, bson.D{{Key: "seriesPosition", Value: 1}})
+	return books, errors.Wrap(err, "bookRepository.FindBySeries")
+}
+
+func (r *bookRepository) FindByOriginal(ctx context.Context, originalId string) ([]*entities.Book, error) {
+	_id, err := primitive.ObjectIDFromHex(originalId)
+	if err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	pipeline := append([]bson.M{{"$match": bson.M{"originalId": _id}}}, joinBook()...)
+	books, err := r.find(ctx, pipeline)
+	return books, errors.Wrap(err, "bookRepository.FindByOriginal")
+}
+
+func (r *bookRepository) FindByTags(ctx context.Context, tags []string, all bool) ([]*entities.Book, error) {
+	if len(tags) == 0 {
+		return []*entities.Book{}, nil
+	}
+
+	operator := "$in"
+	if all {
+		operator = "$all"
+	}
+
+	pipeline := append([]bson.M{{"$match": bson.M{"tags": bson.M{operator: tags}}}}, joinBook()...)
+	books, err := r.find(ctx, pipeline)
+	return books, errors.Wrap(err, "bookRepository.FindByTags")
+}
+
+// AddTags adds the tags to the set of the book, then sorts it.
+func (r *bookRepository) AddTags(ctx context.Context, id string, tags []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(BookCollectionName)
+	_, err = collection.UpdateByID(ctx, _id, bson.M{
+		"$addToSet": bson.M{"tags": bson.M{"$each": tags}},
+		"$set":      bson.M{"updatedAt": time.Now()},
+	})
+	if err != nil {
+		return errors.Wrap(err, "bookRepository.AddTags")
+	}
+
+	_, err = collection.UpdateByID(ctx, _id, bson.M{"$push": bson.M{"tags": bson.M{"$each": bson.A{}, "$sort": 1}}})
+	return errors.Wrap(err, "bookRepository.AddTags")
+}
+
+func (r *bookRepository) RemoveTags(ctx context.Context, id string, tags []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(BookCollectionName)
+	_, err = collection.UpdateByID(ctx, _id, bson.M{
+		"$pull": bson.M{"tags": bson.M{"$in": tags}},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	})
+	return errors.Wrap(err, "bookRepository.RemoveTags")
+}
+
+// CountTags counts the books of every tag. Like joinBook, a book whose author
+// does not exist is left out.
+func (r *bookRepository) CountTags(ctx context.Context) ([]*entities.TagCount, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
 	collection := r.client.Database(r.db).Collection(BookCollectionName)
-	pipeline := []bson.M{
+	cursor, err := collection.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"tags.0": bson.M{"$exists": true}}},
+		{"$lookup": bson.M{
+			"from":         "authors",
+			"localField":   "authorId",
+			"foreignField": "_id",
+			"as":           "author",
+		}},
+		{"$match": bson.M{"author.0": bson.M{"$exists": true}}},
+		{"$unwind": "$tags"},
+		{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.CountTags")
+	}
+
+	counts := []*entities.TagCount{}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, errors.Wrap(err, "bookRepository.CountTags")
+	}
+
+	return counts, nil
+}
+
+// find runs pipeline sorted by ID.
+func (r *bookRepository) find(ctx context.Context, pipeline []bson.M) ([]*entities.Book, error) {
+	return r.findSorted(ctx, pipeline, bson.D{{Key: "_id", Value: 1}})
+}
+
+func (r *bookRepository) findSorted(ctx context.Context, pipeline []bson.M, sort bson.D) ([]*entities.Book, error) {
+	return r.aggregate(ctx, append(pipeline, bson.M{"$sort": sort}))
+}
+
+// aggregate returns the books of pipeline in the order it leaves them.
+func (r *bookRepository) aggregate(ctx context.Context, pipeline []bson.M) ([]*entities.Book, error) {
+	books := []*entities.Book{}
+	collection := r.client.Database(r.db).Collection(BookCollectionName)
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &books); err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (r *bookRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("unable to parse book ID to ObjectID", err)
+	}
+
+	filter := bson.M{"_id": _id}
+	collection := r.client.Database(r.db).Collection(BookCollectionName)
+	_, err = collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func errDuplicateISBN(err error) error {
+	return portError.NewConflictError("A book with this ISBN already exists.", err)
+}
+
+func errDuplicateSeriesPosition(err error) error {
+	return portError.NewConflictError("A book already has this position in the series.", err)
+}
+
+// duplicateKeyError maps a duplicate key error to the unique index it
+// violates.
+func duplicateKeyError(err error) error {
+	if strings.Contains(err.Error(), seriesPositionIndex) {
+		return errDuplicateSeriesPosition(err)
+	}
+
+	return errDuplicateISBN(err)
+}
+
+// checkSeriesPosition checks that no other book is at the position of book in
+// its series, for a clearer error than the unique index.
+func (r *bookRepository) checkSeriesPosition(ctx context.Context, id primitive.ObjectID, book *entities.Book) error {
+	if book.SeriesId == "" {
+		return nil
+	}
+
+	seriesId, err := primitive.ObjectIDFromHex(book.SeriesId)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(BookCollectionName)
+	count, err := collection.CountDocuments(ctx, bson.M{
+		"_id":            bson.M{"$ne": id},
+		"seriesId":       seriesId,
+		"seriesPosition": book.SeriesPosition,
+	})
+	if err != nil {
+		return errors.Wrap(err, "bookRepository.checkSeriesPosition")
+	}
+	if count > 0 {
+		return errDuplicateSeriesPosition(nil)
+	}
+
+	return nil
+}
+
+// checkISBN rejects the ISBNs of book and its editions already used by
+// another book or edition. The unique indexes only cover one of the two.
+func (r *bookRepository) checkISBN(ctx context.Context, id primitive.ObjectID, book *entities.Book, editions []*entities.Edition) error {
+	isbns := bson.A{}
+	if book.ISBN13 != "" {
+		isbns = append(isbns, book.ISBN13)
+	}
+	for _, e := range editions {
+		if e.ISBN13 != "" {
+			isbns = append(isbns, e.ISBN13)
+		}
+	}
+	if len(isbns) == 0 {
+		return nil
+	}
+
+	collection := r.client.Database(r.db).Collection(BookCollectionName)
+	count, err := collection.CountDocuments(ctx, bson.M{
+		"_id": bson.M{"$ne": id},
+		"$or": bson.A{
+			bson.M{"isbn13": bson.M{"$in": isbns}},
+			bson.M{"editions.isbn13": bson.M{"$in": isbns}},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "bookRepository.checkISBN")
+	}
+	if count > 0 {
+		return errDuplicateISBN(nil)
+	}
+
+	return nil
+}
+
+// joinBook returns the stages joining a book with its first author, which
+// leaves out the books of deleted authors, its contributors with theirs and
+// its publisher. Contributors whose author was deleted are left out.
+func joinBook() []bson.M {
+	return append(joinAuthor(), joinDetails()...)
+}
+
+// joinAuthor returns the stages of joinBook joining a book with its first
+// author.
+func joinAuthor() []bson.M {
+	return []bson.M{
 		{
 			"$lookup": bson.M{
 				"from":         "authors",
@@ -171,35 +590,190 @@ func (r *bookRepository) FindAll(ctx context.Context) ([]*entities.Book, error)
 			"$unwind": "$author",
 		},
 	}
+}
 
-	cursor, err := collection.Aggregate(ctx, pipeline)
+// joinDetails returns the stages of joinBook following joinAuthor.
+func joinDetails() []bson.M {
+	return []bson.M{
+		{
+			"$lookup": bson.M{
+				"from":         "authors",
+				"localField":   "contributors.authorId",
+				"foreignField": "_id",
+				"as":           "contributorAuthors",
+			},
+		},
+		{
+			"$addFields": bson.M{
+				"contributors": bson.M{
+					"$map": bson.M{
+						"input": "$contributors",
+						"as":    "c",
+						"in": bson.M{
+							"authorId": "$$c.authorId",
+							"role":     "$$c.role",
+							"author": bson.M{"$arrayElemAt": bson.A{
+								bson.M{"$filter": bson.M{
+									"input": "$contributorAuthors",
+									"as":    "a",
+									"cond":  bson.M{"$eq": bson.A{"$$a._id", "$$c.authorId"}},
+								}},
+								0,
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			"$addFields": bson.M{
+				"contributors": bson.M{
+					"$filter": bson.M{
+						"input": "$contributors",
+						"as":    "c",
+						"cond":  bson.M{"$ne": bson.A{bson.M{"$type": "$$c.author"}, "missing"}},
+					},
+				},
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"from":         PublisherCollectionName,
+				"localField":   "publisherId",
+				"foreignField": "_id",
+				"as":           "publisher",
+			},
+		},
+		{
+			"$unwind": bson.M{"path": "$publisher", "preserveNullAndEmptyArrays": true},
+		},
+		{
+			"$addFields": bson.M{
+				"editions":     bson.M{"$ifNull": bson.A{"$editions", bson.A{}}},
+				"tags":         bson.M{"$ifNull": bson.A{"$tags", bson.A{}}},
+				"translations": bson.M{"$ifNull": bson.A{"$translations", bson.M{}}},
+			},
+		},
+		{
+			"$project": bson.M{"contributorAuthors": 0},
+		},
+	}
+}
+
+// contributorDocs parses the author IDs of book.
+func contributorDocs(book *entities.Book) (primitive.ObjectID, bson.A, error) {
+	authorId, err := primitive.ObjectIDFromHex(book.AuthorId)
 	if err != nil {
-		return nil, errors.Wrap(err, "bookRepository.FindAll")
+		return authorId, nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
 	}
 
-	err = cursor.All(ctx, &books)
+	contributors := bson.A{}
+	for _, c := range book.ContributorsOrAuthor() {
+		id, err := primitive.ObjectIDFromHex(c.AuthorId)
+		if err != nil {
+			return authorId, nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+		}
+		contributors = append(contributors, bson.M{"authorId": id, "role": c.Role})
+	}
+
+	return authorId, contributors, nil
+}
+
+// categoryIdDocs parses the category IDs of book.
+func categoryIdDocs(book *entities.Book) (bson.A, error) {
+	categoryIds := bson.A{}
+	for _, id := range book.CategoryIds {
+		_id, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+		}
+		categoryIds = append(categoryIds, _id)
+	}
+
+	return categoryIds, nil
+}
+
+// publisherIdDoc parses the publisher of book, nil for a book without one.
+func publisherIdDoc(book *entities.Book) (any, error) {
+	if book.PublisherId == "" {
+		return nil, nil
+	}
+
+	publisherId, err := primitive.ObjectIDFromHex(book.PublisherId)
 	if err != nil {
-		return nil, errors.Wrap(err, "bookRepository.FindAll")
+		return nil, portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
 	}
 
-	return books, nil
+	return publisherId, nil
 }
 
-func (r *bookRepository) Delete(ctx context.Context, id string) error {
-	ctx, cancel := context.WithTimeout(ctx, r.timeout)
-	defer cancel()
+// seriesIdDoc parses the series of book, nil for a book without one.
+func seriesIdDoc(book *entities.Book) (any, error) {
+	if book.SeriesId == "" {
+		return nil, nil
+	}
 
-	_id, err := primitive.ObjectIDFromHex(id)
+	seriesId, err := primitive.ObjectIDFromHex(book.SeriesId)
 	if err != nil {
-		return portError.NewBadRequestError("unable to parse author ID to ObjectID", err)
+		return nil, portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
 	}
 
-	filter := bson.M{"_id": _id}
-	collection := r.client.Database(r.db).Collection(BookCollectionName)
-	_, err = collection.DeleteOne(ctx, filter)
+	return seriesId, nil
+}
+
+// originalIdDoc parses the original of book, nil for a book that is not a
+// translation.
+func originalIdDoc(book *entities.Book) (any, error) {
+	if book.OriginalId == "" {
+		return nil, nil
+	}
+
+	originalId, err := primitive.ObjectIDFromHex(book.OriginalId)
 	if err != nil {
-		return err
+		return nil, portError.NewBadRequestError("Unable to parse original book ID to ObjectID.", err)
 	}
 
-	return nil
+	return originalId, nil
+}
+
+// translationsDoc returns translations, an empty map rather than a null one.
+func translationsDoc[T any](translations map[string]*T) map[string]*T {
+	if translations == nil {
+		return map[string]*T{}
+	}
+
+	return translations
+}
+
+// editionDocs parses the edition IDs of book, assigning one to the new
+// editions, and returns the documents with a copy of the editions.
+func editionDocs(book *entities.Book) (bson.A, []*entities.Edition, error) {
+	docs := bson.A{}
+	editions := []*entities.Edition{}
+	for _, e := range book.Editions {
+		_id := primitive.NewObjectID()
+		if e.Id != "" {
+			var err error
+			_id, err = primitive.ObjectIDFromHex(e.Id)
+			if err != nil {
+				return nil, nil, portError.NewBadRequestError("Unable to parse edition ID to ObjectID.", err)
+			}
+		}
+
+		edition := *e
+		edition.Id = _id.Hex()
+		editions = append(editions, &edition)
+		docs = append(docs, bson.M{
+			"_id":             _id,
+			"format":          e.Format,
+			"isbn10":          e.ISBN10,
+			"isbn13":          e.ISBN13,
+			"pageCount":       e.PageCount,
+			"durationMinutes": e.DurationMinutes,
+			"price":           e.Price,
+			"publicationDate": e.PublicationDate,
+		})
+	}
+
+	return docs, editions, nil
 }
@@ -0,0 +1,176 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const CategoryCollectionName = "categories"
+
+type categoryRepository struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+func NewCategoryRepository(mongoServerURL, mongoDb string, timeout int) (repository.CategoryRepository, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	repo := &categoryRepository{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new category mongo repository")
+	}
+
+	return repo, nil
+}
+
+func (r *categoryRepository) Store(ctx context.Context, category *entities.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	parentId, err := parentIdDoc(category)
+	if err != nil {
+		return err
+	}
+
+	collection := r.client.Database(r.db).Collection(CategoryCollectionName)
+
+	categoryId := primitive.NewObjectID()
+	now := time.Now()
+	_, err = collection.InsertOne(
+		ctx,
+		bson.M{
+			"_id":       categoryId,
+			"name":      category.Name,
+			"parentId":  parentId,
+			"createdAt": now,
+			"updatedAt": now,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "categoryRepository.Store")
+	}
+
+	category.Id = categoryId.Hex()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	return nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(category.Id)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+	}
+
+	parentId, err := parentIdDoc(category)
+	if err != nil {
+		return err
+	}
+
+	collection := r.client.Database(r.db).Collection(CategoryCollectionName)
+	_, err = collection.UpdateByID(
+		ctx,
+		_id,
+		bson.D{
+			{
+				Key: "$set", Value: bson.D{
+					{Key: "name", Value: category.Name},
+					{Key: "parentId", Value: parentId},
+					{Key: "updatedAt", Value: time.Now()},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "categoryRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) Find(ctx context.Context, id string) (*entities.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+	}
+
+	category := &entities.Category{}
+	collection := r.client.Database(r.db).Collection(CategoryCollectionName)
+	err = collection.FindOne(ctx, bson.M{"_id": _id}).Decode(category)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, portError.NewNotFoundError("Category not found.", err)
+		}
+		return nil, errors.Wrap(err, "categoryRepository.Find")
+	}
+
+	return category, nil
+}
+
+func (r *categoryRepository) FindAll(ctx context.Context) ([]*entities.Category, error) {
+	categories := []*entities.Category{}
+	collection := r.client.Database(r.db).Collection(CategoryCollectionName)
+	cur, err := collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "categoryRepository.FindAll")
+	}
+	defer cur.Close(ctx)
+
+	if err := cur.All(ctx, &categories); err != nil {
+		return nil, errors.Wrap(err, "categoryRepository.FindAll")
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("unable to parse category ID to ObjectID", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(CategoryCollectionName)
+	_, err = collection.DeleteOne(ctx, bson.M{"_id": _id})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parentIdDoc parses the parent of category, nil for a top level category.
+func parentIdDoc(category *entities.Category) (any, error) {
+	if category.ParentId == "" {
+		return nil, nil
+	}
+
+	parentId, err := primitive.ObjectIDFromHex(category.ParentId)
+	if err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse parent category ID to ObjectID.", err)
+	}
+
+	return parentId, nil
+}
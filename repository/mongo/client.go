@@ -2,22 +2,70 @@ package mongorepo
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
-// Create a Mongo Client
+// Clients are shared per server URL: a client is a connection pool, and
+// repositories must share it to take part in the same transaction.
+var (
+	clientsMu sync.Mutex
+	clients   = map[string]*mongo.Client{}
+)
+
+// Create a Mongo Client. Every command sent through it is traced.
 func newMongClient(mongoServerURL string, timeout int) (*mongo.Client, error) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	if client, ok := clients[mongoServerURL]; ok {
+		return client, nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoServerURL))
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoServerURL).SetMonitor(otelmongo.NewMonitor()))
 	if err != nil {
 		return nil, err
 	}
 
 	//We could ping the server to test connectivity if we want
 
+	clients[mongoServerURL] = client
 	return client, nil
 }
+
+type transactor struct {
+	client *mongo.Client
+}
+
+// NewTransactor creates a Transactor running Mongo multi-document
+// transactions. Mongo must run as a replica set.
+func NewTransactor(mongoServerURL string, timeout int) (repository.Transactor, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new mongo transactor")
+	}
+
+	return &transactor{client: mongoClient}, nil
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.client.StartSession()
+	if err != nil {
+		return errors.Wrap(err, "transactor.StartSession")
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+
+	return err
+}
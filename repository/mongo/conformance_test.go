@@ -0,0 +1,74 @@
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"bookstore.com/repository/repositorytest"
+)
+
+// TestConformance runs against the server in BOOKSTORE_TEST_MONGO_URL, e.g.
+// the docker-compose one with "mongodb://localhost:27017/?directConnection=true".
+// Every test uses its own database, dropped afterwards.
+func TestConformance(t *testing.T) {
+	url := os.Getenv("BOOKSTORE_TEST_MONGO_URL")
+	if url == "" {
+		t.Skip("BOOKSTORE_TEST_MONGO_URL is not set")
+	}
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		ctx := context.Background()
+		db := fmt.Sprintf("bookstore_test_%d", time.Now().UnixNano())
+
+		client, err := newMongClient(url, 5)
+		if err != nil {
+			t.Fatalf("newMongClient() error = %v", err)
+		}
+		t.Cleanup(func() { client.Database(db).Drop(ctx) })
+
+		migrator, err := NewMigrator(url, db, 5)
+		if err != nil {
+			t.Fatalf("NewMigrator() error = %v", err)
+		}
+		if _, err := migrator.Up(ctx); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+
+		authorRepo, err := NewAuthorRepository(url, db, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bookRepo, err := NewBookRepository(url, db, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		categoryRepo, err := NewCategoryRepository(url, db, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publisherRepo, err := NewPublisherRepository(url, db, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seriesRepo, err := NewSeriesRepository(url, db, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		userRepo, err := NewUserRepository(url, db, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return repositorytest.Repositories{
+			Author:    authorRepo,
+			Book:      bookRepo,
+			Category:  categoryRepo,
+			Publisher: publisherRepo,
+			Series:    seriesRepo,
+			User:      userRepo,
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	"bookstore.com/tools/migrate"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const MigrationCollectionName = "migrations"
+
+type migrationRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+type migrationStore struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+// NewMigrator creates a Migrator applying the registered Migrations to the
+// mongoDb database. The applied versions are recorded in the migrations
+// collection.
+func NewMigrator(mongoServerURL, mongoDb string, timeout int) (*migrate.Migrator, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new mongo migrator")
+	}
+
+	store := &migrationStore{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+
+	return migrate.New(store, Migrations(mongoClient.Database(mongoDb))...)
+}
+
+func (s *migrationStore) collection() *mongo.Collection {
+	return s.client.Database(s.db).Collection(MigrationCollectionName)
+}
+
+func (s *migrationStore) Applied(ctx context.Context) ([]*migrate.Record, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	cur, err := s.collection().Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "migrationStore.Applied")
+	}
+	defer cur.Close(ctx)
+
+	docs := []*migrationRecord{}
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, errors.Wrap(err, "migrationStore.Applied")
+	}
+
+	records := []*migrate.Record{}
+	for _, doc := range docs {
+		records = append(records, &migrate.Record{
+			Version:     doc.Version,
+			Description: doc.Description,
+			AppliedAt:   doc.AppliedAt,
+		})
+	}
+
+	return records, nil
+}
+
+// Insert records a migration. Another instance starting at the same time may
+// have recorded it first; migrations are idempotent so this is not an error.
+func (s *migrationStore) Insert(ctx context.Context, record *migrate.Record) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := s.collection().InsertOne(ctx, &migrationRecord{
+		Version:     record.Version,
+		Description: record.Description,
+		AppliedAt:   record.AppliedAt,
+	})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return errors.Wrap(err, "migrationStore.Insert")
+	}
+
+	return nil
+}
+
+func (s *migrationStore) Delete(ctx context.Context, version int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if _, err := s.collection().DeleteOne(ctx, bson.M{"_id": version}); err != nil {
+		return errors.Wrap(err, "migrationStore.Delete")
+	}
+
+	return nil
+}
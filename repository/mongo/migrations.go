@@ -0,0 +1,139 @@
+package mongorepo
+
+import (
+	"context"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/tools/migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migrations lists the schema changes of the Mongo backend. Append new ones
+// with the next version; never renumber or edit an applied migration.
+func Migrations(db *mongo.Database) []migrate.Migration {
+	return []migrate.Migration{
+		indexMigration(db, 1, "index books by author", BookCollectionName,
+			"authorId_1", bson.D{{Key: "authorId", Value: 1}}, false),
+		indexMigration(db, 2, "index books by name", BookCollectionName,
+			"name_1", bson.D{{Key: "name", Value: 1}}, false),
+		indexMigration(db, 3, "unique username", UserCollectionName,
+			"username_1", bson.D{{Key: "username", Value: 1}}, true),
+		indexMigration(db, 4, "index authors by name", AuthorCollectionName,
+			"lastName_1_firstName_1", bson.D{{Key: "lastName", Value: 1}, {Key: "firstName", Value: 1}}, false),
+		indexMigration(db, 5, "index pending outbox messages", OutboxCollectionName,
+			"status_1_nextAttemptAt_1", bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}}, false),
+		indexMigration(db, 6, "index webhooks by event type", WebhookCollectionName,
+			"eventTypes_1", bson.D{{Key: "eventTypes", Value: 1}}, false),
+		indexMigration(db, 7, "index webhook deliveries by webhook", WebhookDeliveryCollectionName,
+			"webhookId_1_createdAt_-1", bson.D{{Key: "webhookId", Value: 1}, {Key: "createdAt", Value: -1}}, false),
+		indexMigration(db, 8, "index pending webhook deliveries", WebhookDeliveryCollectionName,
+			"status_1_nextAttemptAt_1", bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}}, false),
+		// Books without ISBN store an empty string and are left out.
+		indexMigrationWithOptions(db, 9, "unique book ISBN", BookCollectionName,
+			"isbn13_1", bson.D{{Key: "isbn13", Value: 1}},
+			options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"isbn13": bson.M{"$gt": ""}})),
+		{
+			Version:     10,
+			Description: "add book contributors",
+			Up: func(ctx context.Context) error {
+				_, err := db.Collection(BookCollectionName).UpdateMany(ctx,
+					bson.M{"contributors": bson.M{"$exists": false}},
+					bson.A{bson.M{"$set": bson.M{"contributors": bson.A{bson.M{"authorId": "$authorId", "role": entity.ContributorAuthor}}}}},
+				)
+				return err
+			},
+			Down: func(ctx context.Context) error {
+				_, err := db.Collection(BookCollectionName).UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"contributors": ""}})
+				return err
+			},
+		},
+		indexMigration(db, 11, "index books by contributor", BookCollectionName,
+			"contributors.authorId_1", bson.D{{Key: "contributors.authorId", Value: 1}}, false),
+		indexMigration(db, 12, "index categories by parent", CategoryCollectionName,
+			"parentId_1", bson.D{{Key: "parentId", Value: 1}}, false),
+		indexMigration(db, 13, "index books by category", BookCollectionName,
+			"categoryIds_1", bson.D{{Key: "categoryIds", Value: 1}}, false),
+		indexMigration(db, 14, "index books by publisher", BookCollectionName,
+			"publisherId_1", bson.D{{Key: "publisherId", Value: 1}}, false),
+		// Superseded by migration 19: the partial filter is evaluated per book,
+		// not per edition, so the editions without ISBN of two books collide.
+		indexMigrationWithOptions(db, 15, "unique edition ISBN", BookCollectionName,
+			"editions.isbn13_1", bson.D{{Key: "editions.isbn13", Value: 1}},
+			options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"editions.isbn13": bson.M{"$gt": ""}})),
+		// Books without series store a null seriesId and are left out.
+		indexMigrationWithOptions(db, 16, "unique book series position", BookCollectionName,
+			seriesPositionIndex, bson.D{{Key: "seriesId", Value: 1}, {Key: "seriesPosition", Value: 1}},
+			options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"seriesId": bson.M{"$type": "objectId"}})),
+		indexMigration(db, 17, "index books by original", BookCollectionName,
+			"originalId_1", bson.D{{Key: "originalId", Value: 1}}, false),
+		// A multikey index, with an entry for every tag of a book.
+		indexMigration(db, 18, "index books by tag", BookCollectionName,
+			"tags_1", bson.D{{Key: "tags", Value: 1}}, false),
+		editionISBNIndexMigration(db, 19),
+	}
+}
+
+// editionISBNIndexMigration replaces the unique edition ISBN index of
+// migration 15 with a lookup index. A book with an edition with ISBN and one
+// without indexes both the ISBN and the empty one, so uniqueness is left to
+// checkISBN.
+func editionISBNIndexMigration(db *mongo.Database, version int) migrate.Migration {
+	const name = "editions.isbn13_1"
+	keys := bson.D{{Key: "editions.isbn13", Value: 1}}
+	replace := func(ctx context.Context, opts *options.IndexOptions) error {
+		indexes := db.Collection(BookCollectionName).Indexes()
+		if _, err := indexes.DropOne(ctx, name); err != nil {
+			return err
+		}
+		_, err := indexes.CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: opts.SetName(name)})
+		return err
+	}
+
+	return migrate.Migration{
+		Version:     version,
+		Description: "index edition ISBN without uniqueness",
+		Up: func(ctx context.Context) error {
+			return replace(ctx, options.Index())
+		},
+		Down: func(ctx context.Context) error {
+			return replace(ctx, options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"editions.isbn13": bson.M{"$gt": ""}}))
+		},
+	}
+}
+
+// indexMigration creates the index name on collection, and drops it on the
+// way down. Creating an index that already exists with the same keys is a
+// no-op in Mongo, so the migration can be run again safely.
+func indexMigration(db *mongo.Database, version int, description, collection, name string, keys bson.D, unique bool) migrate.Migration {
+	opts := options.Index()
+	if unique {
+		opts.SetUnique(true)
+	}
+
+	return indexMigrationWithOptions(db, version, description, collection, name, keys, opts)
+}
+
+func indexMigrationWithOptions(
+	db *mongo.Database,
+	version int,
+	description, collection, name string,
+	keys bson.D,
+	opts *options.IndexOptions,
+) migrate.Migration {
+	return migrate.Migration{
+		Version:     version,
+		Description: description,
+		Up: func(ctx context.Context) error {
+			opts.SetName(name)
+			_, err := db.Collection(collection).Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: opts})
+			return err
+		},
+		Down: func(ctx context.Context) error {
+			_, err := db.Collection(collection).Indexes().DropOne(ctx, name)
+			return err
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package mongorepo
+
+import (
+	"context"
+	"testing"
+
+	"bookstore.com/tools/migrate"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMigrations(t *testing.T) {
+	// Connect does not reach the server, building the migrations needs none.
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Disconnect(context.TODO())
+
+	migrations := Migrations(client.Database("bookstore"))
+	if _, err := migrate.New(migrate.NewMemoryStore(), migrations...); err != nil {
+		t.Fatalf("invalid migrations: %v", err)
+	}
+
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %q has version %d, want %d", m.Description, m.Version, i+1)
+		}
+		if m.Down == nil {
+			t.Errorf("migration %d cannot be reverted", m.Version)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package mongorepo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const OutboxCollectionName = "outbox"
+
+// outboxMessage is the document stored in the outbox collection. The event
+// payload is kept as its JSON text so that it is relayed byte for byte.
+type outboxMessage struct {
+	Id            string     `bson:"_id"`
+	Type          event.Type `bson:"type"`
+	EntityId      string     `bson:"entityId"`
+	Actor         string     `bson:"actor"`
+	Timestamp     time.Time  `bson:"timestamp"`
+	Payload       string     `bson:"payload"`
+	Status        string     `bson:"status"`
+	Attempts      int        `bson:"attempts"`
+	NextAttemptAt time.Time  `bson:"nextAttemptAt"`
+	LastError     string     `bson:"lastError"`
+	CreatedAt     time.Time  `bson:"createdAt"`
+	UpdatedAt     time.Time  `bson:"updatedAt"`
+}
+
+func (m *outboxMessage) toEntity() *entities.OutboxMessage {
+	e := &event.Event{
+		Id:        m.Id,
+		Type:      m.Type,
+		EntityId:  m.EntityId,
+		Actor:     m.Actor,
+		Timestamp: m.Timestamp,
+	}
+	if m.Payload != "" {
+		e.Payload = json.RawMessage(m.Payload)
+	}
+
+	return &entities.OutboxMessage{
+		Id:            m.Id,
+		Event:         e,
+		Status:        m.Status,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+type outboxRepository struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+func NewOutboxRepository(mongoServerURL, mongoDb string, timeout int) (repository.OutboxRepository, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	repo := &outboxRepository{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new outbox mongo repository")
+	}
+
+	return repo, nil
+}
+
+// Publish adds e to the outbox. Called with a transaction context, the event
+// is only visible to the relay once the transaction commits.
+func (r *outboxRepository) Publish(ctx context.Context, e *event.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(OutboxCollectionName)
+
+	now := time.Now()
+	_, err := collection.InsertOne(ctx, &outboxMessage{
+		Id:            e.Id,
+		Type:          e.Type,
+		EntityId:      e.EntityId,
+		Actor:         e.Actor,
+		Timestamp:     e.Timestamp,
+		Payload:       string(e.Payload),
+		Status:        entities.OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		return errors.Wrap(err, "outboxRepository.Publish")
+	}
+
+	return nil
+}
+
+// Claim returns the oldest pending message due for delivery and hides it
+// from other relays for lease. It returns nil when nothing is due.
+func (r *outboxRepository) Claim(ctx context.Context, lease time.Duration) (*entities.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(OutboxCollectionName)
+
+	now := time.Now()
+	msg := &outboxMessage{}
+	err := collection.FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"status":        entities.OutboxPending,
+			"nextAttemptAt": bson.M{"$lte": now},
+		},
+		bson.M{
+			"$set": bson.M{"nextAttemptAt": now.Add(lease), "updatedAt": now},
+			"$inc": bson.M{"attempts": 1},
+		},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(msg)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "outboxRepository.Claim")
+	}
+
+	return msg.toEntity(), nil
+}
+
+func (r *outboxRepository) MarkDelivered(ctx context.Context, id string) error {
+	return r.update(ctx, id, bson.M{
+		"status":    entities.OutboxDelivered,
+		"lastError": "",
+	})
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	return r.update(ctx, id, bson.M{
+		"nextAttemptAt": retryAt,
+		"lastError":     cause.Error(),
+	})
+}
+
+func (r *outboxRepository) MarkDead(ctx context.Context, id string, cause error) error {
+	return r.update(ctx, id, bson.M{
+		"status":    entities.OutboxDead,
+		"lastError": cause.Error(),
+	})
+}
+
+func (r *outboxRepository) update(ctx context.Context, id string, set bson.M) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(OutboxCollectionName)
+
+	set["updatedAt"] = time.Now()
+	_, err := collection.UpdateByID(ctx, id, bson.M{"$set": set})
+	if err != nil {
+		return errors.Wrap(err, "outboxRepository.update")
+	}
+
+	return nil
+}
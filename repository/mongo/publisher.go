@@ -0,0 +1,158 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const PublisherCollectionName = "publishers"
+
+type publisherRepository struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+func NewPublisherRepository(mongoServerURL, mongoDb string, timeout int) (repository.PublisherRepository, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	repo := &publisherRepository{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new publisher mongo repository")
+	}
+
+	return repo, nil
+}
+
+func (r *publisherRepository) Store(ctx context.Context, publisher *entities.Publisher) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(PublisherCollectionName)
+
+	publisherId := primitive.NewObjectID()
+	now := time.Now()
+	_, err := collection.InsertOne(
+		ctx,
+		bson.M{
+			"_id":       publisherId,
+			"name":      publisher.Name,
+			"country":   publisher.Country,
+			"website":   publisher.Website,
+			"createdAt": now,
+			"updatedAt": now,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "publisherRepository.Store")
+	}
+
+	publisher.Id = publisherId.Hex()
+	publisher.CreatedAt = now
+	publisher.UpdatedAt = now
+
+	return nil
+}
+
+func (r *publisherRepository) Update(ctx context.Context, publisher *entities.Publisher) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(publisher.Id)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(PublisherCollectionName)
+	now := time.Now()
+	_, err = collection.UpdateByID(
+		ctx,
+		_id,
+		bson.D{
+			{
+				Key: "$set", Value: bson.D{
+					{Key: "name", Value: publisher.Name},
+					{Key: "country", Value: publisher.Country},
+					{Key: "website", Value: publisher.Website},
+					{Key: "updatedAt", Value: now},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "publisherRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *publisherRepository) Find(ctx context.Context, id string) (*entities.Publisher, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	publisher := &entities.Publisher{}
+	collection := r.client.Database(r.db).Collection(PublisherCollectionName)
+
+	filter := bson.M{"_id": _id}
+	err = collection.FindOne(ctx, filter).Decode(publisher)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, portError.NewNotFoundError("Publisher not found.", err)
+		}
+		return nil, errors.Wrap(err, "publisherRepository.Find")
+	}
+
+	return publisher, nil
+
+}
+
+func (r *publisherRepository) FindAll(ctx context.Context) ([]*entities.Publisher, error) {
+	publishers := []*entities.Publisher{}
+	collection := r.client.Database(r.db).Collection(PublisherCollectionName)
+	cur, err := collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "publisherRepository.FindAll")
+	}
+	defer cur.Close(ctx)
+
+	if err := cur.All(ctx, &publishers); err != nil {
+		return nil, errors.Wrap(err, "publisherRepository.FindAll")
+	}
+
+	return publishers, nil
+}
+
+func (r *publisherRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("unable to parse publisher ID to ObjectID", err)
+	}
+
+	filter := bson.M{"_id": _id}
+	collection := r.client.Database(r.db).Collection(PublisherCollectionName)
+	_, err = collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	"bookstore.com/tools/ratelimit"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const RateLimitCollectionName = "ratelimits"
+
+type rateLimitStore struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+// NewRateLimitStore creates a ratelimit.Store sharing the token buckets
+// between replicas through Mongo. Buckets expire once they are full again.
+func NewRateLimitStore(mongoServerURL, mongoDb string, timeout int) (ratelimit.Store, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new rate limit mongo store")
+	}
+
+	store := &rateLimitStore{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), store.timeout)
+	defer cancel()
+
+	_, err = store.collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expireAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create rate limit ttl index")
+	}
+
+	return store, nil
+}
+
+func (s *rateLimitStore) collection() *mongo.Collection {
+	return s.client.Database(s.db).Collection(RateLimitCollectionName)
+}
+
+// Take refills and takes a token from the bucket in a single atomic update so
+// that concurrent replicas never over-spend it.
+func (s *rateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	now := time.Now()
+	capacity := limit.Capacity()
+	ratePerMs := limit.Rate() / 1000
+	fullIn := time.Duration(capacity / limit.Rate() * float64(time.Second))
+
+	elapsedMs := bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$updatedAt", now}}}}}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"tokens": bson.M{"$min": bson.A{capacity, bson.M{"$add": bson.A{
+				bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+				bson.M{"$multiply": bson.A{elapsedMs, ratePerMs}},
+			}}}},
+			"updatedAt": now,
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed": bson.M{"$gte": bson.A{"$tokens", 1}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"tokens":   bson.M{"$cond": bson.A{"$allowed", bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+			"expireAt": now.Add(fullIn),
+		}}},
+	}
+
+	var bucket struct {
+		Tokens  float64 `bson:"tokens"`
+		Allowed bool    `bson:"allowed"`
+	}
+	err := s.collection().FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": key},
+		pipeline,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&bucket)
+	if err != nil {
+		return nil, errors.Wrap(err, "rateLimitStore.Take")
+	}
+
+	return ratelimit.NewResult(bucket.Allowed, bucket.Tokens, limit), nil
+}
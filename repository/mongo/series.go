@@ -0,0 +1,156 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const SeriesCollectionName = "series"
+
+type seriesRepository struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+func NewSeriesRepository(mongoServerURL, mongoDb string, timeout int) (repository.SeriesRepository, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	repo := &seriesRepository{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new series mongo repository")
+	}
+
+	return repo, nil
+}
+
+func (r *seriesRepository) Store(ctx context.Context, series *entities.Series) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(SeriesCollectionName)
+
+	seriesId := primitive.NewObjectID()
+	now := time.Now()
+	_, err := collection.InsertOne(
+		ctx,
+		bson.M{
+			"_id":         seriesId,
+			"name":        series.Name,
+			"description": series.Description,
+			"createdAt":   now,
+			"updatedAt":   now,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "seriesRepository.Store")
+	}
+
+	series.Id = seriesId.Hex()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	return nil
+}
+
+func (r *seriesRepository) Update(ctx context.Context, series *entities.Series) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(series.Id)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(SeriesCollectionName)
+	now := time.Now()
+	_, err = collection.UpdateByID(
+		ctx,
+		_id,
+		bson.D{
+			{
+				Key: "$set", Value: bson.D{
+					{Key: "name", Value: series.Name},
+					{Key: "description", Value: series.Description},
+					{Key: "updatedAt", Value: now},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "seriesRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *seriesRepository) Find(ctx context.Context, id string) (*entities.Series, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	series := &entities.Series{}
+	collection := r.client.Database(r.db).Collection(SeriesCollectionName)
+
+	filter := bson.M{"_id": _id}
+	err = collection.FindOne(ctx, filter).Decode(series)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, portError.NewNotFoundError("Series not found.", err)
+		}
+		return nil, errors.Wrap(err, "seriesRepository.Find")
+	}
+
+	return series, nil
+
+}
+
+func (r *seriesRepository) FindAll(ctx context.Context) ([]*entities.Series, error) {
+	list := []*entities.Series{}
+	collection := r.client.Database(r.db).Collection(SeriesCollectionName)
+	cur, err := collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "seriesRepository.FindAll")
+	}
+	defer cur.Close(ctx)
+
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, errors.Wrap(err, "seriesRepository.FindAll")
+	}
+
+	return list, nil
+}
+
+func (r *seriesRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("unable to parse series ID to ObjectID", err)
+	}
+
+	filter := bson.M{"_id": _id}
+	collection := r.client.Database(r.db).Collection(SeriesCollectionName)
+	_, err = collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
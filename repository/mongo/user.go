@@ -5,6 +5,7 @@ import (
 	"time"
 
 	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
 	"bookstore.com/repository"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
@@ -48,10 +49,14 @@ func (r *userRepository) Store(ctx context.Context, user *entities.User) error {
 			"lastName":  user.LastName,
 			"password":  user.Password,
 			"username":  user.Username,
+			"role":      user.Role,
 			"createdAt": now,
 			"updatedAt": now,
 		},
 	)
+	if mongo.IsDuplicateKeyError(err) {
+		return portError.NewBadRequestError("User is exist.", err)
+	}
 	if err != nil {
 		return errors.Wrap(err, "mongoRepository.Store")
 	}
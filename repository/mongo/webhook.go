@@ -0,0 +1,165 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const WebhookCollectionName = "webhooks"
+
+type webhookRepository struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+func NewWebhookRepository(mongoServerURL, mongoDb string, timeout int) (repository.WebhookRepository, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	repo := &webhookRepository{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new webhook mongo repository")
+	}
+
+	return repo, nil
+}
+
+func (r *webhookRepository) Store(ctx context.Context, webhook *entities.Webhook) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(WebhookCollectionName)
+
+	webhookId := primitive.NewObjectID()
+	now := time.Now()
+	_, err := collection.InsertOne(
+		ctx,
+		bson.M{
+			"_id":        webhookId,
+			"url":        webhook.URL,
+			"eventTypes": webhook.EventTypes,
+			"secret":     webhook.Secret,
+			"createdAt":  now,
+			"updatedAt":  now,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepository.Store")
+	}
+
+	webhook.Id = webhookId.Hex()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	return nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *entities.Webhook) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(webhook.Id)
+	if err != nil {
+		return portError.NewBadRequestError("Unable to parse webhook ID to ObjectID.", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(WebhookCollectionName)
+	now := time.Now()
+	_, err = collection.UpdateByID(
+		ctx,
+		_id,
+		bson.D{
+			{
+				Key: "$set", Value: bson.D{
+					{Key: "url", Value: webhook.URL},
+					{Key: "eventTypes", Value: webhook.EventTypes},
+					{Key: "secret", Value: webhook.Secret},
+					{Key: "updatedAt", Value: now},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) Find(ctx context.Context, id string) (*entities.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse webhook ID to ObjectID.", err)
+	}
+
+	webhook := &entities.Webhook{}
+	collection := r.client.Database(r.db).Collection(WebhookCollectionName)
+
+	err = collection.FindOne(ctx, bson.M{"_id": _id}).Decode(webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, portError.NewNotFoundError("Webhook not found.", err)
+		}
+		return nil, errors.Wrap(err, "webhookRepository.Find")
+	}
+
+	return webhook, nil
+}
+
+func (r *webhookRepository) FindAll(ctx context.Context) ([]*entities.Webhook, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *webhookRepository) FindByEventType(ctx context.Context, eventType string) ([]*entities.Webhook, error) {
+	return r.find(ctx, bson.M{"eventTypes": eventType})
+}
+
+func (r *webhookRepository) find(ctx context.Context, filter bson.M) ([]*entities.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	webhooks := []*entities.Webhook{}
+	collection := r.client.Database(r.db).Collection(WebhookCollectionName)
+	cur, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookRepository.FindAll")
+	}
+	defer cur.Close(ctx)
+
+	if err := cur.All(ctx, &webhooks); err != nil {
+		return nil, errors.Wrap(err, "webhookRepository.FindAll")
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_id, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return portError.NewBadRequestError("unable to parse webhook ID to ObjectID", err)
+	}
+
+	collection := r.client.Database(r.db).Collection(WebhookCollectionName)
+	_, err = collection.DeleteOne(ctx, bson.M{"_id": _id})
+	if err != nil {
+		return errors.Wrap(err, "webhookRepository.Delete")
+	}
+
+	return nil
+}
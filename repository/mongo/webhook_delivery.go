@@ -0,0 +1,160 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const WebhookDeliveryCollectionName = "webhookDeliveries"
+
+type webhookDeliveryRepository struct {
+	client  *mongo.Client
+	db      string
+	timeout time.Duration
+}
+
+func NewWebhookDeliveryRepository(mongoServerURL, mongoDb string, timeout int) (repository.WebhookDeliveryRepository, error) {
+	mongoClient, err := newMongClient(mongoServerURL, timeout)
+	repo := &webhookDeliveryRepository{
+		client:  mongoClient,
+		db:      mongoDb,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new webhook delivery mongo repository")
+	}
+
+	return repo, nil
+}
+
+func (r *webhookDeliveryRepository) Store(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(WebhookDeliveryCollectionName)
+
+	now := time.Now()
+	delivery.Status = entities.DeliveryPending
+	delivery.Attempts = []*entities.DeliveryAttempt{}
+	delivery.NextAttemptAt = now
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	_, err := collection.InsertOne(ctx, delivery)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+		return errors.Wrap(err, "webhookDeliveryRepository.Store")
+	}
+
+	return nil
+}
+
+func (r *webhookDeliveryRepository) Find(ctx context.Context, id string) (*entities.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	delivery := &entities.WebhookDelivery{}
+	collection := r.client.Database(r.db).Collection(WebhookDeliveryCollectionName)
+
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, portError.NewNotFoundError("Webhook delivery not found.", err)
+		}
+		return nil, errors.Wrap(err, "webhookDeliveryRepository.Find")
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookDeliveryRepository) FindByWebhook(ctx context.Context, webhookId string) ([]*entities.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	deliveries := []*entities.WebhookDelivery{}
+	collection := r.client.Database(r.db).Collection(WebhookDeliveryCollectionName)
+	cur, err := collection.Find(
+		ctx,
+		bson.M{"webhookId": webhookId},
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookDeliveryRepository.FindByWebhook")
+	}
+	defer cur.Close(ctx)
+
+	if err := cur.All(ctx, &deliveries); err != nil {
+		return nil, errors.Wrap(err, "webhookDeliveryRepository.FindByWebhook")
+	}
+
+	return deliveries, nil
+}
+
+// Claim returns the oldest pending delivery due and hides it from other
+// workers for lease. It returns nil when nothing is due.
+func (r *webhookDeliveryRepository) Claim(ctx context.Context, lease time.Duration) (*entities.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(WebhookDeliveryCollectionName)
+
+	now := time.Now()
+	delivery := &entities.WebhookDelivery{}
+	err := collection.FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"status":        entities.DeliveryPending,
+			"nextAttemptAt": bson.M{"$lte": now},
+		},
+		bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease), "updatedAt": now}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "webhookDeliveryRepository.Claim")
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookDeliveryRepository) RecordAttempt(
+	ctx context.Context,
+	id string,
+	attempt *entities.DeliveryAttempt,
+	status string,
+	nextAttemptAt time.Time,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	collection := r.client.Database(r.db).Collection(WebhookDeliveryCollectionName)
+
+	_, err := collection.UpdateByID(
+		ctx,
+		id,
+		bson.M{
+			"$push": bson.M{"attempts": attempt},
+			"$set": bson.M{
+				"status":        status,
+				"nextAttemptAt": nextAttemptAt,
+				"updatedAt":     time.Now(),
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "webhookDeliveryRepository.RecordAttempt")
+	}
+
+	return nil
+}
@@ -2,23 +2,93 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
 )
 
+// AuthorRepository stores the authors. Search returns the authors whose first
+// name, last name, full name or one of the aliases contains query, ignoring
+// case, in the order of FindAll. SetPhoto sets the PhotoId of an author and
+// leaves their other fields alone; Update does not change it.
 type AuthorRepository interface {
 	Find(ctx context.Context, id string) (*entity.Author, error)
 	Store(ctx context.Context, author *entity.Author) error
 	Update(ctx context.Context, author *entity.Author) error
 	FindAll(ctx context.Context) ([]*entity.Author, error)
+	Search(ctx context.Context, query string) ([]*entity.Author, error)
+	SetPhoto(ctx context.Context, id, photoId string) error
 	Delete(ctx context.Context, id string) error
 }
 
+// BookRepository stores the books. ISBNs are unique: storing a book with the
+// ISBN-13 of another one fails with a conflict error. Books without ISBN have
+// an empty ISBN13. FindByCategories returns the books assigned to any of the
+// categories, and FindByPublisher the books of a publisher, in the order of
+// FindAll. Positions are unique within a series: storing a book at the
+// position of another one fails with a conflict error. FindBySeries returns
+// the books of a series in reading order. SetCover sets the CoverId of a book
+// and leaves its other fields alone; Update does not change it.
+// FindByOriginal returns the translations of a book in the order of FindAll.
+// Books and authors without translations may have nil Translations.
+// Tags are given normalized by payload.NormalizeTags: sorted and distinct.
+// FindByTags returns the books with any of the tags, or with all of them when
+// all is set, in the order of FindAll. AddTags and RemoveTags change the tags
+// of a book and leave its other fields alone, adding a tag it already has or
+// removing one it does not have is ignored. CountTags returns every tag with
+// its number of books, most used first. FindPage returns at most limit books
+// in the order of FindAll, starting after the book with the id after, or from
+// the first one when after is empty.
 type BookRepository interface {
 	Find(ctx context.Context, id string) (*entity.Book, error)
+	FindByISBN(ctx context.Context, isbn13 string) (*entity.Book, error)
+	FindByCategories(ctx context.Context, categoryIds []string) ([]*entity.Book, error)
+	FindByPublisher(ctx context.Context, publisherId string) ([]*entity.Book, error)
+	FindBySeries(ctx context.Context, seriesId string) ([]*entity.Book, error)
+	FindByOriginal(ctx context.Context, originalId string) ([]*entity.Book, error)
+	FindByTags(ctx context.Context, tags []string, all bool) ([]*entity.Book, error)
+	AddTags(ctx context.Context, id string, tags []string) error
+	RemoveTags(ctx context.Context, id string, tags []string) error
+	CountTags(ctx context.Context) ([]*entity.TagCount, error)
+	SetCover(ctx context.Context, id, coverId string) error
 	Store(ctx context.Context, author *entity.Book) (*entity.Book, error)
 	Update(ctx context.Context, author *entity.Book) error
 	FindAll(ctx context.Context) ([]*entity.Book, error)
+	FindPage(ctx context.Context, after string, limit int) ([]*entity.Book, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// BlobStore stores binary objects by key. Put replaces the object stored
+// under the key, Get fails with a not found error when there is none and
+// Delete ignores a missing key.
+type BlobStore interface {
+	Put(ctx context.Context, blob *entity.Blob) error
+	Get(ctx context.Context, key string) (*entity.Blob, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type PublisherRepository interface {
+	Find(ctx context.Context, id string) (*entity.Publisher, error)
+	Store(ctx context.Context, publisher *entity.Publisher) error
+	Update(ctx context.Context, publisher *entity.Publisher) error
+	FindAll(ctx context.Context) ([]*entity.Publisher, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type SeriesRepository interface {
+	Find(ctx context.Context, id string) (*entity.Series, error)
+	Store(ctx context.Context, series *entity.Series) error
+	Update(ctx context.Context, series *entity.Series) error
+	FindAll(ctx context.Context) ([]*entity.Series, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type CategoryRepository interface {
+	Find(ctx context.Context, id string) (*entity.Category, error)
+	Store(ctx context.Context, category *entity.Category) error
+	Update(ctx context.Context, category *entity.Category) error
+	FindAll(ctx context.Context) ([]*entity.Category, error)
 	Delete(ctx context.Context, id string) error
 }
 
@@ -27,7 +97,38 @@ type UserRepository interface {
 	Store(ctx context.Context, user *entity.User) error
 }
 
-type NotificationRepository interface {
-	Store(ctx context.Context, book *entity.Book)
-	AddAction(ctx context.Context, action string)
+// Transactor runs fn in a transaction. The repositories called with the
+// context passed to fn take part in the transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OutboxRepository stores the events to relay. Publish adds an event to the
+// outbox, within the transaction carried by ctx if any.
+type OutboxRepository interface {
+	event.Publisher
+	Claim(ctx context.Context, lease time.Duration) (*entity.OutboxMessage, error)
+	MarkDelivered(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error
+	MarkDead(ctx context.Context, id string, cause error) error
+}
+
+type WebhookRepository interface {
+	Find(ctx context.Context, id string) (*entity.Webhook, error)
+	Store(ctx context.Context, webhook *entity.Webhook) error
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	FindAll(ctx context.Context) ([]*entity.Webhook, error)
+	FindByEventType(ctx context.Context, eventType string) ([]*entity.Webhook, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookDeliveryRepository stores the deliveries to webhooks. Store ignores
+// a delivery whose ID already exists so that an event relayed twice is only
+// delivered once per webhook.
+type WebhookDeliveryRepository interface {
+	Find(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+	Store(ctx context.Context, delivery *entity.WebhookDelivery) error
+	FindByWebhook(ctx context.Context, webhookId string) ([]*entity.WebhookDelivery, error)
+	Claim(ctx context.Context, lease time.Duration) (*entity.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, id string, attempt *entity.DeliveryAttempt, status string, nextAttemptAt time.Time) error
 }
@@ -0,0 +1,1825 @@
+// Package repositorytest checks that a storage backend behaves like the
+// others. Every backend runs the same suite from its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+//			db := NewDB()
+//			return repositorytest.Repositories{...}
+//		})
+//	}
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"bookstore.com/test"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const invalidId = "invalid"
+
+// Repositories are the repositories under test. They share one store so that
+// books can be joined with their author, categories, publisher and series.
+type Repositories struct {
+	Author    repository.AuthorRepository
+	Book      repository.BookRepository
+	Category  repository.CategoryRepository
+	Publisher repository.PublisherRepository
+	Series    repository.SeriesRepository
+	User      repository.UserRepository
+}
+
+// Factory returns repositories over an empty store. It is called once per
+// test and registers its own cleanup with t.
+type Factory func(t *testing.T) Repositories
+
+// Run runs the conformance suite against the repositories made by
+// newRepositories.
+func Run(t *testing.T, newRepositories Factory) {
+	t.Run("Author", func(t *testing.T) { testAuthor(t, newRepositories(t)) })
+	t.Run("AuthorErrors", func(t *testing.T) { testAuthorErrors(t, newRepositories(t)) })
+	t.Run("AuthorOrder", func(t *testing.T) { testAuthorOrder(t, newRepositories(t)) })
+	t.Run("AuthorDetails", func(t *testing.T) { testAuthorDetails(t, newRepositories(t)) })
+	t.Run("AuthorSearch", func(t *testing.T) { testAuthorSearch(t, newRepositories(t)) })
+	t.Run("Book", func(t *testing.T) { testBook(t, newRepositories(t)) })
+	t.Run("BookErrors", func(t *testing.T) { testBookErrors(t, newRepositories(t)) })
+	t.Run("BookJoin", func(t *testing.T) { testBookJoin(t, newRepositories(t)) })
+	t.Run("BookOrder", func(t *testing.T) { testBookOrder(t, newRepositories(t)) })
+	t.Run("BookPaging", func(t *testing.T) { testBookPaging(t, newRepositories(t)) })
+	t.Run("BookISBN", func(t *testing.T) { testBookISBN(t, newRepositories(t)) })
+	t.Run("BookContributors", func(t *testing.T) { testBookContributors(t, newRepositories(t)) })
+	t.Run("Category", func(t *testing.T) { testCategory(t, newRepositories(t)) })
+	t.Run("BookCategories", func(t *testing.T) { testBookCategories(t, newRepositories(t)) })
+	t.Run("Publisher", func(t *testing.T) { testPublisher(t, newRepositories(t)) })
+	t.Run("BookPublisher", func(t *testing.T) { testBookPublisher(t, newRepositories(t)) })
+	t.Run("BookEditions", func(t *testing.T) { testBookEditions(t, newRepositories(t)) })
+	t.Run("Series", func(t *testing.T) { testSeries(t, newRepositories(t)) })
+	t.Run("BookSeries", func(t *testing.T) { testBookSeries(t, newRepositories(t)) })
+	t.Run("BookCover", func(t *testing.T) { testBookCover(t, newRepositories(t)) })
+	t.Run("AuthorTranslations", func(t *testing.T) { testAuthorTranslations(t, newRepositories(t)) })
+	t.Run("BookTranslations", func(t *testing.T) { testBookTranslations(t, newRepositories(t)) })
+	t.Run("BookTags", func(t *testing.T) { testBookTags(t, newRepositories(t)) })
+	t.Run("User", func(t *testing.T) { testUser(t, newRepositories(t)) })
+}
+
+func testAuthor(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	author := newAuthor(1)
+	if err := repos.Author.Store(ctx, author); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if author.Id == "" || author.CreatedAt.IsZero() || !sameTime(author.CreatedAt, author.UpdatedAt) {
+		t.Fatalf("Store() = %+v, want ID and timestamps set", author)
+	}
+
+	found, err := repos.Author.Find(ctx, author.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertAuthor(t, found, author)
+
+	author.FirstName = "updated firstname"
+	author.LastName = "updated lastname"
+	author.BirthDate = "1990-01-01"
+	author.Nationality = "France"
+	time.Sleep(2 * time.Millisecond)
+	if err := repos.Author.Update(ctx, author); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	found, err = repos.Author.Find(ctx, author.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	if found.FirstName != author.FirstName || found.LastName != author.LastName ||
+		found.BirthDate != author.BirthDate || found.Nationality != author.Nationality {
+		t.Errorf("Find() after Update() = %+v, want %+v", found, author)
+	}
+	if !sameTime(found.CreatedAt, author.CreatedAt) || !found.UpdatedAt.After(author.CreatedAt) {
+		t.Errorf("Update() timestamps = %v, %v, want createdAt kept and updatedAt moved", found.CreatedAt, found.UpdatedAt)
+	}
+
+	if err := repos.Author.Delete(ctx, author.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Author.Find(ctx, author.Id); status(err) != http.StatusNotFound {
+		t.Errorf("Find() after Delete() error = %v, want not found", err)
+	}
+}
+
+func testAuthorErrors(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	unknownId := primitive.NewObjectID().Hex()
+
+	if _, err := repos.Author.Find(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("Find(invalid) error = %v, want bad request", err)
+	}
+	if _, err := repos.Author.Find(ctx, unknownId); status(err) != http.StatusNotFound {
+		t.Errorf("Find(unknown) error = %v, want not found", err)
+	}
+	if err := repos.Author.Update(ctx, &entity.Author{Id: invalidId}); status(err) != http.StatusBadRequest {
+		t.Errorf("Update(invalid) error = %v, want bad request", err)
+	}
+	if err := repos.Author.Delete(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("Delete(invalid) error = %v, want bad request", err)
+	}
+
+	// The services look the author up first, so updating or deleting a
+	// missing one is a no-op rather than an error.
+	if err := repos.Author.Update(ctx, &entity.Author{Id: unknownId}); err != nil {
+		t.Errorf("Update(unknown) error = %v", err)
+	}
+	if err := repos.Author.Delete(ctx, unknownId); err != nil {
+		t.Errorf("Delete(unknown) error = %v", err)
+	}
+	if _, err := repos.Author.Find(ctx, unknownId); status(err) != http.StatusNotFound {
+		t.Errorf("Update(unknown) created the author, Find() error = %v", err)
+	}
+
+	authors, err := repos.Author.FindAll(ctx)
+	if err != nil || authors == nil || len(authors) != 0 {
+		t.Errorf("FindAll() on an empty store = %v, %v, want an empty list", authors, err)
+	}
+}
+
+func testAuthorOrder(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	var ids []string
+	for i := 1; i <= 5; i++ {
+		author := newAuthor(i)
+		if err := repos.Author.Store(ctx, author); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		ids = append(ids, author.Id)
+	}
+
+	// Updates must not move an author.
+	if err := repos.Author.Update(ctx, &entity.Author{Id: ids[0], FirstName: "updated"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repos.Author.Delete(ctx, ids[2]); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	want := []string{ids[0], ids[1], ids[3], ids[4]}
+
+	authors, err := repos.Author.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	var got []string
+	for _, author := range authors {
+		got = append(got, author.Id)
+	}
+	assertIds(t, got, want)
+}
+
+func testAuthorDetails(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	plain := storeAuthor(t, repos, 1)
+	found, err := repos.Author.Find(ctx, plain.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.DeathDate != "" || found.Biography != "" || len(found.Aliases) != 0 || found.Aliases == nil ||
+		found.ISNI != "" || found.VIAF != "" || found.PhotoId != "" {
+		t.Errorf("Find() details = %+v, want none", found)
+	}
+
+	author := newAuthor(2)
+	author.DeathDate = "2001-01-01"
+	author.Biography = "Wrote *many* books.\n\nAnd more."
+	author.Aliases = []string{"Pen Name", "Other Name"}
+	author.ISNI = "000000012281955X"
+	author.VIAF = "102333412"
+	if err := repos.Author.Store(ctx, author); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	found, err = repos.Author.Find(ctx, author.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertAuthorDetails(t, found, author)
+
+	if err := repos.Author.SetPhoto(ctx, author.Id, "photo-1"); err != nil {
+		t.Fatalf("SetPhoto() error = %v", err)
+	}
+
+	// Update replaces the details and leaves the photo alone.
+	author.DeathDate = ""
+	author.Biography = "Rewritten."
+	author.Aliases = []string{"Third Name"}
+	author.ISNI = ""
+	author.PhotoId = ""
+	if err := repos.Author.Update(ctx, author); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Author.Find(ctx, author.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	author.PhotoId = "photo-1"
+	assertAuthorDetails(t, found, author)
+
+	// Books carry the details of their author.
+	book := storeBook(t, repos, author.Id, 1)
+	foundBook, err := repos.Book.Find(ctx, book.Id)
+	if err != nil {
+		t.Fatalf("Book.Find() error = %v", err)
+	}
+	assertAuthorDetails(t, foundBook.Author, author)
+
+	if err := repos.Author.SetPhoto(ctx, invalidId, "photo-2"); status(err) != http.StatusBadRequest {
+		t.Errorf("SetPhoto() with an invalid ID error = %v, want bad request", err)
+	}
+}
+
+func testAuthorSearch(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	first := newAuthor(1)
+	first.FirstName = "Mary"
+	first.LastName = "Shelley"
+	first.Aliases = []string{"Mary Wollstonecraft", "M. W. S."}
+	if err := repos.Author.Store(ctx, first); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	second := newAuthor(2)
+	second.FirstName = "Samuel"
+	second.LastName = "Clemens"
+	second.Aliases = []string{"Mark Twain"}
+	if err := repos.Author.Store(ctx, second); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	third := newAuthor(3)
+	third.FirstName = "Émile"
+	third.LastName = "Zola"
+	if err := repos.Author.Store(ctx, third); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "shelley", want: []string{first.Id}},
+		{query: "MARY SHEL", want: []string{first.Id}},
+		{query: "twain", want: []string{second.Id}},
+		{query: "émile", want: []string{third.Id}},
+		{query: "ÉMILE ZOLA", want: []string{third.Id}},
+		{query: "ar", want: []string{first.Id, second.Id}},
+		{query: "Wollstonecraft\nM.", want: []string{}},
+		{query: "%", want: []string{}},
+		{query: "_", want: []string{}},
+		{query: "nobody", want: []string{}},
+	}
+	for _, tt := range tests {
+		authors, err := repos.Author.Search(ctx, tt.query)
+		if err != nil {
+			t.Fatalf("Search(%q) error = %v", tt.query, err)
+		}
+		ids := []string{}
+		for _, a := range authors {
+			ids = append(ids, a.Id)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, ids, tt.want)
+		}
+	}
+}
+
+func assertAuthorDetails(t *testing.T, got, want *entity.Author) {
+	t.Helper()
+
+	if got.DeathDate != want.DeathDate || got.Biography != want.Biography || !reflect.DeepEqual(got.Aliases, want.Aliases) ||
+		got.ISNI != want.ISNI || got.VIAF != want.VIAF || got.PhotoId != want.PhotoId {
+		t.Errorf("author details = %q %q %q %q %q %q, want %q %q %q %q %q %q",
+			got.DeathDate, got.Biography, got.Aliases, got.ISNI, got.VIAF, got.PhotoId,
+			want.DeathDate, want.Biography, want.Aliases, want.ISNI, want.VIAF, want.PhotoId)
+	}
+}
+
+func testBook(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+
+	book := newBook(author.Id, 1)
+	stored, err := repos.Book.Store(ctx, book)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if stored.Id == "" || stored.CreatedAt.IsZero() || stored.Name != book.Name || stored.AuthorId != author.Id {
+		t.Fatalf("Store() = %+v, want the book with ID and timestamps set", stored)
+	}
+
+	found, err := repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertBook(t, found, stored)
+
+	other := storeAuthor(t, repos, 2)
+	update := *stored
+	update.AuthorId = other.Id
+	update.Name = "updated name"
+	update.Description = "updated description"
+	update.PublicationDate = "2001-02-03"
+	update.Price = 42.5
+	time.Sleep(2 * time.Millisecond)
+	if err := repos.Book.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	if found.Name != update.Name || found.Description != update.Description ||
+		found.PublicationDate != update.PublicationDate || found.Price != update.Price {
+		t.Errorf("Find() after Update() = %+v, want %+v", found, update)
+	}
+	if found.AuthorId != other.Id || found.Author == nil || found.Author.Id != other.Id {
+		t.Errorf("Find() after Update() author = %v, %+v, want %s", found.AuthorId, found.Author, other.Id)
+	}
+	if !sameTime(found.CreatedAt, stored.CreatedAt) || !found.UpdatedAt.After(stored.CreatedAt) {
+		t.Errorf("Update() timestamps = %v, %v, want createdAt kept and updatedAt moved", found.CreatedAt, found.UpdatedAt)
+	}
+
+	if err := repos.Book.Delete(ctx, stored.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Book.Find(ctx, stored.Id); status(err) != http.StatusNotFound {
+		t.Errorf("Find() after Delete() error = %v, want not found", err)
+	}
+	if _, err := repos.Author.Find(ctx, other.Id); err != nil {
+		t.Errorf("Delete() of a book removed its author: %v", err)
+	}
+}
+
+func testBookErrors(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+	unknownId := primitive.NewObjectID().Hex()
+
+	if _, err := repos.Book.Find(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("Find(invalid) error = %v, want bad request", err)
+	}
+	if _, err := repos.Book.Find(ctx, unknownId); status(err) != http.StatusNotFound {
+		t.Errorf("Find(unknown) error = %v, want not found", err)
+	}
+	if _, err := repos.Book.Store(ctx, newBook(invalidId, 1)); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() with an invalid author ID error = %v, want bad request", err)
+	}
+	if err := repos.Book.Update(ctx, &entity.Book{Id: invalidId, AuthorId: author.Id}); status(err) != http.StatusBadRequest {
+		t.Errorf("Update(invalid) error = %v, want bad request", err)
+	}
+	if err := repos.Book.Update(ctx, &entity.Book{Id: unknownId, AuthorId: invalidId}); status(err) != http.StatusBadRequest {
+		t.Errorf("Update() with an invalid author ID error = %v, want bad request", err)
+	}
+	if err := repos.Book.Delete(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("Delete(invalid) error = %v, want bad request", err)
+	}
+
+	if err := repos.Book.Update(ctx, &entity.Book{Id: unknownId, AuthorId: author.Id}); err != nil {
+		t.Errorf("Update(unknown) error = %v", err)
+	}
+	if err := repos.Book.Delete(ctx, unknownId); err != nil {
+		t.Errorf("Delete(unknown) error = %v", err)
+	}
+
+	books, err := repos.Book.FindAll(ctx)
+	if err != nil || books == nil || len(books) != 0 {
+		t.Errorf("FindAll() on an empty store = %v, %v, want an empty list", books, err)
+	}
+}
+
+func testBookJoin(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := storeAuthor(t, repos, 1)
+	second := storeAuthor(t, repos, 2)
+
+	firstBook := storeBook(t, repos, first.Id, 1)
+	secondBook := storeBook(t, repos, second.Id, 2)
+
+	books, err := repos.Book.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(books) != 2 {
+		t.Fatalf("FindAll() = %d books, want 2", len(books))
+	}
+	for _, book := range books {
+		want := first
+		if book.Id == secondBook.Id {
+			want = second
+		}
+		if book.Author == nil {
+			t.Fatalf("FindAll() book %s has no author", book.Id)
+		}
+		assertAuthor(t, book.Author, want)
+	}
+
+	// The joined author follows its updates.
+	first.Nationality = "Japan"
+	if err := repos.Author.Update(ctx, first); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err := repos.Book.Find(ctx, firstBook.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.Author == nil || found.Author.Nationality != "Japan" {
+		t.Errorf("Find() author = %+v, want the updated author", found.Author)
+	}
+
+	// An author cannot be deleted while they have books.
+	if err := repos.Author.Delete(ctx, first.Id); status(err) != http.StatusConflict {
+		t.Errorf("Delete() of an author with books error = %v, want conflict", err)
+	}
+	if _, err := repos.Book.Find(ctx, firstBook.Id); err != nil {
+		t.Errorf("Find() after deleting its author failed error = %v", err)
+	}
+
+	if err := repos.Book.Delete(ctx, firstBook.Id); err != nil {
+		t.Fatalf("Book.Delete() error = %v", err)
+	}
+	if err := repos.Author.Delete(ctx, first.Id); err != nil {
+		t.Fatalf("Delete() of an author without books error = %v", err)
+	}
+	books, err = repos.Book.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(books) != 1 || books[0].Id != secondBook.Id {
+		t.Errorf("FindAll() after deleting an author = %v, want only %s", books, secondBook.Id)
+	}
+}
+
+func testBookOrder(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := storeAuthor(t, repos, 1)
+	second := storeAuthor(t, repos, 2)
+
+	var ids []string
+	for i := 1; i <= 6; i++ {
+		authorId := first.Id
+		if i%2 == 0 {
+			authorId = second.Id
+		}
+		ids = append(ids, storeBook(t, repos, authorId, i).Id)
+	}
+
+	// Books are listed in the order they were added, whatever their author.
+	if err := repos.Book.Update(ctx, &entity.Book{Id: ids[0], AuthorId: second.Id, Name: "moved"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repos.Book.Delete(ctx, ids[3]); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	want := []string{ids[0], ids[1], ids[2], ids[4], ids[5]}
+
+	books, err := repos.Book.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	var got []string
+	for _, book := range books {
+		got = append(got, book.Id)
+	}
+	assertIds(t, got, want)
+}
+
+func testBookPaging(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := storeAuthor(t, repos, 1)
+	second := storeAuthor(t, repos, 2)
+
+	if books, err := repos.Book.FindPage(ctx, "", 2); err != nil || books == nil || len(books) != 0 {
+		t.Errorf("FindPage() on an empty store = %v, %v, want an empty list", books, err)
+	}
+	if _, err := repos.Book.FindPage(ctx, invalidId, 2); status(err) != http.StatusBadRequest {
+		t.Errorf("FindPage(invalid) error = %v, want bad request", err)
+	}
+
+	var ids []string
+	for i := 1; i <= 6; i++ {
+		authorId := first.Id
+		if i%2 == 0 {
+			authorId = second.Id
+		}
+		ids = append(ids, storeBook(t, repos, authorId, i).Id)
+	}
+	if err := repos.Book.Delete(ctx, ids[2]); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// Walking the pages lists every book of FindAll once, joined like it.
+	all, err := repos.Book.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	var want []string
+	for _, book := range all {
+		want = append(want, book.Id)
+	}
+
+	var got []string
+	var sizes []int
+	after := ""
+	for {
+		books, err := repos.Book.FindPage(ctx, after, 2)
+		if err != nil {
+			t.Fatalf("FindPage(%q) error = %v", after, err)
+		}
+		if len(books) == 0 {
+			break
+		}
+		if len(sizes) == len(want) {
+			t.Fatalf("FindPage() does not end, got %v", got)
+		}
+		sizes = append(sizes, len(books))
+		for _, book := range books {
+			if book.Author == nil || book.Author.Id != book.AuthorId {
+				t.Errorf("FindPage() book %s author = %+v, want %s", book.Id, book.Author, book.AuthorId)
+			}
+			got = append(got, book.Id)
+		}
+		after = books[len(books)-1].Id
+	}
+	assertIds(t, got, want)
+	if len(sizes) != 3 || sizes[0] != 2 || sizes[1] != 2 || sizes[2] != 1 {
+		t.Errorf("FindPage() page sizes = %v, want [2 2 1]", sizes)
+	}
+
+	// A page may start after a deleted book.
+	books, err := repos.Book.FindPage(ctx, ids[2], 10)
+	if err != nil {
+		t.Fatalf("FindPage() after a deleted book error = %v", err)
+	}
+	got = nil
+	for _, book := range books {
+		got = append(got, book.Id)
+	}
+	assertIds(t, got, []string{ids[3], ids[4], ids[5]})
+}
+
+func testBookISBN(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+
+	book := newBook(author.Id, 1)
+	book.ISBN10 = test.ISBN10_1
+	book.ISBN13 = test.ISBN13_1
+	stored, err := repos.Book.Store(ctx, book)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	// Books without ISBN do not collide.
+	withoutISBN := storeBook(t, repos, author.Id, 2)
+	storeBook(t, repos, author.Id, 3)
+
+	found, err := repos.Book.FindByISBN(ctx, test.ISBN13_1)
+	if err != nil {
+		t.Fatalf("FindByISBN() error = %v", err)
+	}
+	assertBook(t, found, stored)
+	if found.ISBN10 != test.ISBN10_1 || found.ISBN13 != test.ISBN13_1 {
+		t.Errorf("FindByISBN() ISBNs = %s, %s", found.ISBN10, found.ISBN13)
+	}
+
+	if _, err := repos.Book.FindByISBN(ctx, "9791090636071"); status(err) != http.StatusNotFound {
+		t.Errorf("FindByISBN(unknown) error = %v, want not found", err)
+	}
+	if _, err := repos.Book.FindByISBN(ctx, ""); status(err) != http.StatusNotFound {
+		t.Errorf("FindByISBN(empty) error = %v, want not found", err)
+	}
+
+	duplicate := newBook(author.Id, 4)
+	duplicate.ISBN13 = test.ISBN13_1
+	if _, err := repos.Book.Store(ctx, duplicate); status(err) != http.StatusConflict {
+		t.Errorf("Store() of a duplicate ISBN error = %v, want conflict", err)
+	}
+
+	update := *withoutISBN
+	update.ISBN13 = test.ISBN13_1
+	if err := repos.Book.Update(ctx, &update); status(err) != http.StatusConflict {
+		t.Errorf("Update() to a duplicate ISBN error = %v, want conflict", err)
+	}
+
+	// A book keeps its own ISBN on update.
+	stored.Name = "updated name"
+	if err := repos.Book.Update(ctx, stored); err != nil {
+		t.Errorf("Update() keeping the ISBN error = %v", err)
+	}
+
+	books, err := repos.Book.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(books) != 3 {
+		t.Errorf("FindAll() = %d books, want 3", len(books))
+	}
+
+	// The ISBN of a book is kept while its author cannot be deleted, and is
+	// free again once the book is.
+	if err := repos.Author.Delete(ctx, author.Id); status(err) != http.StatusConflict {
+		t.Errorf("Delete() of the author error = %v, want conflict", err)
+	}
+	other := storeAuthor(t, repos, 2)
+	reused := newBook(other.Id, 5)
+	reused.ISBN13 = test.ISBN13_1
+	if _, err := repos.Book.Store(ctx, reused); status(err) != http.StatusConflict {
+		t.Errorf("Store() of the ISBN of a book whose author was kept error = %v, want conflict", err)
+	}
+
+	for _, book := range books {
+		if err := repos.Book.Delete(ctx, book.Id); err != nil {
+			t.Fatalf("Book.Delete() error = %v", err)
+		}
+	}
+	if err := repos.Author.Delete(ctx, author.Id); err != nil {
+		t.Fatalf("Delete() of the author error = %v", err)
+	}
+	if _, err := repos.Book.Store(ctx, reused); err != nil {
+		t.Errorf("Store() of the ISBN of a deleted book error = %v", err)
+	}
+}
+
+func testBookContributors(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := storeAuthor(t, repos, 1)
+	translator := storeAuthor(t, repos, 2)
+	illustrator := storeAuthor(t, repos, 3)
+
+	// A book without contributors has its author as only contributor.
+	single := storeBook(t, repos, first.Id, 1)
+	found, err := repos.Book.Find(ctx, single.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertContributors(t, found.Contributors, []*entity.Contributor{
+		{AuthorId: first.Id, Role: entity.ContributorAuthor},
+	})
+
+	book := newBook(first.Id, 2)
+	book.Contributors = []*entity.Contributor{
+		{AuthorId: first.Id, Role: entity.ContributorAuthor},
+		{AuthorId: translator.Id, Role: entity.ContributorTranslator},
+		{AuthorId: illustrator.Id, Role: entity.ContributorIllustrator},
+		{AuthorId: first.Id, Role: entity.ContributorIllustrator},
+	}
+	stored, err := repos.Book.Store(ctx, book)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertContributors(t, found.Contributors, book.Contributors)
+	if found.Author == nil || found.Author.Id != first.Id {
+		t.Errorf("Find() author = %+v, want the first contributor", found.Author)
+	}
+	if found.Contributors[1].Author == nil || found.Contributors[1].Author.LastName != translator.LastName {
+		t.Errorf("Find() contributor author = %+v, want %+v", found.Contributors[1].Author, translator)
+	}
+
+	books, err := repos.Book.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(books) != 2 {
+		t.Fatalf("FindAll() = %d books, want 2", len(books))
+	}
+	assertContributors(t, books[1].Contributors, book.Contributors)
+
+	// Update replaces the contributors.
+	update := *stored
+	update.AuthorId = translator.Id
+	update.Contributors = []*entity.Contributor{
+		{AuthorId: translator.Id, Role: entity.ContributorEditor},
+		{AuthorId: first.Id, Role: entity.ContributorAuthor},
+	}
+	if err := repos.Book.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	assertContributors(t, found.Contributors, update.Contributors)
+
+	// Deleting a contributor leaves the book without them.
+	if err := repos.Book.Delete(ctx, single.Id); err != nil {
+		t.Fatalf("Book.Delete() error = %v", err)
+	}
+	if err := repos.Author.Delete(ctx, first.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() after deleting a contributor error = %v", err)
+	}
+	assertContributors(t, found.Contributors, update.Contributors[:1])
+
+	invalid := newBook(first.Id, 3)
+	invalid.Contributors = []*entity.Contributor{{AuthorId: invalidId, Role: entity.ContributorAuthor}}
+	if _, err := repos.Book.Store(ctx, invalid); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() with an invalid contributor ID error = %v, want bad request", err)
+	}
+}
+
+func testCategory(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	root := storeCategory(t, repos, "", 1)
+	child := storeCategory(t, repos, root.Id, 2)
+
+	found, err := repos.Category.Find(ctx, child.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.Name != child.Name || found.ParentId != root.Id || !sameTime(found.CreatedAt, child.CreatedAt) {
+		t.Errorf("Find() = %+v, want %+v", found, child)
+	}
+
+	found, err = repos.Category.Find(ctx, root.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.ParentId != "" {
+		t.Errorf("Find() parent = %q, want a top level category", found.ParentId)
+	}
+
+	// Update moves the child to the top level.
+	update := *child
+	update.Name = test.CategoryName1 + " updated"
+	update.ParentId = ""
+	if err := repos.Category.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Category.Find(ctx, child.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	if found.Name != update.Name || found.ParentId != "" {
+		t.Errorf("Find() after Update() = %+v, want %+v", found, update)
+	}
+
+	categories, err := repos.Category.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	ids := []string{}
+	for _, category := range categories {
+		ids = append(ids, category.Id)
+	}
+	assertIds(t, ids, []string{root.Id, child.Id})
+
+	if err := repos.Category.Delete(ctx, child.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Category.Find(ctx, child.Id); status(err) != http.StatusNotFound {
+		t.Errorf("Find() after Delete() error = %v, want not found", err)
+	}
+
+	if _, err := repos.Category.Find(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("Find() with an invalid ID error = %v, want bad request", err)
+	}
+	invalid := &entity.Category{Name: test.CategoryName1, ParentId: invalidId}
+	if err := repos.Category.Store(ctx, invalid); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() with an invalid parent ID error = %v, want bad request", err)
+	}
+}
+
+func testBookCategories(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+	fiction := storeCategory(t, repos, "", 1)
+	poetry := storeCategory(t, repos, "", 2)
+	history := storeCategory(t, repos, "", 3)
+
+	uncategorized := storeBook(t, repos, author.Id, 1)
+	found, err := repos.Book.Find(ctx, uncategorized.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(found.CategoryIds) != 0 {
+		t.Errorf("Find() categoryIds = %v, want none", found.CategoryIds)
+	}
+
+	book := newBook(author.Id, 2)
+	book.CategoryIds = []string{fiction.Id, poetry.Id}
+	stored, err := repos.Book.Store(ctx, book)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertCategoryIds(t, found.CategoryIds, book.CategoryIds)
+
+	other := newBook(author.Id, 3)
+	other.CategoryIds = []string{poetry.Id}
+	otherStored, err := repos.Book.Store(ctx, other)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	books, err := repos.Book.FindByCategories(ctx, []string{poetry.Id, history.Id})
+	if err != nil {
+		t.Fatalf("FindByCategories() error = %v", err)
+	}
+	ids := []string{}
+	for _, b := range books {
+		ids = append(ids, b.Id)
+	}
+	assertIds(t, ids, []string{stored.Id, otherStored.Id})
+	if books[0].Author == nil || books[0].Author.Id != author.Id {
+		t.Errorf("FindByCategories() author = %+v, want %s", books[0].Author, author.Id)
+	}
+	assertCategoryIds(t, books[0].CategoryIds, book.CategoryIds)
+
+	books, err = repos.Book.FindByCategories(ctx, []string{history.Id})
+	if err != nil {
+		t.Fatalf("FindByCategories() error = %v", err)
+	}
+	if len(books) != 0 {
+		t.Errorf("FindByCategories() = %d books, want 0", len(books))
+	}
+
+	// Update replaces the categories.
+	update := *stored
+	update.CategoryIds = []string{history.Id}
+	if err := repos.Book.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	assertCategoryIds(t, found.CategoryIds, update.CategoryIds)
+
+	books, err = repos.Book.FindByCategories(ctx, []string{fiction.Id})
+	if err != nil {
+		t.Fatalf("FindByCategories() error = %v", err)
+	}
+	if len(books) != 0 {
+		t.Errorf("FindByCategories() after Update() = %d books, want 0", len(books))
+	}
+
+	invalid := newBook(author.Id, 4)
+	invalid.CategoryIds = []string{invalidId}
+	if _, err := repos.Book.Store(ctx, invalid); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() with an invalid category ID error = %v, want bad request", err)
+	}
+	if _, err := repos.Book.FindByCategories(ctx, []string{invalidId}); status(err) != http.StatusBadRequest {
+		t.Errorf("FindByCategories() with an invalid ID error = %v, want bad request", err)
+	}
+}
+
+func testPublisher(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	publisher := storePublisher(t, repos, 1)
+	other := storePublisher(t, repos, 2)
+
+	found, err := repos.Publisher.Find(ctx, publisher.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertPublisher(t, found, publisher)
+	if !sameTime(found.CreatedAt, publisher.CreatedAt) {
+		t.Errorf("Find() createdAt = %v, want %v", found.CreatedAt, publisher.CreatedAt)
+	}
+
+	update := *publisher
+	update.Name = test.PublisherName1 + " updated"
+	update.Website = ""
+	if err := repos.Publisher.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Publisher.Find(ctx, publisher.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	assertPublisher(t, found, &update)
+
+	publishers, err := repos.Publisher.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	ids := []string{}
+	for _, p := range publishers {
+		ids = append(ids, p.Id)
+	}
+	assertIds(t, ids, []string{publisher.Id, other.Id})
+
+	if err := repos.Publisher.Delete(ctx, other.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Publisher.Find(ctx, other.Id); status(err) != http.StatusNotFound {
+		t.Errorf("Find() after Delete() error = %v, want not found", err)
+	}
+	if _, err := repos.Publisher.Find(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("Find() with an invalid ID error = %v, want bad request", err)
+	}
+}
+
+func testBookPublisher(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+	publisher := storePublisher(t, repos, 1)
+	other := storePublisher(t, repos, 2)
+
+	unpublished := storeBook(t, repos, author.Id, 1)
+	found, err := repos.Book.Find(ctx, unpublished.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.PublisherId != "" || found.Publisher != nil {
+		t.Errorf("Find() publisher = %q %+v, want none", found.PublisherId, found.Publisher)
+	}
+
+	book := newBook(author.Id, 2)
+	book.PublisherId = publisher.Id
+	stored, err := repos.Book.Store(ctx, book)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.PublisherId != publisher.Id || found.Publisher == nil {
+		t.Fatalf("Find() publisher = %q %+v, want %s", found.PublisherId, found.Publisher, publisher.Id)
+	}
+	assertPublisher(t, found.Publisher, publisher)
+
+	books, err := repos.Book.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(books) != 2 || books[1].Publisher == nil || books[1].Publisher.Id != publisher.Id {
+		t.Errorf("FindAll() = %+v, want the second book joined with its publisher", books)
+	}
+
+	books, err = repos.Book.FindByPublisher(ctx, publisher.Id)
+	if err != nil {
+		t.Fatalf("FindByPublisher() error = %v", err)
+	}
+	if len(books) != 1 || books[0].Id != stored.Id {
+		t.Errorf("FindByPublisher() = %+v, want %s", books, stored.Id)
+	}
+
+	// Update moves the book to the other publisher.
+	update := *stored
+	update.PublisherId = other.Id
+	if err := repos.Book.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	if found.Publisher == nil || found.Publisher.Id != other.Id {
+		t.Errorf("Find() after Update() publisher = %+v, want %s", found.Publisher, other.Id)
+	}
+	books, err = repos.Book.FindByPublisher(ctx, publisher.Id)
+	if err != nil {
+		t.Fatalf("FindByPublisher() error = %v", err)
+	}
+	if len(books) != 0 {
+		t.Errorf("FindByPublisher() after Update() = %d books, want 0", len(books))
+	}
+
+	invalid := newBook(author.Id, 3)
+	invalid.PublisherId = invalidId
+	if _, err := repos.Book.Store(ctx, invalid); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() with an invalid publisher ID error = %v, want bad request", err)
+	}
+	if _, err := repos.Book.FindByPublisher(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("FindByPublisher() with an invalid ID error = %v, want bad request", err)
+	}
+}
+
+func testBookEditions(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+
+	unpublished := storeBook(t, repos, author.Id, 1)
+	found, err := repos.Book.Find(ctx, unpublished.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.Editions == nil || len(found.Editions) != 0 {
+		t.Errorf("Find() editions = %+v, want empty", found.Editions)
+	}
+
+	book := newBook(author.Id, 2)
+	book.Editions = []*entity.Edition{
+		{Format: entity.FormatHardcover, ISBN10: test.ISBN10_1, ISBN13: test.ISBN13_1, PageCount: 320, Price: 30, PublicationDate: "2001-01-01"},
+		{Format: entity.FormatAudiobook, DurationMinutes: 540, Price: 20, PublicationDate: "2002-01-01"},
+		{Format: entity.FormatEbook, PageCount: 300, Price: 10, PublicationDate: "2003-01-01"},
+	}
+	stored, err := repos.Book.Store(ctx, book)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if len(stored.Editions) != 3 {
+		t.Fatalf("Store() editions = %+v, want 3", stored.Editions)
+	}
+	for i, e := range stored.Editions {
+		if e.Id == "" {
+			t.Errorf("Store() edition %d has no ID", i)
+		}
+	}
+
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertEditions(t, found.Editions, stored.Editions)
+
+	// An edition ISBN finds its book.
+	found, err = repos.Book.FindByISBN(ctx, test.ISBN13_1)
+	if err != nil {
+		t.Fatalf("FindByISBN() error = %v", err)
+	}
+	if found.Id != stored.Id {
+		t.Errorf("FindByISBN() = %s, want %s", found.Id, stored.Id)
+	}
+
+	// Edition ISBNs are unique across books and editions.
+	duplicate := newBook(author.Id, 3)
+	duplicate.ISBN13 = test.ISBN13_1
+	if _, err := repos.Book.Store(ctx, duplicate); status(err) != http.StatusConflict {
+		t.Errorf("Store() of a book with an edition ISBN error = %v, want conflict", err)
+	}
+	duplicate.ISBN13 = ""
+	duplicate.Editions = []*entity.Edition{{Format: entity.FormatPaperback, ISBN13: test.ISBN13_1, PageCount: 1, Price: 1, PublicationDate: "2001-01-01"}}
+	if _, err := repos.Book.Store(ctx, duplicate); status(err) != http.StatusConflict {
+		t.Errorf("Store() of a duplicate edition ISBN error = %v, want conflict", err)
+	}
+
+	// Editions without ISBN do not collide across books, even next to one
+	// with an ISBN.
+	other := newBook(author.Id, 5)
+	other.Editions = []*entity.Edition{
+		{Format: entity.FormatHardcover, ISBN13: test.ISBN13_2, PageCount: 200, Price: 25, PublicationDate: "2001-01-01"},
+		{Format: entity.FormatEbook, PageCount: 200, Price: 5, PublicationDate: "2001-01-01"},
+	}
+	otherStored, err := repos.Book.Store(ctx, other)
+	if err != nil {
+		t.Fatalf("Store() of a second book with an edition without ISBN error = %v", err)
+	}
+	if err := repos.Book.Update(ctx, otherStored); err != nil {
+		t.Errorf("Update() of a second book with an edition without ISBN error = %v", err)
+	}
+
+	// Update keeps the IDs of the editions it is given and replaces the others.
+	update := *stored
+	update.Editions = []*entity.Edition{
+		stored.Editions[2],
+		stored.Editions[0],
+		{Format: entity.FormatPaperback, PageCount: 310, Price: 15, PublicationDate: "2004-01-01"},
+	}
+	if err := repos.Book.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	if len(found.Editions) != 3 {
+		t.Fatalf("Find() after Update() editions = %+v, want 3", found.Editions)
+	}
+	assertEditions(t, found.Editions[:2], update.Editions[:2])
+	if found.Editions[2].Id == "" || found.Editions[2].Format != entity.FormatPaperback {
+		t.Errorf("Find() after Update() new edition = %+v", found.Editions[2])
+	}
+
+	invalid := newBook(author.Id, 4)
+	invalid.Editions = []*entity.Edition{{Id: invalidId, Format: entity.FormatEbook, PageCount: 1, Price: 1, PublicationDate: "2001-01-01"}}
+	if _, err := repos.Book.Store(ctx, invalid); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() with an invalid edition ID error = %v, want bad request", err)
+	}
+}
+
+func assertEditions(t *testing.T, got, want []*entity.Edition) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("editions = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Errorf("edition %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func testSeries(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	series := storeSeries(t, repos, 1)
+	other := storeSeries(t, repos, 2)
+
+	found, err := repos.Series.Find(ctx, series.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertSeries(t, found, series)
+	if !sameTime(found.CreatedAt, series.CreatedAt) {
+		t.Errorf("Find() createdAt = %v, want %v", found.CreatedAt, series.CreatedAt)
+	}
+
+	update := *series
+	update.Name = test.SeriesName1 + " updated"
+	update.Description = ""
+	if err := repos.Series.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Series.Find(ctx, series.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	assertSeries(t, found, &update)
+
+	list, err := repos.Series.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	ids := []string{}
+	for _, s := range list {
+		ids = append(ids, s.Id)
+	}
+	assertIds(t, ids, []string{series.Id, other.Id})
+
+	if err := repos.Series.Delete(ctx, other.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Series.Find(ctx, other.Id); status(err) != http.StatusNotFound {
+		t.Errorf("Find() after Delete() error = %v, want not found", err)
+	}
+	if _, err := repos.Series.Find(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("Find() with an invalid ID error = %v, want bad request", err)
+	}
+}
+
+func testBookSeries(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+	series := storeSeries(t, repos, 1)
+	other := storeSeries(t, repos, 2)
+
+	standalone := storeBook(t, repos, author.Id, 1)
+	found, err := repos.Book.Find(ctx, standalone.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.SeriesId != "" || found.SeriesPosition != 0 {
+		t.Errorf("Find() series = %q %d, want none", found.SeriesId, found.SeriesPosition)
+	}
+
+	// Stored out of order, listed in reading order.
+	want := map[int]*entity.Book{}
+	for i, position := range []int{3, 1, 2} {
+		book := newBook(author.Id, 2+i)
+		book.SeriesId = series.Id
+		book.SeriesPosition = position
+		stored, err := repos.Book.Store(ctx, book)
+		if err != nil {
+			t.Fatalf("Store() at position %d error = %v", position, err)
+		}
+		want[position] = stored
+	}
+
+	books, err := repos.Book.FindBySeries(ctx, series.Id)
+	if err != nil {
+		t.Fatalf("FindBySeries() error = %v", err)
+	}
+	ids := []string{}
+	for _, b := range books {
+		ids = append(ids, b.Id)
+	}
+	assertIds(t, ids, []string{want[1].Id, want[2].Id, want[3].Id})
+	if books[0].SeriesId != series.Id || books[0].SeriesPosition != 1 {
+		t.Errorf("FindBySeries() first book series = %q %d", books[0].SeriesId, books[0].SeriesPosition)
+	}
+
+	// Positions are unique within a series, not across series.
+	duplicate := newBook(author.Id, 5)
+	duplicate.SeriesId = series.Id
+	duplicate.SeriesPosition = 2
+	if _, err := repos.Book.Store(ctx, duplicate); status(err) != http.StatusConflict {
+		t.Errorf("Store() at a taken position error = %v, want conflict", err)
+	}
+	duplicate.SeriesId = other.Id
+	if _, err := repos.Book.Store(ctx, duplicate); err != nil {
+		t.Errorf("Store() at the same position in another series error = %v", err)
+	}
+
+	update := *want[3]
+	update.SeriesPosition = 1
+	if err := repos.Book.Update(ctx, &update); status(err) != http.StatusConflict {
+		t.Errorf("Update() to a taken position error = %v, want conflict", err)
+	}
+
+	// A book keeps its own position on update, and can move to a free one.
+	update.SeriesPosition = 3
+	if err := repos.Book.Update(ctx, &update); err != nil {
+		t.Errorf("Update() keeping the position error = %v", err)
+	}
+	update.SeriesPosition = 4
+	if err := repos.Book.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() to a free position error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, update.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	if found.SeriesPosition != 4 {
+		t.Errorf("Find() after Update() position = %d, want 4", found.SeriesPosition)
+	}
+
+	invalid := newBook(author.Id, 6)
+	invalid.SeriesId = invalidId
+	invalid.SeriesPosition = 1
+	if _, err := repos.Book.Store(ctx, invalid); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() with an invalid series ID error = %v, want bad request", err)
+	}
+	if _, err := repos.Book.FindBySeries(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("FindBySeries() with an invalid ID error = %v, want bad request", err)
+	}
+}
+
+func testBookCover(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+	book := storeBook(t, repos, author.Id, 1)
+
+	found, err := repos.Book.Find(ctx, book.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.CoverId != "" {
+		t.Errorf("Find() coverId = %q, want none", found.CoverId)
+	}
+
+	if err := repos.Book.SetCover(ctx, book.Id, "cover-1"); err != nil {
+		t.Fatalf("SetCover() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, book.Id)
+	if err != nil {
+		t.Fatalf("Find() after SetCover() error = %v", err)
+	}
+	if found.CoverId != "cover-1" {
+		t.Errorf("Find() after SetCover() coverId = %q, want %q", found.CoverId, "cover-1")
+	}
+
+	// Update leaves the cover alone.
+	update := *found
+	update.CoverId = ""
+	update.Name = "renamed"
+	if err := repos.Book.Update(ctx, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, book.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	if found.CoverId != "cover-1" || found.Name != "renamed" {
+		t.Errorf("Find() after Update() = %q %q, want %q %q", found.CoverId, found.Name, "cover-1", "renamed")
+	}
+
+	if err := repos.Book.SetCover(ctx, invalidId, "cover-2"); status(err) != http.StatusBadRequest {
+		t.Errorf("SetCover() with an invalid ID error = %v, want bad request", err)
+	}
+}
+
+func testAuthorTranslations(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	plain := storeAuthor(t, repos, 1)
+	found, err := repos.Author.Find(ctx, plain.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(found.Translations) != 0 {
+		t.Errorf("Find() translations = %v, want none", found.Translations)
+	}
+
+	author := newAuthor(2)
+	author.Translations = map[string]*entity.AuthorTranslation{
+		"ru":      {FirstName: "Лев", LastName: "Толстой"},
+		"zh-Hant": {FirstName: "列夫", LastName: "托爾斯泰"},
+	}
+	if err := repos.Author.Store(ctx, author); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	found, err = repos.Author.Find(ctx, author.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertTranslations(t, found.Translations, author.Translations)
+
+	author.Translations = map[string]*entity.AuthorTranslation{"fr": {FirstName: "Léon", LastName: "Tolstoï"}}
+	if err := repos.Author.Update(ctx, author); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Author.Find(ctx, author.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	assertTranslations(t, found.Translations, author.Translations)
+
+	// Books carry the translations of their authors.
+	book := storeBook(t, repos, author.Id, 1)
+	foundBook, err := repos.Book.Find(ctx, book.Id)
+	if err != nil {
+		t.Fatalf("Book.Find() error = %v", err)
+	}
+	assertTranslations(t, foundBook.Author.Translations, author.Translations)
+	assertTranslations(t, foundBook.Contributors[0].Author.Translations, author.Translations)
+}
+
+func testBookTranslations(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+
+	original := storeBook(t, repos, author.Id, 1)
+	found, err := repos.Book.Find(ctx, original.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.Language != "" || found.OriginalId != "" || len(found.Translations) != 0 {
+		t.Errorf("Find() = %q %q %v, want no language", found.Language, found.OriginalId, found.Translations)
+	}
+
+	original.Language = "en"
+	original.Translations = map[string]*entity.BookTranslation{
+		"de":    {Name: "Der Name", Description: "Die Beschreibung"},
+		"pt-BR": {Name: "O nome", Description: "A descrição"},
+	}
+	if err := repos.Book.Update(ctx, original); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, original.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	if found.Language != "en" || found.OriginalId != "" {
+		t.Errorf("Find() after Update() = %q %q, want %q and no original", found.Language, found.OriginalId, "en")
+	}
+	assertTranslations(t, found.Translations, original.Translations)
+
+	translation := newBook(author.Id, 2)
+	translation.Language = "fr"
+	translation.OriginalId = original.Id
+	translation.Translations = map[string]*entity.BookTranslation{"it": {Name: "Il nome", Description: "La descrizione"}}
+	stored, err := repos.Book.Store(ctx, translation)
+	if err != nil {
+		t.Fatalf("Store() translation error = %v", err)
+	}
+	assertTranslations(t, stored.Translations, translation.Translations)
+	storeBook(t, repos, author.Id, 3)
+
+	found, err = repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() translation error = %v", err)
+	}
+	if found.Language != "fr" || found.OriginalId != original.Id {
+		t.Errorf("Find() translation = %q %q, want %q %q", found.Language, found.OriginalId, "fr", original.Id)
+	}
+	assertTranslations(t, found.Translations, translation.Translations)
+
+	books, err := repos.Book.FindByOriginal(ctx, original.Id)
+	if err != nil {
+		t.Fatalf("FindByOriginal() error = %v", err)
+	}
+	if len(books) != 1 || books[0].Id != stored.Id || books[0].Author == nil {
+		t.Errorf("FindByOriginal() = %v, want the translation with its author", books)
+	}
+
+	books, err = repos.Book.FindByOriginal(ctx, stored.Id)
+	if err != nil || len(books) != 0 {
+		t.Errorf("FindByOriginal() of a book without translations = %v, %v, want none", books, err)
+	}
+
+	// Update unlinks the translation from its original.
+	found.OriginalId = ""
+	if err := repos.Book.Update(ctx, found); err != nil {
+		t.Fatalf("Update() translation error = %v", err)
+	}
+	books, err = repos.Book.FindByOriginal(ctx, original.Id)
+	if err != nil || len(books) != 0 {
+		t.Errorf("FindByOriginal() after unlinking = %v, %v, want none", books, err)
+	}
+
+	if _, err := repos.Book.FindByOriginal(ctx, invalidId); status(err) != http.StatusBadRequest {
+		t.Errorf("FindByOriginal() with an invalid ID error = %v, want bad request", err)
+	}
+
+	invalid := newBook(author.Id, 4)
+	invalid.OriginalId = invalidId
+	if _, err := repos.Book.Store(ctx, invalid); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() with an invalid original ID error = %v, want bad request", err)
+	}
+}
+
+func testBookTags(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	author := storeAuthor(t, repos, 1)
+
+	book := newBook(author.Id, 1)
+	book.Tags = []string{"staff-pick", "summer-2026"}
+	stored, err := repos.Book.Store(ctx, book)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	assertTags(t, stored.Tags, []string{"staff-pick", "summer-2026"})
+
+	found, err := repos.Book.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertTags(t, found.Tags, []string{"staff-pick", "summer-2026"})
+
+	other := storeBook(t, repos, author.Id, 2)
+	if found, _ := repos.Book.Find(ctx, other.Id); found == nil || found.Tags == nil || len(found.Tags) != 0 {
+		t.Errorf("Find() of a book without tags = %v, want empty tags", found)
+	}
+
+	if err := repos.Book.AddTags(ctx, other.Id, []string{"staff-pick"}); err != nil {
+		t.Fatalf("AddTags() error = %v", err)
+	}
+	if err := repos.Book.AddTags(ctx, other.Id, []string{"award", "staff-pick"}); err != nil {
+		t.Fatalf("AddTags() with a tag the book has error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, other.Id)
+	if err != nil {
+		t.Fatalf("Find() after AddTags() error = %v", err)
+	}
+	assertTags(t, found.Tags, []string{"award", "staff-pick"})
+	if found.Name != other.Name || found.Author == nil {
+		t.Errorf("AddTags() changed the book: %+v", found)
+	}
+
+	third := storeBook(t, repos, author.Id, 3)
+	if err := repos.Book.AddTags(ctx, third.Id, []string{"award"}); err != nil {
+		t.Fatalf("AddTags() error = %v", err)
+	}
+
+	books, err := repos.Book.FindByTags(ctx, []string{"award", "staff-pick"}, false)
+	if err != nil {
+		t.Fatalf("FindByTags() any error = %v", err)
+	}
+	assertIds(t, bookIds(books), []string{stored.Id, other.Id, third.Id})
+	if books[1].Author == nil || len(books[1].Tags) != 2 {
+		t.Errorf("FindByTags() = %+v, want the book with its author and all its tags", books[1])
+	}
+
+	books, err = repos.Book.FindByTags(ctx, []string{"award", "staff-pick"}, true)
+	if err != nil {
+		t.Fatalf("FindByTags() all error = %v", err)
+	}
+	assertIds(t, bookIds(books), []string{other.Id})
+
+	if books, err := repos.Book.FindByTags(ctx, []string{"unknown"}, false); err != nil || len(books) != 0 {
+		t.Errorf("FindByTags() of an unknown tag = %v, %v, want none", books, err)
+	}
+	if books, err := repos.Book.FindByTags(ctx, nil, true); err != nil || len(books) != 0 {
+		t.Errorf("FindByTags() without tags = %v, %v, want none", books, err)
+	}
+
+	counts, err := repos.Book.CountTags(ctx)
+	if err != nil {
+		t.Fatalf("CountTags() error = %v", err)
+	}
+	want := []*entity.TagCount{{Tag: "award", Count: 2}, {Tag: "staff-pick", Count: 2}, {Tag: "summer-2026", Count: 1}}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("CountTags() = %s, want %s", formatTagCounts(counts), formatTagCounts(want))
+	}
+
+	if err := repos.Book.RemoveTags(ctx, other.Id, []string{"staff-pick", "unknown"}); err != nil {
+		t.Fatalf("RemoveTags() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, other.Id)
+	if err != nil {
+		t.Fatalf("Find() after RemoveTags() error = %v", err)
+	}
+	assertTags(t, found.Tags, []string{"award"})
+
+	// Update replaces the tags.
+	found.Tags = []string{"classic"}
+	if err := repos.Book.Update(ctx, found); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.Book.Find(ctx, other.Id)
+	if err != nil {
+		t.Fatalf("Find() after Update() error = %v", err)
+	}
+	assertTags(t, found.Tags, []string{"classic"})
+
+	// Deleted books are not counted.
+	for _, id := range []string{stored.Id, other.Id, third.Id} {
+		if err := repos.Book.Delete(ctx, id); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}
+	if counts, err := repos.Book.CountTags(ctx); err != nil || len(counts) != 0 {
+		t.Errorf("CountTags() after deleting the books = %s, %v, want none", formatTagCounts(counts), err)
+	}
+
+	if err := repos.Book.AddTags(ctx, invalidId, []string{"award"}); status(err) != http.StatusBadRequest {
+		t.Errorf("AddTags() with an invalid ID error = %v, want bad request", err)
+	}
+	if err := repos.Book.RemoveTags(ctx, invalidId, []string{"award"}); status(err) != http.StatusBadRequest {
+		t.Errorf("RemoveTags() with an invalid ID error = %v, want bad request", err)
+	}
+}
+
+func assertTags(t *testing.T, got, want []string) {
+	t.Helper()
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tags = %v, want %v", got, want)
+	}
+}
+
+func formatTagCounts(counts []*entity.TagCount) string {
+	s := []string{}
+	for _, c := range counts {
+		s = append(s, fmt.Sprintf("%s:%d", c.Tag, c.Count))
+	}
+
+	return strings.Join(s, " ")
+}
+
+func bookIds(books []*entity.Book) []string {
+	ids := []string{}
+	for _, book := range books {
+		ids = append(ids, book.Id)
+	}
+
+	return ids
+}
+
+// assertTranslations compares translations, no translations being nil or
+// empty alike.
+func assertTranslations[T any](t *testing.T, got, want map[string]*T) {
+	t.Helper()
+
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("translations = %s, want %s", formatTranslations(got), formatTranslations(want))
+	}
+}
+
+func formatTranslations[T any](translations map[string]*T) string {
+	tags := []string{}
+	for tag, t := range translations {
+		tags = append(tags, fmt.Sprintf("%s:%+v", tag, *t))
+	}
+	sort.Strings(tags)
+
+	return strings.Join(tags, " ")
+}
+
+func testUser(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	if user, err := repos.User.Find(ctx, "alice"); user != nil || err != nil {
+		t.Errorf("Find() of an unknown user = %v, %v, want nil, nil", user, err)
+	}
+
+	user := &entity.User{
+		Username:  "alice",
+		Password:  "hashed password",
+		FirstName: "Alice",
+		LastName:  "Liddell",
+		Role:      entity.RoleAdmin,
+	}
+	if err := repos.User.Store(ctx, user); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if err := repos.User.Store(ctx, &entity.User{Username: "bob", Role: entity.RoleUser}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	found, err := repos.User.Find(ctx, "alice")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.Id == "" || found.CreatedAt.IsZero() || found.Username != user.Username || found.Password != user.Password ||
+		found.FirstName != user.FirstName || found.LastName != user.LastName || found.Role != user.Role {
+		t.Errorf("Find() = %+v, want %+v", found, user)
+	}
+
+	if err := repos.User.Store(ctx, &entity.User{Username: "alice", Role: entity.RoleUser}); status(err) != http.StatusBadRequest {
+		t.Errorf("Store() of an existing username error = %v, want bad request", err)
+	}
+	if found, _ := repos.User.Find(ctx, "alice"); found == nil || found.Role != entity.RoleAdmin {
+		t.Errorf("Store() of an existing username replaced the user: %+v", found)
+	}
+	if found, _ := repos.User.Find(ctx, "Alice"); found != nil {
+		t.Errorf("Find() is not case sensitive, found %+v", found)
+	}
+}
+
+func newAuthor(i int) *entity.Author {
+	return &entity.Author{
+		FirstName:   test.AuthorFirstName1,
+		LastName:    test.AuthorLastName1 + string(rune('a'+i)),
+		BirthDate:   test.AuthorBirthDate1,
+		Nationality: test.AuthorNationality1,
+	}
+}
+
+func newBook(authorId string, i int) *entity.Book {
+	return &entity.Book{
+		AuthorId:        authorId,
+		Name:            test.BookName1 + string(rune('a'+i)),
+		Description:     test.BookDescription1,
+		PublicationDate: test.PublicationDate1,
+		Price:           test.Price1 + float64(i),
+	}
+}
+
+func storeAuthor(t *testing.T, repos Repositories, i int) *entity.Author {
+	t.Helper()
+
+	author := newAuthor(i)
+	if err := repos.Author.Store(context.Background(), author); err != nil {
+		t.Fatalf("Author.Store() error = %v", err)
+	}
+
+	return author
+}
+
+func storeBook(t *testing.T, repos Repositories, authorId string, i int) *entity.Book {
+	t.Helper()
+
+	book, err := repos.Book.Store(context.Background(), newBook(authorId, i))
+	if err != nil {
+		t.Fatalf("Book.Store() error = %v", err)
+	}
+
+	return book
+}
+
+func storeCategory(t *testing.T, repos Repositories, parentId string, i int) *entity.Category {
+	t.Helper()
+
+	category := &entity.Category{Name: test.CategoryName1 + string(rune('a'+i)), ParentId: parentId}
+	if err := repos.Category.Store(context.Background(), category); err != nil {
+		t.Fatalf("Category.Store() error = %v", err)
+	}
+
+	return category
+}
+
+func storePublisher(t *testing.T, repos Repositories, i int) *entity.Publisher {
+	t.Helper()
+
+	publisher := &entity.Publisher{
+		Name:    test.PublisherName1 + string(rune('a'+i)),
+		Country: test.PublisherCountry1,
+		Website: test.PublisherWebsite1,
+	}
+	if err := repos.Publisher.Store(context.Background(), publisher); err != nil {
+		t.Fatalf("Publisher.Store() error = %v", err)
+	}
+
+	return publisher
+}
+
+func storeSeries(t *testing.T, repos Repositories, i int) *entity.Series {
+	t.Helper()
+
+	series := &entity.Series{Name: test.SeriesName1 + string(rune('a'+i)), Description: test.SeriesDescription1}
+	if err := repos.Series.Store(context.Background(), series); err != nil {
+		t.Fatalf("Series.Store() error = %v", err)
+	}
+
+	return series
+}
+
+func assertSeries(t *testing.T, got, want *entity.Series) {
+	t.Helper()
+
+	if got.Id != want.Id || got.Name != want.Name || got.Description != want.Description {
+		t.Errorf("series = %+v, want %+v", got, want)
+	}
+}
+
+func assertPublisher(t *testing.T, got, want *entity.Publisher) {
+	t.Helper()
+
+	if got.Id != want.Id || got.Name != want.Name || got.Country != want.Country || got.Website != want.Website {
+		t.Errorf("publisher = %+v, want %+v", got, want)
+	}
+}
+
+func assertAuthor(t *testing.T, got, want *entity.Author) {
+	t.Helper()
+
+	if got.Id != want.Id || got.FirstName != want.FirstName || got.LastName != want.LastName ||
+		got.BirthDate != want.BirthDate || got.Nationality != want.Nationality {
+		t.Errorf("author = %+v, want %+v", got, want)
+	}
+	if !sameTime(got.CreatedAt, want.CreatedAt) || !sameTime(got.UpdatedAt, want.UpdatedAt) {
+		t.Errorf("author timestamps = %v, %v, want %v, %v", got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+	}
+}
+
+func assertBook(t *testing.T, got, want *entity.Book) {
+	t.Helper()
+
+	if got.Id != want.Id || got.AuthorId != want.AuthorId || got.Name != want.Name || got.Description != want.Description ||
+		got.PublicationDate != want.PublicationDate || got.Price != want.Price {
+		t.Errorf("book = %+v, want %+v", got, want)
+	}
+	if !sameTime(got.CreatedAt, want.CreatedAt) || !sameTime(got.UpdatedAt, want.UpdatedAt) {
+		t.Errorf("book timestamps = %v, %v, want %v, %v", got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+	}
+	if got.Author == nil || got.Author.Id != want.AuthorId {
+		t.Errorf("book author = %+v, want author %s", got.Author, want.AuthorId)
+	}
+}
+
+func assertContributors(t *testing.T, got, want []*entity.Contributor) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("contributors = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].AuthorId != want[i].AuthorId || got[i].Role != want[i].Role {
+			t.Errorf("contributor %d = %s %s, want %s %s", i, got[i].AuthorId, got[i].Role, want[i].AuthorId, want[i].Role)
+		}
+		if got[i].Author == nil || got[i].Author.Id != want[i].AuthorId {
+			t.Errorf("contributor %d author = %+v, want author %s", i, got[i].Author, want[i].AuthorId)
+		}
+	}
+}
+
+// assertCategoryIds compares category IDs regardless of their order.
+func assertCategoryIds(t *testing.T, got, want []string) {
+	t.Helper()
+
+	got = append([]string{}, got...)
+	want = append([]string{}, want...)
+	sort.Strings(got)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("categoryIds = %v, want %v", got, want)
+	}
+}
+
+func assertIds(t *testing.T, got, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("FindAll() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("FindAll() = %v, want %v", got, want)
+		}
+	}
+}
+
+// sameTime compares times at the millisecond precision of Mongo.
+func sameTime(a, b time.Time) bool {
+	d := a.Sub(b)
+	return d > -time.Millisecond && d < time.Millisecond
+}
+
+func status(err error) int {
+	var apiErr *portError.ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+
+	return 0
+}
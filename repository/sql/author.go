@@ -0,0 +1,227 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const authorColumns = "id, first_name, last_name, birth_date, death_date, nationality, biography, aliases, isni, viaf, photo_id, translations, created_at, updated_at"
+
+type authorRepository struct {
+	db *DB
+}
+
+func NewAuthorRepository(db *DB) repository.AuthorRepository {
+	return &authorRepository{db: db}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAuthor(row scanner, prefix ...any) (*entities.Author, error) {
+	author := &entities.Author{}
+	var aliases, translations string
+	dest := append(prefix,
+		&author.Id, &author.FirstName, &author.LastName, &author.BirthDate, &author.DeathDate, &author.Nationality,
+		&author.Biography, &aliases, &author.ISNI, &author.VIAF, &author.PhotoId, &translations,
+		&author.CreatedAt, &author.UpdatedAt,
+	)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	author.Aliases = splitAliases(aliases)
+	if err := json.Unmarshal([]byte(translations), &author.Translations); err != nil {
+		return nil, err
+	}
+	author.CreatedAt = author.CreatedAt.UTC()
+	author.UpdatedAt = author.UpdatedAt.UTC()
+
+	return author, nil
+}
+
+func (r *authorRepository) Store(ctx context.Context, author *entities.Author) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	translations, err := marshalTranslations(author.Translations)
+	if err != nil {
+		return errors.Wrap(err, "authorRepository.Store")
+	}
+
+	id := newObjectId()
+	now := now()
+	_, err = r.db.exec(ctx,
+		"INSERT INTO authors ("+authorColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, '', ?, ?, ?)",
+		id, author.FirstName, author.LastName, author.BirthDate, author.DeathDate, author.Nationality,
+		author.Biography, strings.Join(author.Aliases, "\n"), author.ISNI, author.VIAF, translations, now, now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "authorRepository.Store")
+	}
+
+	author.Id = id
+	author.CreatedAt = now
+	author.UpdatedAt = now
+
+	return nil
+}
+
+func (r *authorRepository) Update(ctx context.Context, author *entities.Author) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(author.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	translations, err := marshalTranslations(author.Translations)
+	if err != nil {
+		return errors.Wrap(err, "authorRepository.Update")
+	}
+
+	_, err = r.db.exec(ctx,
+		`UPDATE authors SET first_name = ?, last_name = ?, birth_date = ?, death_date = ?, nationality = ?,
+		biography = ?, aliases = ?, isni = ?, viaf = ?, translations = ?, updated_at = ? WHERE id = ?`,
+		author.FirstName, author.LastName, author.BirthDate, author.DeathDate, author.Nationality,
+		author.Biography, strings.Join(author.Aliases, "\n"), author.ISNI, author.VIAF, translations, now(), author.Id,
+	)
+	if err != nil {
+		return errors.Wrap(err, "authorRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *authorRepository) Find(ctx context.Context, id string) (*entities.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	author, err := scanAuthor(r.db.queryRow(ctx, "SELECT "+authorColumns+" FROM authors WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, portError.NewNotFoundError("Author not found.", err)
+		}
+		return nil, errors.Wrap(err, "authorRepository.Find")
+	}
+
+	return author, nil
+}
+
+// FindAll returns the authors in insertion order, as Mongo does. ObjectIDs
+// start with their creation time so ordering by id is close enough.
+func (r *authorRepository) FindAll(ctx context.Context) ([]*entities.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	authors, err := r.find(ctx, "SELECT "+authorColumns+" FROM authors ORDER BY id")
+	return authors, errors.Wrap(err, "authorRepository.FindAll")
+}
+
+// Search matches the aliases column as a whole. The aliases are single lines,
+// so a query without line break cannot match across two of them, and a query
+// with one matches no name.
+func (r *authorRepository) Search(ctx context.Context, query string) ([]*entities.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if strings.ContainsAny(query, "\r\n") {
+		return []*entities.Author{}, nil
+	}
+
+	lower := r.db.dialect.lower
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(query)) + "%"
+	authors, err := r.find(ctx,
+		`SELECT `+authorColumns+` FROM authors
+		WHERE `+lower+`(first_name) LIKE ? ESCAPE '\' OR `+lower+`(last_name) LIKE ? ESCAPE '\'
+		OR `+lower+`(first_name || ' ' || last_name) LIKE ? ESCAPE '\' OR `+lower+`(aliases) LIKE ? ESCAPE '\'
+		ORDER BY id`,
+		pattern, pattern, pattern, pattern,
+	)
+	return authors, errors.Wrap(err, "authorRepository.Search")
+}
+
+func (r *authorRepository) SetPhoto(ctx context.Context, id, photoId string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	_, err := r.db.exec(ctx, "UPDATE authors SET photo_id = ?, updated_at = ? WHERE id = ?", photoId, now(), id)
+	return errors.Wrap(err, "authorRepository.SetPhoto")
+}
+
+func (r *authorRepository) find(ctx context.Context, query string, args ...any) ([]*entities.Author, error) {
+	rows, err := r.db.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	authors := []*entities.Author{}
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, author)
+	}
+
+	return authors, rows.Err()
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+func splitAliases(aliases string) []string {
+	if aliases == "" {
+		return []string{}
+	}
+	return strings.Split(aliases, "\n")
+}
+
+// marshalTranslations returns the JSON object of translations, empty when
+// there are none.
+func marshalTranslations[T any](translations map[string]*T) (string, error) {
+	if translations == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(translations)
+	return string(data), err
+}
+
+func (r *authorRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse author ID to ObjectID", err)
+	}
+
+	if _, err := r.db.exec(ctx, "DELETE FROM authors WHERE id = ?", id); err != nil {
+		if r.db.dialect.isForeignKeyError(err) {
+			return errAuthorHasBooks(err)
+		}
+		return errors.Wrap(err, "authorRepository.Delete")
+	}
+
+	return nil
+}
+
+func errAuthorHasBooks(err error) error {
+	return portError.NewConflictError("Author has books.", err)
+}
@@ -0,0 +1,964 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const (
+	bookColumns = "id, author_id, name, description, publication_date, price, isbn10, isbn13, publisher_id, series_id, series_position, language, original_id, translations, created_at, updated_at"
+
+	// selectBooks joins every book with its author, like the Mongo $lookup
+	// followed by $unwind, and with its publisher when it has one.
+	selectBooks = `SELECT b.id, b.author_id, b.name, b.description, b.publication_date, b.price, b.isbn10, b.isbn13,
+	b.publisher_id, b.series_id, b.series_position, b.cover_id, b.language, b.original_id, b.translations,
+	b.created_at, b.updated_at,
+	a.id, a.first_name, a.last_name, a.birth_date, a.death_date, a.nationality, a.biography, a.aliases,
+	a.isni, a.viaf, a.photo_id, a.translations, a.created_at, a.updated_at,
+	p.id, p.name, p.country, p.website, p.created_at, p.updated_at
+	FROM books b JOIN authors a ON a.id = b.author_id
+	LEFT JOIN publishers p ON p.id = b.publisher_id`
+
+	selectContributors = `SELECT c.book_id, c.role,
+	a.id, a.first_name, a.last_name, a.birth_date, a.death_date, a.nationality, a.biography, a.aliases,
+	a.isni, a.viaf, a.photo_id, a.translations, a.created_at, a.updated_at
+	FROM book_contributors c JOIN authors a ON a.id = c.author_id`
+)
+
+type bookRepository struct {
+	db *DB
+}
+
+func NewBookRepository(db *DB) repository.BookRepository {
+	return &bookRepository{db: db}
+}
+
+func scanBook(row scanner) (*entities.Book, error) {
+	book := &entities.Book{}
+	author := &entities.Author{}
+	var aliases, translations, authorTranslations string
+	var publisherId, seriesId, originalId, pId, pName, pCountry, pWebsite sql.NullString
+	var pCreatedAt, pUpdatedAt sql.NullTime
+	err := row.Scan(
+		&book.Id, &book.AuthorId, &book.Name, &book.Description, &book.PublicationDate, &book.Price,
+		&book.ISBN10, &book.ISBN13, &publisherId, &seriesId, &book.SeriesPosition, &book.CoverId,
+		&book.Language, &originalId, &translations, &book.CreatedAt, &book.UpdatedAt,
+		&author.Id, &author.FirstName, &author.LastName, &author.BirthDate, &author.DeathDate, &author.Nationality,
+		&author.Biography, &aliases, &author.ISNI, &author.VIAF, &author.PhotoId, &authorTranslations,
+		&author.CreatedAt, &author.UpdatedAt,
+		&pId, &pName, &pCountry, &pWebsite, &pCreatedAt, &pUpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(translations), &book.Translations); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(authorTranslations), &author.Translations); err != nil {
+		return nil, err
+	}
+	author.Aliases = splitAliases(aliases)
+	author.CreatedAt = author.CreatedAt.UTC()
+	author.UpdatedAt = author.UpdatedAt.UTC()
+	book.Author = author
+	book.PublisherId = publisherId.String
+	book.SeriesId = seriesId.String
+	book.OriginalId = originalId.String
+	if pId.Valid {
+		book.Publisher = &entities.Publisher{
+			Id:        pId.String,
+			Name:      pName.String,
+			Country:   pCountry.String,
+			Website:   pWebsite.String,
+			CreatedAt: pCreatedAt.Time.UTC(),
+			UpdatedAt: pUpdatedAt.Time.UTC(),
+		}
+	}
+	book.CreatedAt = book.CreatedAt.UTC()
+	book.UpdatedAt = book.UpdatedAt.UTC()
+
+	return book, nil
+}
+
+// constraintError maps the foreign key violation of a book referencing a
+// missing author, category, publisher, series or original book, and the
+// unique violation of a duplicate ISBN or series position.
+func (r *bookRepository) constraintError(err error) error {
+	if apiErr, ok := err.(*portError.ApiError); ok {
+		return apiErr
+	}
+	if r.db.dialect.isForeignKeyError(err) {
+		return portError.NewNotFoundError("Author, category, publisher, series or original book not found.", err)
+	}
+	if r.db.dialect.isUniqueViolation(err) {
+		switch r.db.dialect.uniqueConstraint(err) {
+		case "books_series_position_idx", "books.series_id, books.series_position":
+			return errDuplicateSeriesPosition(err)
+		}
+		return portError.NewConflictError("A book with this ISBN already exists.", err)
+	}
+
+	return nil
+}
+
+func (r *bookRepository) Store(ctx context.Context, book *entities.Book) (*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	contributors, err := copyContributors(book)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIds, err := copyCategoryIds(book)
+	if err != nil {
+		return nil, err
+	}
+
+	publisherId, err := bookPublisherId(book)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesId, err := bookSeriesId(book)
+	if err != nil {
+		return nil, err
+	}
+
+	originalId, err := bookOriginalId(book)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := marshalTranslations(book.Translations)
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.Store")
+	}
+
+	editions, err := copyEditions(book)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := book.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	id := newObjectId()
+	now := now()
+	err = NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.checkISBN(ctx, id, book, editions); err != nil {
+			return err
+		}
+		if err := r.checkSeriesPosition(ctx, id, book); err != nil {
+			return err
+		}
+
+		_, err := r.db.exec(ctx,
+			"INSERT INTO books ("+bookColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			id, book.AuthorId, book.Name, book.Description, book.PublicationDate, book.Price, book.ISBN10, book.ISBN13,
+			publisherId, seriesId, book.SeriesPosition, book.Language, originalId, translations, now, now,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := r.insertContributors(ctx, id, contributors); err != nil {
+			return err
+		}
+		if err := r.insertEditions(ctx, id, editions); err != nil {
+			return err
+		}
+		if err := r.insertTags(ctx, id, tags); err != nil {
+			return err
+		}
+
+		return r.insertCategories(ctx, id, categoryIds)
+	})
+	if err != nil {
+		if apiErr := r.constraintError(err); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, errors.Wrap(err, "bookRepository.Store")
+	}
+
+	stored := *book
+	stored.Id = id
+	stored.Contributors = contributors
+	stored.CategoryIds = categoryIds
+	stored.Tags = tags
+	stored.Editions = editions
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+
+	return &stored, nil
+}
+
+func (r *bookRepository) Update(ctx context.Context, book *entities.Book) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(book.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	contributors, err := copyContributors(book)
+	if err != nil {
+		return err
+	}
+
+	categoryIds, err := copyCategoryIds(book)
+	if err != nil {
+		return err
+	}
+
+	publisherId, err := bookPublisherId(book)
+	if err != nil {
+		return err
+	}
+
+	seriesId, err := bookSeriesId(book)
+	if err != nil {
+		return err
+	}
+
+	originalId, err := bookOriginalId(book)
+	if err != nil {
+		return err
+	}
+
+	translations, err := marshalTranslations(book.Translations)
+	if err != nil {
+		return errors.Wrap(err, "bookRepository.Update")
+	}
+
+	editions, err := copyEditions(book)
+	if err != nil {
+		return err
+	}
+
+	err = NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.checkISBN(ctx, book.Id, book, editions); err != nil {
+			return err
+		}
+		if err := r.checkSeriesPosition(ctx, book.Id, book); err != nil {
+			return err
+		}
+
+		res, err := r.db.exec(ctx,
+			`UPDATE books SET author_id = ?, name = ?, description = ?, publication_date = ?, price = ?,
+			isbn10 = ?, isbn13 = ?, publisher_id = ?, series_id = ?, series_position = ?, language = ?,
+			original_id = ?, translations = ?, updated_at = ? WHERE id = ?`,
+			book.AuthorId, book.Name, book.Description, book.PublicationDate, book.Price,
+			book.ISBN10, book.ISBN13, publisherId, seriesId, book.SeriesPosition, book.Language,
+			originalId, translations, now(), book.Id,
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+
+		if _, err := r.db.exec(ctx, "DELETE FROM book_contributors WHERE book_id = ?", book.Id); err != nil {
+			return err
+		}
+		if err := r.insertContributors(ctx, book.Id, contributors); err != nil {
+			return err
+		}
+
+		if _, err := r.db.exec(ctx, "DELETE FROM book_editions WHERE book_id = ?", book.Id); err != nil {
+			return err
+		}
+		if err := r.insertEditions(ctx, book.Id, editions); err != nil {
+			return err
+		}
+
+		if _, err := r.db.exec(ctx, "DELETE FROM book_tags WHERE book_id = ?", book.Id); err != nil {
+			return err
+		}
+		if err := r.insertTags(ctx, book.Id, book.Tags); err != nil {
+			return err
+		}
+
+		if _, err := r.db.exec(ctx, "DELETE FROM book_categories WHERE book_id = ?", book.Id); err != nil {
+			return err
+		}
+		return r.insertCategories(ctx, book.Id, categoryIds)
+	})
+	if err != nil {
+		if apiErr := r.constraintError(err); apiErr != nil {
+			return apiErr
+		}
+		return errors.Wrap(err, "bookRepository.Update")
+	}
+
+	return nil
+}
+
+// checkISBN checks that no other book, or edition of another book, has one
+// of the ISBNs of book and its editions. The unique indexes only cover one
+// table each.
+func (r *bookRepository) checkISBN(ctx context.Context, id string, book *entities.Book, editions []*entities.Edition) error {
+	isbns := []string{}
+	for _, isbn13 := range append([]string{book.ISBN13}, editionISBNs(editions)...) {
+		if isbn13 != "" {
+			isbns = append(isbns, isbn13)
+		}
+	}
+	if len(isbns) == 0 {
+		return nil
+	}
+
+	in, args := inList(isbns)
+	args = append([]any{id}, args...)
+	var n int
+	err := r.db.queryRow(ctx,
+		`SELECT (SELECT COUNT(*) FROM books WHERE id <> ? AND isbn13 IN (`+in+`))
+		+ (SELECT COUNT(*) FROM book_editions WHERE book_id <> ? AND isbn13 IN (`+in+`))`,
+		append(args, args...)...,
+	).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return portError.NewConflictError("A book with this ISBN already exists.", nil)
+	}
+
+	return nil
+}
+
+// checkSeriesPosition checks that no other book is at the position of book in
+// its series, for a clearer error than the unique index.
+func (r *bookRepository) checkSeriesPosition(ctx context.Context, id string, book *entities.Book) error {
+	if book.SeriesId == "" {
+		return nil
+	}
+
+	var n int
+	err := r.db.queryRow(ctx,
+		"SELECT COUNT(*) FROM books WHERE id <> ? AND series_id = ? AND series_position = ?",
+		id, book.SeriesId, book.SeriesPosition,
+	).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return errDuplicateSeriesPosition(nil)
+	}
+
+	return nil
+}
+
+func errDuplicateSeriesPosition(err error) error {
+	return portError.NewConflictError("A book already has this position in the series.", err)
+}
+
+func (r *bookRepository) insertEditions(ctx context.Context, bookId string, editions []*entities.Edition) error {
+	for i, e := range editions {
+		_, err := r.db.exec(ctx,
+			`INSERT INTO book_editions (id, book_id, position, format, isbn10, isbn13, page_count, duration_minutes,
+			price, publication_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			e.Id, bookId, i, e.Format, e.ISBN10, e.ISBN13, e.PageCount, e.DurationMinutes, e.Price, e.PublicationDate,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *bookRepository) insertCategories(ctx context.Context, bookId string, categoryIds []string) error {
+	for _, categoryId := range categoryIds {
+		_, err := r.db.exec(ctx, "INSERT INTO book_categories (book_id, category_id) VALUES (?, ?)", bookId, categoryId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertTags adds the tags the book does not have yet.
+func (r *bookRepository) insertTags(ctx context.Context, bookId string, tags []string) error {
+	for _, tag := range tags {
+		_, err := r.db.exec(ctx,
+			"INSERT INTO book_tags (book_id, tag) VALUES (?, ?) ON CONFLICT (book_id, tag) DO NOTHING",
+			bookId, tag,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *bookRepository) insertContributors(ctx context.Context, bookId string, contributors []*entities.Contributor) error {
+	for i, c := range contributors {
+		_, err := r.db.exec(ctx,
+			"INSERT INTO book_contributors (book_id, position, author_id, role) VALUES (?, ?, ?, ?)",
+			bookId, i, c.AuthorId, c.Role,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *bookRepository) Find(ctx context.Context, id string) (*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	book, err := scanBook(r.db.queryRow(ctx, selectBooks+" WHERE b.id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, portError.NewNotFoundError("Book not found.", nil)
+		}
+		return nil, errors.Wrap(err, "bookRepository.Find")
+	}
+
+	err = r.join(ctx, []*entities.Book{book}, " WHERE c.book_id = ?", book.Id)
+	return book, errors.Wrap(err, "bookRepository.Find")
+}
+
+func (r *bookRepository) FindByISBN(ctx context.Context, isbn13 string) (*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	book, err := scanBook(r.db.queryRow(ctx,
+		selectBooks+` WHERE (b.isbn13 = ? AND b.isbn13 <> '')
+		OR b.id IN (SELECT book_id FROM book_editions WHERE isbn13 = ? AND isbn13 <> '')`,
+		isbn13, isbn13,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, portError.NewNotFoundError("Book not found.", nil)
+		}
+		return nil, errors.Wrap(err, "bookRepository.FindByISBN")
+	}
+
+	err = r.join(ctx, []*entities.Book{book}, " WHERE c.book_id = ?", book.Id)
+	return book, errors.Wrap(err, "bookRepository.FindByISBN")
+}
+
+func (r *bookRepository) FindAll(ctx context.Context) ([]*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	books, err := r.find(ctx, selectBooks+" ORDER BY b.id")
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.FindAll")
+	}
+
+	return books, errors.Wrap(r.join(ctx, books, ""), "bookRepository.FindAll")
+}
+
+func (r *bookRepository) FindPage(ctx context.Context, after string, limit int) ([]*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if after != "" {
+		if err := validObjectId(after); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+		}
+	}
+
+	books, err := r.find(ctx, selectBooks+" WHERE b.id > ? ORDER BY b.id LIMIT ?", after, limit)
+	if err != nil || len(books) == 0 {
+		return books, errors.Wrap(err, "bookRepository.FindPage")
+	}
+
+	ids := make([]string, 0, len(books))
+	for _, book := range books {
+		ids = append(ids, book.Id)
+	}
+	in, args := inList(ids)
+	return books, errors.Wrap(r.join(ctx, books, " WHERE c.book_id IN ("+in+")", args...), "bookRepository.FindPage")
+}
+
+func (r *bookRepository) FindByCategories(ctx context.Context, categoryIds []string) ([]*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	for _, id := range categoryIds {
+		if err := validObjectId(id); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+		}
+	}
+
+	if len(categoryIds) == 0 {
+		return []*entities.Book{}, nil
+	}
+
+	in, args := inList(categoryIds)
+	books, err := r.find(ctx,
+		selectBooks+" WHERE b.id IN (SELECT book_id FROM book_categories WHERE category_id IN ("+in+")) ORDER BY b.id",
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.FindByCategories")
+	}
+
+	where := " WHERE c.book_id IN (SELECT book_id FROM book_categories WHERE category_id IN (" + in + "))"
+	return books, errors.Wrap(r.join(ctx, books, where, args...), "bookRepository.FindByCategories")
+}
+
+func (r *bookRepository) FindByPublisher(ctx context.Context, publisherId string) ([]*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(publisherId); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	books, err := r.find(ctx, selectBooks+" WHERE b.publisher_id = ? ORDER BY b.id", publisherId)
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.FindByPublisher")
+	}
+
+	where := " WHERE c.book_id IN (SELECT id FROM books WHERE publisher_id = ?)"
+	return books, errors.Wrap(r.join(ctx, books, where, publisherId), "bookRepository.FindByPublisher")
+}
+
+func (r *bookRepository) SetCover(ctx context.Context, id, coverId string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	_, err := r.db.exec(ctx, "UPDATE books SET cover_id = ?, updated_at = ? WHERE id = ?", coverId, now(), id)
+	return errors.Wrap(err, "bookRepository.SetCover")
+}
+
+// FindBySeries returns the books of the series sorted by position.
+func (r *bookRepository) FindBySeries(ctx context.Context, seriesId string) ([]*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(seriesId); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	books, err := r.find(ctx, selectBooks+" WHERE b.series_id = ? ORDER BY b.series_position", seriesId)
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.FindBySeries")
+	}
+
+	where := " WHERE c.book_id IN (SELECT id FROM books WHERE series_id = ?)"
+	return books, errors.Wrap(r.join(ctx, books, where, seriesId), "bookRepository.FindBySeries")
+}
+
+func (r *bookRepository) FindByOriginal(ctx context.Context, originalId string) ([]*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(originalId); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	books, err := r.find(ctx, selectBooks+" WHERE b.original_id = ? ORDER BY b.id", originalId)
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.FindByOriginal")
+	}
+
+	where := " WHERE c.book_id IN (SELECT id FROM books WHERE original_id = ?)"
+	return books, errors.Wrap(r.join(ctx, books, where, originalId), "bookRepository.FindByOriginal")
+}
+
+func (r *bookRepository) FindByTags(ctx context.Context, tags []string, all bool) ([]*entities.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if len(tags) == 0 {
+		return []*entities.Book{}, nil
+	}
+
+	in, args := inList(tags)
+	tagged := "SELECT book_id FROM book_tags WHERE tag IN (" + in + ")"
+	if all {
+		tagged += " GROUP BY book_id HAVING COUNT(*) = ?"
+		args = append(args, len(tags))
+	}
+
+	books, err := r.find(ctx, selectBooks+" WHERE b.id IN ("+tagged+") ORDER BY b.id", args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.FindByTags")
+	}
+
+	where := " WHERE c.book_id IN (" + tagged + ")"
+	return books, errors.Wrap(r.join(ctx, books, where, args...), "bookRepository.FindByTags")
+}
+
+func (r *bookRepository) AddTags(ctx context.Context, id string, tags []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	err := NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		res, err := r.db.exec(ctx, "UPDATE books SET updated_at = ? WHERE id = ?", now(), id)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+
+		return r.insertTags(ctx, id, tags)
+	})
+
+	return errors.Wrap(err, "bookRepository.AddTags")
+}
+
+func (r *bookRepository) RemoveTags(ctx context.Context, id string, tags []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("Unable to parse book ID to ObjectID.", err)
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	err := NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		res, err := r.db.exec(ctx, "UPDATE books SET updated_at = ? WHERE id = ?", now(), id)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+
+		in, args := inList(tags)
+		_, err = r.db.exec(ctx, "DELETE FROM book_tags WHERE book_id = ? AND tag IN ("+in+")", append([]any{id}, args...)...)
+		return err
+	})
+
+	return errors.Wrap(err, "bookRepository.RemoveTags")
+}
+
+// CountTags counts the books of every tag. Like the other backends, a book
+// whose author does not exist is left out.
+func (r *bookRepository) CountTags(ctx context.Context) ([]*entities.TagCount, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	rows, err := r.db.query(ctx,
+		`SELECT t.tag, COUNT(*) FROM book_tags t JOIN books b ON b.id = t.book_id JOIN authors a ON a.id = b.author_id
+		GROUP BY t.tag ORDER BY COUNT(*) DESC, t.tag`,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "bookRepository.CountTags")
+	}
+	defer rows.Close()
+
+	counts := []*entities.TagCount{}
+	for rows.Next() {
+		c := &entities.TagCount{}
+		if err := rows.Scan(&c.Tag, &c.Count); err != nil {
+			return nil, errors.Wrap(err, "bookRepository.CountTags")
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, errors.Wrap(rows.Err(), "bookRepository.CountTags")
+}
+
+func (r *bookRepository) find(ctx context.Context, query string, args ...any) ([]*entities.Book, error) {
+	rows, err := r.db.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := []*entities.Book{}
+	for rows.Next() {
+		book, err := scanBook(rows)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
+// join sets the contributors, editions, categories and tags of books. where
+// selects their rows in book_contributors, book_editions, book_categories and
+// book_tags, all aliased c.
+func (r *bookRepository) join(ctx context.Context, books []*entities.Book, where string, args ...any) error {
+	if err := r.joinContributors(ctx, books, where, args...); err != nil {
+		return err
+	}
+
+	if err := r.joinEditions(ctx, books, where, args...); err != nil {
+		return err
+	}
+
+	if err := r.joinCategories(ctx, books, where, args...); err != nil {
+		return err
+	}
+
+	return r.joinTags(ctx, books, where, args...)
+}
+
+func (r *bookRepository) joinEditions(ctx context.Context, books []*entities.Book, where string, args ...any) error {
+	rows, err := r.db.query(ctx,
+		`SELECT c.book_id, c.id, c.format, c.isbn10, c.isbn13, c.page_count, c.duration_minutes, c.price,
+		c.publication_date FROM book_editions c`+where+" ORDER BY c.book_id, c.position",
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byBook := map[string][]*entities.Edition{}
+	for rows.Next() {
+		var bookId string
+		e := &entities.Edition{}
+		err := rows.Scan(&bookId, &e.Id, &e.Format, &e.ISBN10, &e.ISBN13, &e.PageCount, &e.DurationMinutes,
+			&e.Price, &e.PublicationDate)
+		if err != nil {
+			return err
+		}
+		byBook[bookId] = append(byBook[bookId], e)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		book.Editions = append([]*entities.Edition{}, byBook[book.Id]...)
+	}
+
+	return nil
+}
+
+func (r *bookRepository) joinCategories(ctx context.Context, books []*entities.Book, where string, args ...any) error {
+	rows, err := r.db.query(ctx, "SELECT c.book_id, c.category_id FROM book_categories c"+where+" ORDER BY c.book_id, c.category_id", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byBook := map[string][]string{}
+	for rows.Next() {
+		var bookId, categoryId string
+		if err := rows.Scan(&bookId, &categoryId); err != nil {
+			return err
+		}
+		byBook[bookId] = append(byBook[bookId], categoryId)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		book.CategoryIds = append([]string{}, byBook[book.Id]...)
+	}
+
+	return nil
+}
+
+func (r *bookRepository) joinTags(ctx context.Context, books []*entities.Book, where string, args ...any) error {
+	rows, err := r.db.query(ctx, "SELECT c.book_id, c.tag FROM book_tags c"+where+" ORDER BY c.book_id, c.tag", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byBook := map[string][]string{}
+	for rows.Next() {
+		var bookId, tag string
+		if err := rows.Scan(&bookId, &tag); err != nil {
+			return err
+		}
+		byBook[bookId] = append(byBook[bookId], tag)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		book.Tags = append([]string{}, byBook[book.Id]...)
+	}
+
+	return nil
+}
+
+// joinContributors sets the contributors of books, selected from
+// book_contributors with where, with their authors.
+func (r *bookRepository) joinContributors(ctx context.Context, books []*entities.Book, where string, args ...any) error {
+	rows, err := r.db.query(ctx, selectContributors+where+" ORDER BY c.book_id, c.position", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byBook := map[string][]*entities.Contributor{}
+	for rows.Next() {
+		var bookId, role string
+		author, err := scanAuthor(rows, &bookId, &role)
+		if err != nil {
+			return err
+		}
+		byBook[bookId] = append(byBook[bookId], &entities.Contributor{AuthorId: author.Id, Role: role, Author: author})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		book.Contributors = byBook[book.Id]
+		if book.Contributors == nil {
+			book.Contributors = []*entities.Contributor{}
+		}
+	}
+
+	return nil
+}
+
+// copyContributors validates the author IDs of book and returns a copy of its
+// contributors without their authors.
+func copyContributors(book *entities.Book) ([]*entities.Contributor, error) {
+	if err := validObjectId(book.AuthorId); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+	}
+
+	contributors := []*entities.Contributor{}
+	for _, c := range book.ContributorsOrAuthor() {
+		if err := validObjectId(c.AuthorId); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse author ID to ObjectID.", err)
+		}
+		contributors = append(contributors, &entities.Contributor{AuthorId: c.AuthorId, Role: c.Role})
+	}
+
+	return contributors, nil
+}
+
+func (r *bookRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse book ID to ObjectID", err)
+	}
+
+	if _, err := r.db.exec(ctx, "DELETE FROM books WHERE id = ?", id); err != nil {
+		return errors.Wrap(err, "bookRepository.Delete")
+	}
+
+	return nil
+}
+
+// copyCategoryIds validates the category IDs of book and returns a copy.
+func copyCategoryIds(book *entities.Book) ([]string, error) {
+	categoryIds := []string{}
+	for _, id := range book.CategoryIds {
+		if err := validObjectId(id); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+		}
+		categoryIds = append(categoryIds, id)
+	}
+
+	return categoryIds, nil
+}
+
+// copyEditions validates the edition IDs of book and returns a copy of its
+// editions, with an ID for the new ones.
+func copyEditions(book *entities.Book) ([]*entities.Edition, error) {
+	editions := []*entities.Edition{}
+	for _, e := range book.Editions {
+		edition := *e
+		if edition.Id == "" {
+			edition.Id = newObjectId()
+		} else if err := validObjectId(edition.Id); err != nil {
+			return nil, portError.NewBadRequestError("Unable to parse edition ID to ObjectID.", err)
+		}
+		editions = append(editions, &edition)
+	}
+
+	return editions, nil
+}
+
+func editionISBNs(editions []*entities.Edition) []string {
+	isbns := []string{}
+	for _, e := range editions {
+		isbns = append(isbns, e.ISBN13)
+	}
+
+	return isbns
+}
+
+// bookPublisherId validates the publisher of book and returns it, NULL for a
+// book without publisher.
+func bookPublisherId(book *entities.Book) (sql.NullString, error) {
+	if book.PublisherId == "" {
+		return sql.NullString{}, nil
+	}
+
+	if err := validObjectId(book.PublisherId); err != nil {
+		return sql.NullString{}, portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	return sql.NullString{String: book.PublisherId, Valid: true}, nil
+}
+
+func bookSeriesId(book *entities.Book) (sql.NullString, error) {
+	if book.SeriesId == "" {
+		return sql.NullString{}, nil
+	}
+
+	if err := validObjectId(book.SeriesId); err != nil {
+		return sql.NullString{}, portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	return sql.NullString{String: book.SeriesId, Valid: true}, nil
+}
+
+// bookOriginalId validates the original of book and returns it, NULL for a
+// book that is not a translation.
+func bookOriginalId(book *entities.Book) (sql.NullString, error) {
+	if book.OriginalId == "" {
+		return sql.NullString{}, nil
+	}
+
+	if err := validObjectId(book.OriginalId); err != nil {
+		return sql.NullString{}, portError.NewBadRequestError("Unable to parse original book ID to ObjectID.", err)
+	}
+
+	return sql.NullString{String: book.OriginalId, Valid: true}, nil
+}
+
+// inList returns the placeholders and arguments of an IN list of values.
+func inList(values []string) (string, []any) {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+
+	return strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "), args
+}
@@ -0,0 +1,164 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const categoryColumns = "id, name, parent_id, created_at, updated_at"
+
+type categoryRepository struct {
+	db *DB
+}
+
+func NewCategoryRepository(db *DB) repository.CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+func scanCategory(row scanner) (*entities.Category, error) {
+	category := &entities.Category{}
+	var parentId sql.NullString
+	if err := row.Scan(&category.Id, &category.Name, &parentId, &category.CreatedAt, &category.UpdatedAt); err != nil {
+		return nil, err
+	}
+	category.ParentId = parentId.String
+	category.CreatedAt = category.CreatedAt.UTC()
+	category.UpdatedAt = category.UpdatedAt.UTC()
+
+	return category, nil
+}
+
+// parentId validates the parent of category and returns it, NULL for a top
+// level category.
+func parentId(category *entities.Category) (sql.NullString, error) {
+	if category.ParentId == "" {
+		return sql.NullString{}, nil
+	}
+
+	if err := validObjectId(category.ParentId); err != nil {
+		return sql.NullString{}, portError.NewBadRequestError("Unable to parse parent category ID to ObjectID.", err)
+	}
+
+	return sql.NullString{String: category.ParentId, Valid: true}, nil
+}
+
+func (r *categoryRepository) Store(ctx context.Context, category *entities.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	parent, err := parentId(category)
+	if err != nil {
+		return err
+	}
+
+	id := newObjectId()
+	now := now()
+	_, err = r.db.exec(ctx,
+		"INSERT INTO categories ("+categoryColumns+") VALUES (?, ?, ?, ?, ?)",
+		id, category.Name, parent, now, now,
+	)
+	if err != nil {
+		if r.db.dialect.isForeignKeyError(err) {
+			return portError.NewNotFoundError("Parent category not found.", err)
+		}
+		return errors.Wrap(err, "categoryRepository.Store")
+	}
+
+	category.Id = id
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	return nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(category.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+	}
+
+	parent, err := parentId(category)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.exec(ctx,
+		"UPDATE categories SET name = ?, parent_id = ?, updated_at = ? WHERE id = ?",
+		category.Name, parent, now(), category.Id,
+	)
+	if err != nil {
+		if r.db.dialect.isForeignKeyError(err) {
+			return portError.NewNotFoundError("Parent category not found.", err)
+		}
+		return errors.Wrap(err, "categoryRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) Find(ctx context.Context, id string) (*entities.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse category ID to ObjectID.", err)
+	}
+
+	category, err := scanCategory(r.db.queryRow(ctx, "SELECT "+categoryColumns+" FROM categories WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, portError.NewNotFoundError("Category not found.", err)
+		}
+		return nil, errors.Wrap(err, "categoryRepository.Find")
+	}
+
+	return category, nil
+}
+
+func (r *categoryRepository) FindAll(ctx context.Context) ([]*entities.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, "SELECT "+categoryColumns+" FROM categories ORDER BY id")
+	if err != nil {
+		return nil, errors.Wrap(err, "categoryRepository.FindAll")
+	}
+	defer rows.Close()
+
+	categories := []*entities.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "categoryRepository.FindAll")
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, errors.Wrap(rows.Err(), "categoryRepository.FindAll")
+}
+
+// Delete fails with a conflict while the category has subcategories or books.
+func (r *categoryRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse category ID to ObjectID", err)
+	}
+
+	if _, err := r.db.exec(ctx, "DELETE FROM categories WHERE id = ?", id); err != nil {
+		if r.db.dialect.isForeignKeyError(err) {
+			return portError.NewConflictError("Category has subcategories or books.", err)
+		}
+		return errors.Wrap(err, "categoryRepository.Delete")
+	}
+
+	return nil
+}
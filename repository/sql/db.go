@@ -0,0 +1,178 @@
+// Package sqlrepo implements the repositories on SQL databases through
+// database/sql. The queries are written once with ? placeholders; a dialect
+// adapts them and the error codes to each database.
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type dialect struct {
+	name string
+	// driver is the database/sql driver name.
+	driver string
+	// numbered placeholders ($1, $2, …) instead of ?.
+	numbered bool
+	// skipLocked is appended to the SELECT claiming a queued row, so that
+	// concurrent relays do not wait on each other.
+	skipLocked string
+	// lower is the SQL function lowering the case of any letter.
+	lower string
+	// maxOpenConns limits the connection pool when positive.
+	maxOpenConns int
+	// dsn adds the options the repositories rely on to the configured DSN.
+	dsn               func(dsn string) string
+	isUniqueViolation func(err error) bool
+	// uniqueConstraint names the unique index a violation is on: its name on
+	// Postgres, its table.column list on SQLite, which does not report it.
+	uniqueConstraint  func(err error) string
+	isForeignKeyError func(err error) bool
+}
+
+var dialects = map[string]*dialect{}
+
+// DB is a connection pool to a SQL database, shared by the repositories.
+type DB struct {
+	sql     *sql.DB
+	dialect *dialect
+	timeout time.Duration
+}
+
+// Open connects to the database dsn with the driver name, one of "postgres"
+// or "sqlite".
+func Open(name, dsn string, timeout int) (*DB, error) {
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sql driver %q", name)
+	}
+
+	if d.dsn != nil {
+		dsn = d.dsn(dsn)
+	}
+
+	db, err := sql.Open(d.driver, dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open sql database")
+	}
+	if d.maxOpenConns > 0 {
+		db.SetMaxOpenConns(d.maxOpenConns)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "failed to connect to sql database")
+	}
+
+	return &DB{sql: db, dialect: d, timeout: time.Duration(timeout) * time.Second}, nil
+}
+
+func (db *DB) Close() error {
+	return db.sql.Close()
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txCtxKey struct{}
+
+// conn returns the transaction carried by ctx, or the pool.
+func (db *DB) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txCtxKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db.sql
+}
+
+func (db *DB) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return db.conn(ctx).ExecContext(ctx, db.rebind(query), args...)
+}
+
+func (db *DB) query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.conn(ctx).QueryContext(ctx, db.rebind(query), args...)
+}
+
+func (db *DB) queryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.conn(ctx).QueryRowContext(ctx, db.rebind(query), args...)
+}
+
+// rebind turns the ? placeholders of query into the dialect ones. Queries
+// must not contain a literal ?.
+func (db *DB) rebind(query string) string {
+	if !db.dialect.numbered {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+type transactor struct {
+	db *DB
+}
+
+// NewTransactor creates a Transactor running SQL transactions. A transaction
+// already carried by the context is joined.
+func NewTransactor(db *DB) repository.Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txCtxKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "transactor.BeginTx")
+	}
+
+	if err := fn(context.WithValue(ctx, txCtxKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(), "transactor.Commit")
+}
+
+// now returns the current time with the precision kept by Mongo, so that all
+// the backends return the same timestamps.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// IDs are ObjectIDs, as with Mongo, so that clients see the same format
+// whatever the backend.
+func newObjectId() string {
+	return primitive.NewObjectID().Hex()
+}
+
+func validObjectId(id string) error {
+	_, err := primitive.ObjectIDFromHex(id)
+	return err
+}
@@ -0,0 +1,30 @@
+package sqlrepo
+
+import "testing"
+
+func TestDB_rebind(t *testing.T) {
+	query := "SELECT id FROM books WHERE author_id = ? AND name = ?"
+
+	tests := []struct {
+		name    string
+		dialect string
+		want    string
+	}{
+		{name: "numbered", dialect: "postgres", want: "SELECT id FROM books WHERE author_id = $1 AND name = $2"},
+		{name: "question marks", dialect: "", want: query},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := dialects[tt.dialect]
+			if d == nil {
+				d = &dialect{}
+			}
+
+			db := &DB{dialect: d}
+			if got := db.rebind(query); got != tt.want {
+				t.Errorf("rebind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
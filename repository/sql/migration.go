@@ -0,0 +1,171 @@
+package sqlrepo
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"bookstore.com/tools/migrate"
+	"github.com/pkg/errors"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+// NewMigrator creates a Migrator applying the SQL migrations of the database
+// dialect, found in migrations/<dialect>/NNNN_description.{up,down}.sql. Each
+// migration runs in a transaction; the applied versions are recorded in the
+// schema_migrations table.
+func NewMigrator(db *DB) (*migrate.Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, path.Join("migrations", db.dialect.name), db)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.New(&migrationStore{db: db}, migrations...)
+}
+
+func loadMigrations(fsys fs.FS, dir string, db *DB) ([]migrate.Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "loadMigrations")
+	}
+
+	byVersion := map[int]*migrate.Migration{}
+	var versions []int
+	for _, entry := range entries {
+		name := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		prefix, description, _ := strings.Cut(strings.TrimSuffix(name, "."+direction+".sql"), "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+
+		raw, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, errors.Wrap(err, "loadMigrations")
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migrate.Migration{Version: version, Description: strings.ReplaceAll(description, "_", " ")}
+			byVersion[version] = m
+			versions = append(versions, version)
+		}
+
+		run := db.script(string(raw))
+		if direction == "up" {
+			m.Up = run
+		} else {
+			m.Down = run
+		}
+	}
+
+	migrations := []migrate.Migration{}
+	for _, version := range versions {
+		migrations = append(migrations, *byVersion[version])
+	}
+
+	return migrations, nil
+}
+
+// script returns a function running the statements of script, separated by
+// semicolons, in one transaction.
+func (db *DB) script(script string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		tx, err := db.sql.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		for _, statement := range strings.Split(script, ";") {
+			if strings.TrimSpace(stripComments(statement)) == "" {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit()
+	}
+}
+
+func stripComments(statement string) string {
+	lines := strings.Split(statement, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines[i] = ""
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type migrationStore struct {
+	db *DB
+}
+
+func (s *migrationStore) init(ctx context.Context) error {
+	_, err := s.db.exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version     INTEGER   PRIMARY KEY,
+		description TEXT      NOT NULL,
+		applied_at  TIMESTAMP NOT NULL
+	)`)
+
+	return errors.Wrap(err, "migrationStore.init")
+}
+
+func (s *migrationStore) Applied(ctx context.Context) ([]*migrate.Record, error) {
+	if err := s.init(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.query(ctx, "SELECT version, description, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, errors.Wrap(err, "migrationStore.Applied")
+	}
+	defer rows.Close()
+
+	records := []*migrate.Record{}
+	for rows.Next() {
+		record := &migrate.Record{}
+		if err := rows.Scan(&record.Version, &record.Description, &record.AppliedAt); err != nil {
+			return nil, errors.Wrap(err, "migrationStore.Applied")
+		}
+		records = append(records, record)
+	}
+
+	return records, errors.Wrap(rows.Err(), "migrationStore.Applied")
+}
+
+func (s *migrationStore) Insert(ctx context.Context, record *migrate.Record) error {
+	_, err := s.db.exec(ctx,
+		"INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, ?)",
+		record.Version, record.Description, record.AppliedAt.UTC().Truncate(time.Millisecond),
+	)
+
+	return errors.Wrap(err, "migrationStore.Insert")
+}
+
+func (s *migrationStore) Delete(ctx context.Context, version int) error {
+	_, err := s.db.exec(ctx, "DELETE FROM schema_migrations WHERE version = ?", version)
+
+	return errors.Wrap(err, "migrationStore.Delete")
+}
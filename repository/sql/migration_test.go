@@ -0,0 +1,59 @@
+package sqlrepo
+
+import (
+	"io/fs"
+	"path"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0002_add_isbn.up.sql":         {Data: []byte("ALTER TABLE books ADD isbn TEXT;")},
+		"m/0002_add_isbn.down.sql":       {Data: []byte("ALTER TABLE books DROP isbn;")},
+		"m/0001_create_catalog.up.sql":   {Data: []byte("CREATE TABLE authors (id TEXT);")},
+		"m/0001_create_catalog.down.sql": {Data: []byte("DROP TABLE authors;")},
+		"m/README.md":                    {Data: []byte("ignored")},
+	}
+
+	migrations, err := loadMigrations(fsys, "m", &DB{})
+	if err != nil {
+		t.Fatalf("loadMigrations() error = %v", err)
+	}
+
+	if len(migrations) != 2 {
+		t.Fatalf("loaded %d migrations, want 2", len(migrations))
+	}
+	for i, want := range []string{"create catalog", "add isbn"} {
+		m := migrations[i]
+		if m.Version != i+1 || m.Description != want || m.Up == nil || m.Down == nil {
+			t.Errorf("migration %d = %d %q", i, m.Version, m.Description)
+		}
+	}
+
+	if _, err := loadMigrations(fstest.MapFS{"m/init.up.sql": {}}, "m", &DB{}); err == nil {
+		t.Errorf("loadMigrations() error = nil for a file without version")
+	}
+}
+
+// Every dialect ships up and down scripts for each version.
+func TestEmbeddedMigrations(t *testing.T) {
+	for name := range dialects {
+		t.Run(name, func(t *testing.T) {
+			dir := path.Join("migrations", name)
+			if _, err := fs.Stat(migrationFiles, dir); err != nil {
+				t.Fatal(err)
+			}
+
+			migrations, err := loadMigrations(migrationFiles, dir, &DB{dialect: dialects[name]})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, m := range migrations {
+				if m.Version != i+1 || m.Up == nil || m.Down == nil {
+					t.Errorf("migration %d: version %d, up %v, down %v", i, m.Version, m.Up != nil, m.Down != nil)
+				}
+			}
+		})
+	}
+}
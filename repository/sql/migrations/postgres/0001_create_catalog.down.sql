@@ -0,0 +1,3 @@
+DROP TABLE users;
+DROP TABLE books;
+DROP TABLE authors;
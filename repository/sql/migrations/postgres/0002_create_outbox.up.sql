@@ -0,0 +1,16 @@
+CREATE TABLE outbox (
+    id              TEXT        PRIMARY KEY,
+    type            TEXT        NOT NULL,
+    entity_id       TEXT        NOT NULL,
+    actor           TEXT        NOT NULL,
+    timestamp       TIMESTAMPTZ NOT NULL,
+    payload         TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        INTEGER     NOT NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error      TEXT        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX outbox_status_next_attempt_at_idx ON outbox (status, next_attempt_at);
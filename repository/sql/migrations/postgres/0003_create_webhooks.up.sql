@@ -0,0 +1,26 @@
+-- event_types and attempts are JSON arrays.
+CREATE TABLE webhooks (
+    id          CHAR(24)    PRIMARY KEY,
+    url         TEXT        NOT NULL,
+    event_types TEXT        NOT NULL,
+    secret      TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id              TEXT        PRIMARY KEY,
+    webhook_id      CHAR(24)    NOT NULL,
+    event_id        TEXT        NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         TEXT        NOT NULL,
+    redelivery_of   TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        TEXT        NOT NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_created_at_idx ON webhook_deliveries (webhook_id, created_at DESC);
+CREATE INDEX webhook_deliveries_status_next_attempt_at_idx ON webhook_deliveries (status, next_attempt_at);
@@ -0,0 +1,5 @@
+ALTER TABLE books ADD COLUMN isbn10 TEXT NOT NULL DEFAULT '';
+ALTER TABLE books ADD COLUMN isbn13 TEXT NOT NULL DEFAULT '';
+
+-- Books without ISBN keep an empty string and are left out.
+CREATE UNIQUE INDEX books_isbn13_idx ON books (isbn13) WHERE isbn13 <> '';
@@ -0,0 +1,15 @@
+-- The ordered contributors of a book. books.author_id stays the first one.
+-- Deleting an author deletes their contributions, and a book whose first author
+-- is deleted is deleted with it.
+CREATE TABLE book_contributors (
+    book_id   CHAR(24)    NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    position  INTEGER     NOT NULL,
+    author_id CHAR(24)    NOT NULL REFERENCES authors (id) ON DELETE CASCADE,
+    role      TEXT        NOT NULL,
+    PRIMARY KEY (book_id, position)
+);
+
+CREATE INDEX book_contributors_author_id_idx ON book_contributors (author_id);
+
+INSERT INTO book_contributors (book_id, position, author_id, role)
+SELECT id, 0, author_id, 'author' FROM books;
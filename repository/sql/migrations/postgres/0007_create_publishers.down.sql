@@ -0,0 +1,5 @@
+DROP INDEX books_publisher_id_idx;
+
+ALTER TABLE books DROP COLUMN publisher_id;
+
+DROP TABLE publishers;
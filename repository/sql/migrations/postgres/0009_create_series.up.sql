@@ -0,0 +1,15 @@
+CREATE TABLE series (
+    id          CHAR(24)    PRIMARY KEY,
+    name        TEXT        NOT NULL,
+    description TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+-- A book is in at most one series, at a position unique within it. Books
+-- without series have a NULL series_id and position 0. A series cannot be
+-- deleted while it has books.
+ALTER TABLE books ADD COLUMN series_id CHAR(24) REFERENCES series (id);
+ALTER TABLE books ADD COLUMN series_position INTEGER NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX books_series_position_idx ON books (series_id, series_position);
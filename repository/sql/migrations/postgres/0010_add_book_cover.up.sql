@@ -0,0 +1,3 @@
+-- The current cover upload of a book, empty for a book without cover. The
+-- images themselves are kept in the blob store.
+ALTER TABLE books ADD COLUMN cover_id TEXT NOT NULL DEFAULT '';
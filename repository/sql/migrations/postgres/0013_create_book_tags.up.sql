@@ -0,0 +1,8 @@
+-- Tags are free-form labels of books, listed with their number of books.
+CREATE TABLE book_tags (
+    book_id CHAR(24) NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    tag     TEXT     NOT NULL,
+    PRIMARY KEY (book_id, tag)
+);
+
+CREATE INDEX book_tags_tag_idx ON book_tags (tag);
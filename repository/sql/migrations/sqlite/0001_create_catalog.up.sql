@@ -0,0 +1,37 @@
+CREATE TABLE authors (
+    id          CHAR(24)    PRIMARY KEY,
+    first_name  TEXT        NOT NULL,
+    last_name   TEXT        NOT NULL,
+    birth_date  TEXT        NOT NULL,
+    nationality TEXT        NOT NULL,
+    created_at  TIMESTAMP   NOT NULL,
+    updated_at  TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX authors_last_name_first_name_idx ON authors (last_name, first_name);
+
+-- An author cannot be deleted while they have books, as with Mongo.
+CREATE TABLE books (
+    id               CHAR(24)         PRIMARY KEY,
+    author_id        CHAR(24)         NOT NULL REFERENCES authors (id) ON DELETE RESTRICT,
+    name             TEXT             NOT NULL,
+    description      TEXT             NOT NULL,
+    publication_date TEXT             NOT NULL,
+    price            REAL             NOT NULL,
+    created_at       TIMESTAMP        NOT NULL,
+    updated_at       TIMESTAMP        NOT NULL
+);
+
+CREATE INDEX books_author_id_idx ON books (author_id);
+CREATE INDEX books_name_idx ON books (name);
+
+CREATE TABLE users (
+    id         CHAR(24)    PRIMARY KEY,
+    username   TEXT        NOT NULL UNIQUE,
+    password   TEXT        NOT NULL,
+    first_name TEXT        NOT NULL,
+    last_name  TEXT        NOT NULL,
+    role       TEXT        NOT NULL,
+    created_at TIMESTAMP   NOT NULL,
+    updated_at TIMESTAMP   NOT NULL
+);
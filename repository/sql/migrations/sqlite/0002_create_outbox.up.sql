@@ -0,0 +1,16 @@
+CREATE TABLE outbox (
+    id              TEXT        PRIMARY KEY,
+    type            TEXT        NOT NULL,
+    entity_id       TEXT        NOT NULL,
+    actor           TEXT        NOT NULL,
+    timestamp       TIMESTAMP   NOT NULL,
+    payload         TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        INTEGER     NOT NULL,
+    next_attempt_at TIMESTAMP   NOT NULL,
+    last_error      TEXT        NOT NULL,
+    created_at      TIMESTAMP   NOT NULL,
+    updated_at      TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX outbox_status_next_attempt_at_idx ON outbox (status, next_attempt_at);
@@ -0,0 +1,4 @@
+DROP INDEX books_isbn13_idx;
+
+ALTER TABLE books DROP COLUMN isbn13;
+ALTER TABLE books DROP COLUMN isbn10;
@@ -0,0 +1,18 @@
+-- A category cannot be deleted while it has subcategories or books.
+CREATE TABLE categories (
+    id         CHAR(24)    PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    parent_id  CHAR(24)    REFERENCES categories (id),
+    created_at TIMESTAMP   NOT NULL,
+    updated_at TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
+
+CREATE TABLE book_categories (
+    book_id     CHAR(24)    NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    category_id CHAR(24)    NOT NULL REFERENCES categories (id),
+    PRIMARY KEY (book_id, category_id)
+);
+
+CREATE INDEX book_categories_category_id_idx ON book_categories (category_id);
@@ -0,0 +1,14 @@
+CREATE TABLE publishers (
+    id         CHAR(24)    PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    country    TEXT        NOT NULL,
+    website    TEXT        NOT NULL,
+    created_at TIMESTAMP   NOT NULL,
+    updated_at TIMESTAMP   NOT NULL
+);
+
+-- The publisher of a book is optional. A publisher cannot be deleted while it
+-- has books.
+ALTER TABLE books ADD COLUMN publisher_id CHAR(24) REFERENCES publishers (id);
+
+CREATE INDEX books_publisher_id_idx ON books (publisher_id);
@@ -0,0 +1,17 @@
+-- The ordered editions of a book. Their ISBNs are unique like the ones of the
+-- books, which the repository checks across both tables.
+CREATE TABLE book_editions (
+    id               CHAR(24)         PRIMARY KEY,
+    book_id          CHAR(24)         NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    position         INTEGER          NOT NULL,
+    format           TEXT             NOT NULL,
+    isbn10           TEXT             NOT NULL,
+    isbn13           TEXT             NOT NULL,
+    page_count       INTEGER          NOT NULL,
+    duration_minutes INTEGER          NOT NULL,
+    price            REAL             NOT NULL,
+    publication_date TEXT             NOT NULL,
+    UNIQUE (book_id, position)
+);
+
+CREATE UNIQUE INDEX book_editions_isbn13_idx ON book_editions (isbn13) WHERE isbn13 <> '';
@@ -0,0 +1,7 @@
+DROP INDEX books_series_position_idx;
+
+ALTER TABLE books DROP COLUMN series_position;
+
+ALTER TABLE books DROP COLUMN series_id;
+
+DROP TABLE series;
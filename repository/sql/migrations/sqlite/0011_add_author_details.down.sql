@@ -0,0 +1,6 @@
+ALTER TABLE authors DROP COLUMN photo_id;
+ALTER TABLE authors DROP COLUMN viaf;
+ALTER TABLE authors DROP COLUMN isni;
+ALTER TABLE authors DROP COLUMN aliases;
+ALTER TABLE authors DROP COLUMN biography;
+ALTER TABLE authors DROP COLUMN death_date;
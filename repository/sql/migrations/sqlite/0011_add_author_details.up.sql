@@ -0,0 +1,8 @@
+-- Aliases are kept newline separated: they are single lines, and a search
+-- matching the column cannot run across two of them.
+ALTER TABLE authors ADD COLUMN death_date TEXT NOT NULL DEFAULT '';
+ALTER TABLE authors ADD COLUMN biography TEXT NOT NULL DEFAULT '';
+ALTER TABLE authors ADD COLUMN aliases TEXT NOT NULL DEFAULT '';
+ALTER TABLE authors ADD COLUMN isni TEXT NOT NULL DEFAULT '';
+ALTER TABLE authors ADD COLUMN viaf TEXT NOT NULL DEFAULT '';
+ALTER TABLE authors ADD COLUMN photo_id TEXT NOT NULL DEFAULT '';
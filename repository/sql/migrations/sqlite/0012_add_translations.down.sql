@@ -0,0 +1,5 @@
+DROP INDEX books_original_id_idx;
+ALTER TABLE authors DROP COLUMN translations;
+ALTER TABLE books DROP COLUMN translations;
+ALTER TABLE books DROP COLUMN original_id;
+ALTER TABLE books DROP COLUMN language;
@@ -0,0 +1,10 @@
+-- The language of a book is a BCP 47 tag, empty when unknown. A translated
+-- book references its original, NULL for an original work, which cannot be
+-- deleted while it has translations. The translations of the names and
+-- descriptions are JSON objects keyed by language tag.
+ALTER TABLE books ADD COLUMN language TEXT NOT NULL DEFAULT '';
+ALTER TABLE books ADD COLUMN original_id CHAR(24) REFERENCES books (id);
+ALTER TABLE books ADD COLUMN translations TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE authors ADD COLUMN translations TEXT NOT NULL DEFAULT '{}';
+
+CREATE INDEX books_original_id_idx ON books (original_id);
@@ -0,0 +1,116 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const outboxColumns = "id, type, entity_id, actor, timestamp, payload, status, attempts, next_attempt_at, last_error, created_at, updated_at"
+
+type outboxRepository struct {
+	db *DB
+}
+
+func NewOutboxRepository(db *DB) repository.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func scanOutboxMessage(row scanner) (*entities.OutboxMessage, error) {
+	e := &event.Event{}
+	msg := &entities.OutboxMessage{Event: e}
+	var payload string
+	err := row.Scan(
+		&e.Id, &e.Type, &e.EntityId, &e.Actor, &e.Timestamp, &payload,
+		&msg.Status, &msg.Attempts, &msg.NextAttemptAt, &msg.LastError, &msg.CreatedAt, &msg.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Id = e.Id
+	e.Timestamp = e.Timestamp.UTC()
+	if payload != "" {
+		e.Payload = json.RawMessage(payload)
+	}
+	msg.NextAttemptAt = msg.NextAttemptAt.UTC()
+	msg.CreatedAt = msg.CreatedAt.UTC()
+	msg.UpdatedAt = msg.UpdatedAt.UTC()
+
+	return msg, nil
+}
+
+// Publish adds e to the outbox. Called with a transaction context, the event
+// is only visible to the relay once the transaction commits.
+func (r *outboxRepository) Publish(ctx context.Context, e *event.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	now := now()
+	_, err := r.db.exec(ctx,
+		"INSERT INTO outbox ("+outboxColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		e.Id, e.Type, e.EntityId, e.Actor, e.Timestamp, string(e.Payload),
+		entities.OutboxPending, 0, now, "", now, now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "outboxRepository.Publish")
+	}
+
+	return nil
+}
+
+// Claim returns the oldest pending message due for delivery and hides it
+// from other relays for lease. It returns nil when nothing is due.
+func (r *outboxRepository) Claim(ctx context.Context, lease time.Duration) (*entities.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	now := now()
+	msg, err := scanOutboxMessage(r.db.queryRow(ctx,
+		`UPDATE outbox SET attempts = attempts + 1, next_attempt_at = ?, updated_at = ?
+		WHERE id = (
+			SELECT id FROM outbox WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at LIMIT 1`+r.db.dialect.skipLocked+`
+		)
+		RETURNING `+outboxColumns,
+		now.Add(lease), now, entities.OutboxPending, now,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "outboxRepository.Claim")
+	}
+
+	return msg, nil
+}
+
+func (r *outboxRepository) MarkDelivered(ctx context.Context, id string) error {
+	return r.update(ctx, id, "status = ?, last_error = ?", entities.OutboxDelivered, "")
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	return r.update(ctx, id, "next_attempt_at = ?, last_error = ?", retryAt.UTC(), cause.Error())
+}
+
+func (r *outboxRepository) MarkDead(ctx context.Context, id string, cause error) error {
+	return r.update(ctx, id, "status = ?, last_error = ?", entities.OutboxDead, cause.Error())
+}
+
+func (r *outboxRepository) update(ctx context.Context, id string, set string, args ...any) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	args = append(args, now(), id)
+	if _, err := r.db.exec(ctx, "UPDATE outbox SET "+set+", updated_at = ? WHERE id = ?", args...); err != nil {
+		return errors.Wrap(err, "outboxRepository.update")
+	}
+
+	return nil
+}
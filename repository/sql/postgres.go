@@ -0,0 +1,37 @@
+package sqlrepo
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func init() {
+	dialects["postgres"] = &dialect{
+		name:              "postgres",
+		driver:            "pgx",
+		numbered:          true,
+		skipLocked:        " FOR UPDATE SKIP LOCKED",
+		lower:             "LOWER",
+		isUniqueViolation: pgErrorCode("23505"),
+		uniqueConstraint:  pgConstraint,
+		isForeignKeyError: pgErrorCode("23503"),
+	}
+}
+
+func pgConstraint(err error) string {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return ""
+	}
+
+	return pgErr.ConstraintName
+}
+
+func pgErrorCode(code string) func(err error) bool {
+	return func(err error) bool {
+		var pgErr *pgconn.PgError
+		return errors.As(err, &pgErr) && pgErr.Code == code
+	}
+}
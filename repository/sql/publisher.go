@@ -0,0 +1,136 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const publisherColumns = "id, name, country, website, created_at, updated_at"
+
+type publisherRepository struct {
+	db *DB
+}
+
+func NewPublisherRepository(db *DB) repository.PublisherRepository {
+	return &publisherRepository{db: db}
+}
+
+func scanPublisher(row scanner) (*entities.Publisher, error) {
+	publisher := &entities.Publisher{}
+	err := row.Scan(
+		&publisher.Id, &publisher.Name, &publisher.Country, &publisher.Website,
+		&publisher.CreatedAt, &publisher.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	publisher.CreatedAt = publisher.CreatedAt.UTC()
+	publisher.UpdatedAt = publisher.UpdatedAt.UTC()
+
+	return publisher, nil
+}
+
+func (r *publisherRepository) Store(ctx context.Context, publisher *entities.Publisher) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	id := newObjectId()
+	now := now()
+	_, err := r.db.exec(ctx,
+		"INSERT INTO publishers ("+publisherColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		id, publisher.Name, publisher.Country, publisher.Website, now, now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "publisherRepository.Store")
+	}
+
+	publisher.Id = id
+	publisher.CreatedAt = now
+	publisher.UpdatedAt = now
+
+	return nil
+}
+
+func (r *publisherRepository) Update(ctx context.Context, publisher *entities.Publisher) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(publisher.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	_, err := r.db.exec(ctx,
+		"UPDATE publishers SET name = ?, country = ?, website = ?, updated_at = ? WHERE id = ?",
+		publisher.Name, publisher.Country, publisher.Website, now(), publisher.Id,
+	)
+	if err != nil {
+		return errors.Wrap(err, "publisherRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *publisherRepository) Find(ctx context.Context, id string) (*entities.Publisher, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse publisher ID to ObjectID.", err)
+	}
+
+	publisher, err := scanPublisher(r.db.queryRow(ctx, "SELECT "+publisherColumns+" FROM publishers WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, portError.NewNotFoundError("Publisher not found.", err)
+		}
+		return nil, errors.Wrap(err, "publisherRepository.Find")
+	}
+
+	return publisher, nil
+}
+
+func (r *publisherRepository) FindAll(ctx context.Context) ([]*entities.Publisher, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, "SELECT "+publisherColumns+" FROM publishers ORDER BY id")
+	if err != nil {
+		return nil, errors.Wrap(err, "publisherRepository.FindAll")
+	}
+	defer rows.Close()
+
+	publishers := []*entities.Publisher{}
+	for rows.Next() {
+		publisher, err := scanPublisher(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "publisherRepository.FindAll")
+		}
+		publishers = append(publishers, publisher)
+	}
+
+	return publishers, errors.Wrap(rows.Err(), "publisherRepository.FindAll")
+}
+
+// Delete fails with a conflict while the publisher has books.
+func (r *publisherRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse publisher ID to ObjectID", err)
+	}
+
+	if _, err := r.db.exec(ctx, "DELETE FROM publishers WHERE id = ?", id); err != nil {
+		if r.db.dialect.isForeignKeyError(err) {
+			return portError.NewConflictError("Publisher has books.", err)
+		}
+		return errors.Wrap(err, "publisherRepository.Delete")
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const seriesColumns = "id, name, description, created_at, updated_at"
+
+type seriesRepository struct {
+	db *DB
+}
+
+func NewSeriesRepository(db *DB) repository.SeriesRepository {
+	return &seriesRepository{db: db}
+}
+
+func scanSeries(row scanner) (*entities.Series, error) {
+	series := &entities.Series{}
+	err := row.Scan(&series.Id, &series.Name, &series.Description, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	series.CreatedAt = series.CreatedAt.UTC()
+	series.UpdatedAt = series.UpdatedAt.UTC()
+
+	return series, nil
+}
+
+func (r *seriesRepository) Store(ctx context.Context, series *entities.Series) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	id := newObjectId()
+	now := now()
+	_, err := r.db.exec(ctx,
+		"INSERT INTO series ("+seriesColumns+") VALUES (?, ?, ?, ?, ?)",
+		id, series.Name, series.Description, now, now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "seriesRepository.Store")
+	}
+
+	series.Id = id
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	return nil
+}
+
+func (r *seriesRepository) Update(ctx context.Context, series *entities.Series) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(series.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	_, err := r.db.exec(ctx,
+		"UPDATE series SET name = ?, description = ?, updated_at = ? WHERE id = ?",
+		series.Name, series.Description, now(), series.Id,
+	)
+	if err != nil {
+		return errors.Wrap(err, "seriesRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *seriesRepository) Find(ctx context.Context, id string) (*entities.Series, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse series ID to ObjectID.", err)
+	}
+
+	series, err := scanSeries(r.db.queryRow(ctx, "SELECT "+seriesColumns+" FROM series WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, portError.NewNotFoundError("Series not found.", err)
+		}
+		return nil, errors.Wrap(err, "seriesRepository.Find")
+	}
+
+	return series, nil
+}
+
+func (r *seriesRepository) FindAll(ctx context.Context) ([]*entities.Series, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, "SELECT "+seriesColumns+" FROM series ORDER BY id")
+	if err != nil {
+		return nil, errors.Wrap(err, "seriesRepository.FindAll")
+	}
+	defer rows.Close()
+
+	list := []*entities.Series{}
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "seriesRepository.FindAll")
+		}
+		list = append(list, series)
+	}
+
+	return list, errors.Wrap(rows.Err(), "seriesRepository.FindAll")
+}
+
+// Delete fails with a conflict while the series has books.
+func (r *seriesRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse series ID to ObjectID", err)
+	}
+
+	if _, err := r.db.exec(ctx, "DELETE FROM series WHERE id = ?", id); err != nil {
+		if r.db.dialect.isForeignKeyError(err) {
+			return portError.NewConflictError("Series has books.", err)
+		}
+		return errors.Wrap(err, "seriesRepository.Delete")
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package sqlrepo
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+func init() {
+	dialects["sqlite"] = &dialect{
+		name:   "sqlite",
+		driver: "sqlite",
+		// SQLite has a single writer: one connection avoids "database is
+		// locked" errors, and is required for :memory: where every
+		// connection would open its own empty database.
+		maxOpenConns:      1,
+		dsn:               sqliteDSN,
+		lower:             "unicode_lower",
+		isUniqueViolation: sqliteErrorCode(sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY),
+		uniqueConstraint:  sqliteConstraint,
+		// An ON DELETE RESTRICT violation is reported as a trigger error.
+		isForeignKeyError: sqliteErrorCode(sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY, sqlite3.SQLITE_CONSTRAINT_TRIGGER),
+	}
+
+	sqlite.MustRegisterDeterministicScalarFunction("unicode_lower", 1, unicodeLower)
+}
+
+// unicodeLower lowers the case of text like strings.ToLower. The SQLite LOWER
+// only lowers ASCII letters.
+func unicodeLower(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	switch v := args[0].(type) {
+	case string:
+		return strings.ToLower(v), nil
+	case []byte:
+		return strings.ToLower(string(v)), nil
+	default:
+		return v, nil
+	}
+}
+
+// sqliteDSN enables the foreign keys, off by default in SQLite, and stores
+// the times in a format that sorts as text.
+func sqliteDSN(dsn string) string {
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+
+	return dsn + sep + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite"
+}
+
+// sqliteConstraint returns the columns of a message like "constraint failed:
+// UNIQUE constraint failed: books.series_id, books.series_position (2067)".
+func sqliteConstraint(err error) string {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return ""
+	}
+
+	_, columns, ok := strings.Cut(sqliteErr.Error(), "UNIQUE constraint failed: ")
+	if !ok {
+		return ""
+	}
+	if i := strings.LastIndex(columns, " ("); i >= 0 {
+		columns = columns[:i]
+	}
+
+	return columns
+}
+
+func sqliteErrorCode(codes ...int) func(err error) bool {
+	return func(err error) bool {
+		var sqliteErr *sqlite.Error
+		if !errors.As(err, &sqliteErr) {
+			return false
+		}
+
+		for _, code := range codes {
+			if sqliteErr.Code() == code {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,219 @@
+package sqlrepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"bookstore.com/domain/entity"
+	"bookstore.com/domain/event"
+	portError "bookstore.com/port/error"
+	"bookstore.com/test"
+)
+
+func openSQLite(t *testing.T) *DB {
+	t.Helper()
+
+	db, err := Open("sqlite", ":memory:", 5)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	return db
+}
+
+func apiStatus(err error) int {
+	var apiErr *portError.ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+
+	return 0
+}
+
+func TestSQLite_bookConstraintError(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	authorRepo := NewAuthorRepository(db)
+	bookRepo := NewBookRepository(db).(*bookRepository)
+
+	author := &entity.Author{FirstName: test.AuthorFirstName1, LastName: test.AuthorLastName1}
+	if err := authorRepo.Store(ctx, author); err != nil {
+		t.Fatal(err)
+	}
+	series := &entity.Series{Name: test.SeriesName1}
+	if err := NewSeriesRepository(db).Store(ctx, series); err != nil {
+		t.Fatal(err)
+	}
+	first, err := bookRepo.Store(ctx, &entity.Book{AuthorId: author.Id, Name: test.BookName1, ISBN13: test.ISBN13_1, SeriesId: series.Id, SeriesPosition: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := bookRepo.Store(ctx, &entity.Book{AuthorId: author.Id, Name: test.BookName1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		args        []any
+		wantMessage string
+	}{
+		{
+			name:        "series position",
+			query:       "UPDATE books SET series_id = ?, series_position = 1 WHERE id = ?",
+			args:        []any{series.Id, second.Id},
+			wantMessage: "A book already has this position in the series.",
+		},
+		{
+			name:        "isbn",
+			query:       "UPDATE books SET isbn13 = ? WHERE id = ?",
+			args:        []any{first.ISBN13, second.Id},
+			wantMessage: "A book with this ISBN already exists.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := db.exec(ctx, tt.query, tt.args...)
+			if err == nil {
+				t.Fatalf("exec() succeeded, want a unique violation")
+			}
+
+			var apiErr *portError.ApiError
+			if !errors.As(bookRepo.constraintError(err), &apiErr) || apiErr.Message != tt.wantMessage {
+				t.Errorf("constraintError(%v) = %v, want %q", err, apiErr, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestSQLite_books(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	authorRepo := NewAuthorRepository(db)
+	bookRepo := NewBookRepository(db)
+
+	author := &entity.Author{FirstName: test.AuthorFirstName1, LastName: test.AuthorLastName1}
+	if err := authorRepo.Store(ctx, author); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := bookRepo.Store(ctx, &entity.Book{AuthorId: author.Id, Name: test.BookName1, Price: 9.99})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book, err := bookRepo.Find(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if book.Author == nil || book.Author.Id != author.Id || book.Price != 9.99 || !book.CreatedAt.Equal(stored.CreatedAt) {
+		t.Errorf("Find() = %+v, want stored book joined with its author", book)
+	}
+
+	if _, err := bookRepo.Store(ctx, &entity.Book{AuthorId: stored.Id}); apiStatus(err) != http.StatusNotFound {
+		t.Errorf("Store() with unknown author error = %v, want not found", err)
+	}
+
+	if err := authorRepo.Delete(ctx, author.Id); apiStatus(err) != http.StatusConflict {
+		t.Errorf("Delete() of an author with books error = %v, want conflict", err)
+	}
+	if books, _ := bookRepo.FindAll(ctx); len(books) != 1 {
+		t.Errorf("FindAll() = %d books, want the book of the kept author", len(books))
+	}
+}
+
+func TestSQLite_transactionAndOutbox(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	tx := NewTransactor(db)
+	authorRepo := NewAuthorRepository(db)
+	outboxRepo := NewOutboxRepository(db)
+
+	err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := authorRepo.Store(ctx, &entity.Author{FirstName: "rolled back"}); err != nil {
+			return err
+		}
+		return errors.New("error occur")
+	})
+	if err == nil {
+		t.Fatal("WithinTransaction() error = nil")
+	}
+	if authors, _ := authorRepo.FindAll(ctx); len(authors) != 0 {
+		t.Errorf("authors after rollback = %d", len(authors))
+	}
+
+	e, _ := event.New(ctx, event.BookCreated, "book-1", map[string]string{"name": test.BookName1})
+	if err := outboxRepo.Publish(ctx, e); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := outboxRepo.Claim(ctx, time.Minute)
+	if err != nil || msg == nil || msg.Id != e.Id || msg.Attempts != 1 || string(msg.Event.Payload) != string(e.Payload) {
+		t.Fatalf("Claim() = %+v, %v", msg, err)
+	}
+	if again, _ := outboxRepo.Claim(ctx, time.Minute); again != nil {
+		t.Errorf("leased message claimed again")
+	}
+
+	if err := outboxRepo.MarkFailed(ctx, e.Id, errors.New("error occur"), time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if msg, _ := outboxRepo.Claim(ctx, time.Minute); msg == nil || msg.Attempts != 2 || msg.LastError != "error occur" {
+		t.Errorf("Claim() after failure = %+v", msg)
+	}
+}
+
+func TestSQLite_users(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewUserRepository(openSQLite(t))
+
+	if user, err := userRepo.Find(ctx, "alice"); user != nil || err != nil {
+		t.Errorf("Find() of unknown user = %v, %v", user, err)
+	}
+
+	if err := userRepo.Store(ctx, &entity.User{Username: "alice", Role: entity.RoleUser}); err != nil {
+		t.Fatal(err)
+	}
+	if err := userRepo.Store(ctx, &entity.User{Username: "alice"}); apiStatus(err) != http.StatusBadRequest {
+		t.Errorf("Store() of duplicate user error = %v, want bad request", err)
+	}
+
+	if user, err := userRepo.Find(ctx, "alice"); err != nil || user.Role != entity.RoleUser || user.Id == "" {
+		t.Errorf("Find() = %+v, %v", user, err)
+	}
+}
+
+func TestSQLite_migrationsDown(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+
+	reverted, err := migrator.Down(ctx, 100)
+	if err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if len(reverted) == 0 {
+		t.Fatal("Down() reverted no migration")
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up() after Down() error = %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const userColumns = "id, username, password, first_name, last_name, role, created_at, updated_at"
+
+type userRepository struct {
+	db *DB
+}
+
+func NewUserRepository(db *DB) repository.UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) Store(ctx context.Context, user *entities.User) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	now := now()
+	_, err := r.db.exec(ctx,
+		"INSERT INTO users ("+userColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		newObjectId(), user.Username, user.Password, user.FirstName, user.LastName, user.Role, now, now,
+	)
+	if r.db.dialect.isUniqueViolation(err) {
+		return portError.NewBadRequestError("User is exist.", err)
+	}
+	if err != nil {
+		return errors.Wrap(err, "userRepository.Store")
+	}
+
+	return nil
+}
+
+// Find returns the user named username, or nil when there is none.
+func (r *userRepository) Find(ctx context.Context, username string) (*entities.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	user := &entities.User{}
+	err := r.db.queryRow(ctx, "SELECT "+userColumns+" FROM users WHERE username = ?", username).Scan(
+		&user.Id, &user.Username, &user.Password, &user.FirstName, &user.LastName, &user.Role,
+		&user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "userRepository.Find")
+	}
+	user.CreatedAt = user.CreatedAt.UTC()
+	user.UpdatedAt = user.UpdatedAt.UTC()
+
+	return user, nil
+}
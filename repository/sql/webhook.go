@@ -0,0 +1,167 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const webhookColumns = "id, url, event_types, secret, created_at, updated_at"
+
+type webhookRepository struct {
+	db *DB
+}
+
+func NewWebhookRepository(db *DB) repository.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func scanWebhook(row scanner) (*entities.Webhook, error) {
+	webhook := &entities.Webhook{}
+	var eventTypes string
+	err := row.Scan(&webhook.Id, &webhook.URL, &eventTypes, &webhook.Secret, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(eventTypes), &webhook.EventTypes); err != nil {
+		return nil, err
+	}
+	webhook.CreatedAt = webhook.CreatedAt.UTC()
+	webhook.UpdatedAt = webhook.UpdatedAt.UTC()
+
+	return webhook, nil
+}
+
+func (r *webhookRepository) Store(ctx context.Context, webhook *entities.Webhook) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepository.Store")
+	}
+
+	id := newObjectId()
+	now := now()
+	_, err = r.db.exec(ctx,
+		"INSERT INTO webhooks ("+webhookColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		id, webhook.URL, string(eventTypes), webhook.Secret, now, now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepository.Store")
+	}
+
+	webhook.Id = id
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	return nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *entities.Webhook) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(webhook.Id); err != nil {
+		return portError.NewBadRequestError("Unable to parse webhook ID to ObjectID.", err)
+	}
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepository.Update")
+	}
+
+	_, err = r.db.exec(ctx,
+		"UPDATE webhooks SET url = ?, event_types = ?, secret = ?, updated_at = ? WHERE id = ?",
+		webhook.URL, string(eventTypes), webhook.Secret, now(), webhook.Id,
+	)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepository.Update")
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) Find(ctx context.Context, id string) (*entities.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return nil, portError.NewBadRequestError("Unable to parse webhook ID to ObjectID.", err)
+	}
+
+	webhook, err := scanWebhook(r.db.queryRow(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, portError.NewNotFoundError("Webhook not found.", err)
+		}
+		return nil, errors.Wrap(err, "webhookRepository.Find")
+	}
+
+	return webhook, nil
+}
+
+func (r *webhookRepository) FindAll(ctx context.Context) ([]*entities.Webhook, error) {
+	return r.find(ctx, "")
+}
+
+// FindByEventType filters the webhooks in Go: event_types is a JSON array
+// and there are few webhooks.
+func (r *webhookRepository) FindByEventType(ctx context.Context, eventType string) ([]*entities.Webhook, error) {
+	return r.find(ctx, eventType)
+}
+
+func (r *webhookRepository) find(ctx context.Context, eventType string) ([]*entities.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, "SELECT "+webhookColumns+" FROM webhooks ORDER BY id")
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookRepository.find")
+	}
+	defer rows.Close()
+
+	webhooks := []*entities.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "webhookRepository.find")
+		}
+		if eventType == "" || contains(webhook.EventTypes, eventType) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, errors.Wrap(rows.Err(), "webhookRepository.find")
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	if err := validObjectId(id); err != nil {
+		return portError.NewBadRequestError("unable to parse webhook ID to ObjectID", err)
+	}
+
+	if _, err := r.db.exec(ctx, "DELETE FROM webhooks WHERE id = ?", id); err != nil {
+		return errors.Wrap(err, "webhookRepository.Delete")
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	entities "bookstore.com/domain/entity"
+	portError "bookstore.com/port/error"
+	"bookstore.com/repository"
+	"github.com/pkg/errors"
+)
+
+const deliveryColumns = "id, webhook_id, event_id, event_type, payload, redelivery_of, status, attempts, next_attempt_at, created_at, updated_at"
+
+type webhookDeliveryRepository struct {
+	db *DB
+}
+
+func NewWebhookDeliveryRepository(db *DB) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func scanDelivery(row scanner) (*entities.WebhookDelivery, error) {
+	delivery := &entities.WebhookDelivery{}
+	var attempts string
+	err := row.Scan(
+		&delivery.Id, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Payload,
+		&delivery.RedeliveryOf, &delivery.Status, &attempts, &delivery.NextAttemptAt,
+		&delivery.CreatedAt, &delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(attempts), &delivery.Attempts); err != nil {
+		return nil, err
+	}
+	delivery.NextAttemptAt = delivery.NextAttemptAt.UTC()
+	delivery.CreatedAt = delivery.CreatedAt.UTC()
+	delivery.UpdatedAt = delivery.UpdatedAt.UTC()
+
+	return delivery, nil
+}
+
+func (r *webhookDeliveryRepository) Store(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	now := now()
+	delivery.Status = entities.DeliveryPending
+	delivery.Attempts = []*entities.DeliveryAttempt{}
+	delivery.NextAttemptAt = now
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	_, err := r.db.exec(ctx,
+		"INSERT INTO webhook_deliveries ("+deliveryColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		delivery.Id, delivery.WebhookId, delivery.EventId, delivery.EventType, delivery.Payload,
+		delivery.RedeliveryOf, delivery.Status, "[]", now, now, now,
+	)
+	if err != nil {
+		if r.db.dialect.isUniqueViolation(err) {
+			return nil
+		}
+		return errors.Wrap(err, "webhookDeliveryRepository.Store")
+	}
+
+	return nil
+}
+
+func (r *webhookDeliveryRepository) Find(ctx context.Context, id string) (*entities.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	delivery, err := scanDelivery(r.db.queryRow(ctx, "SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, portError.NewNotFoundError("Webhook delivery not found.", err)
+		}
+		return nil, errors.Wrap(err, "webhookDeliveryRepository.Find")
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookDeliveryRepository) FindByWebhook(ctx context.Context, webhookId string) ([]*entities.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	rows, err := r.db.query(ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC",
+		webhookId,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookDeliveryRepository.FindByWebhook")
+	}
+	defer rows.Close()
+
+	deliveries := []*entities.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "webhookDeliveryRepository.FindByWebhook")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, errors.Wrap(rows.Err(), "webhookDeliveryRepository.FindByWebhook")
+}
+
+// Claim returns the oldest pending delivery due and hides it from other
+// workers for lease. It returns nil when nothing is due.
+func (r *webhookDeliveryRepository) Claim(ctx context.Context, lease time.Duration) (*entities.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	now := now()
+	delivery, err := scanDelivery(r.db.queryRow(ctx,
+		`UPDATE webhook_deliveries SET next_attempt_at = ?, updated_at = ?
+		WHERE id = (
+			SELECT id FROM webhook_deliveries WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at LIMIT 1`+r.db.dialect.skipLocked+`
+		)
+		RETURNING `+deliveryColumns,
+		now.Add(lease), now, entities.DeliveryPending, now,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "webhookDeliveryRepository.Claim")
+	}
+
+	return delivery, nil
+}
+
+// RecordAttempt appends attempt to the delivery. The delivery is leased to
+// the caller by Claim, so reading and rewriting the attempts is safe.
+func (r *webhookDeliveryRepository) RecordAttempt(
+	ctx context.Context,
+	id string,
+	attempt *entities.DeliveryAttempt,
+	status string,
+	nextAttemptAt time.Time,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, r.db.timeout)
+	defer cancel()
+
+	delivery, err := r.Find(ctx, id)
+	if err != nil {
+		var apiErr *portError.ApiError
+		if errors.As(err, &apiErr) {
+			return nil
+		}
+		return err
+	}
+
+	attempts, err := json.Marshal(append(delivery.Attempts, attempt))
+	if err != nil {
+		return errors.Wrap(err, "webhookDeliveryRepository.RecordAttempt")
+	}
+
+	_, err = r.db.exec(ctx,
+		"UPDATE webhook_deliveries SET attempts = ?, status = ?, next_attempt_at = ?, updated_at = ? WHERE id = ?",
+		string(attempts), status, nextAttemptAt.UTC(), now(), id,
+	)
+	if err != nil {
+		return errors.Wrap(err, "webhookDeliveryRepository.RecordAttempt")
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import "time"
 
 const (
 	AuthorId1          = "64fbf00fc3a88d3a02b964dc"
+	AuthorId2          = "64fbf00fc3a88d3a02b964dd"
 	AuthorFirstName1   = "author firstname 1"
 	AuthorLastName1    = "author lastname 1"
 	AuthorBirthDate1   = "1985-04-04"
@@ -12,11 +13,35 @@ const (
 
 const (
 	BookId1          = "8sfbf00fc3a3jd3a02b964ds"
+	BookId2          = "64fbf00fc3a88d3a02b964de"
 	BookName1        = "book name 1"
 	BookDescription1 = "description 1"
 	PublicationDate1 = "1992-01-01"
 	Price1           = 12.12
 	InvalidPrice1    = -1
+	ISBN10_1         = "0306406152"
+	ISBN13_1         = "9780306406157"
+	ISBN13_2         = "9780262033848"
+)
+
+const (
+	PublisherId1      = "64fbf00fc3a88d3a02b96601"
+	PublisherName1    = "publisher name 1"
+	PublisherCountry1 = "Viet Nam"
+	PublisherWebsite1 = "https://publisher.example.com"
+)
+
+const (
+	SeriesId1          = "64fbf00fc3a88d3a02b96801"
+	SeriesName1        = "series name 1"
+	SeriesDescription1 = "series description 1"
+)
+
+const (
+	CategoryId1   = "64fbf00fc3a88d3a02b96501"
+	CategoryId2   = "64fbf00fc3a88d3a02b96502"
+	CategoryId3   = "64fbf00fc3a88d3a02b96503"
+	CategoryName1 = "category name 1"
 )
 
 const (
@@ -0,0 +1,162 @@
+// Package isbn validates ISBN-10 and ISBN-13 numbers and converts between
+// them. Hyphens and spaces are ignored; the results are bare digits, with an
+// upper case X check digit for ISBN-10.
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidLength   = errors.New("invalid length, want 10 or 13 digits")
+	ErrInvalidLength10 = errors.New("invalid length, want 10 digits")
+	ErrInvalidLength13 = errors.New("invalid length, want 13 digits")
+	ErrInvalidChar     = errors.New("invalid character")
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	ErrNoISBN10        = errors.New("only 978 ISBN-13 have an ISBN-10")
+)
+
+// Parse validates an ISBN-10 or ISBN-13 and returns both forms. isbn10 is
+// empty for an ISBN-13 outside the 978 prefix.
+func Parse(s string) (isbn10, isbn13 string, err error) {
+	s = clean(s)
+	switch len(s) {
+	case 10:
+		if err := validate10(s); err != nil {
+			return "", "", err
+		}
+		return s, to13(s), nil
+	case 13:
+		if err := validate13(s); err != nil {
+			return "", "", err
+		}
+		isbn10, _ = To10(s)
+		return isbn10, s, nil
+	default:
+		return "", "", ErrInvalidLength
+	}
+}
+
+// Normalize10 validates an ISBN-10 and returns its bare form.
+func Normalize10(s string) (string, error) {
+	s = clean(s)
+	if len(s) != 10 {
+		return "", ErrInvalidLength10
+	}
+	if err := validate10(s); err != nil {
+		return "", err
+	}
+
+	return s, nil
+}
+
+// Normalize13 validates an ISBN-13 and returns its bare form.
+func Normalize13(s string) (string, error) {
+	s = clean(s)
+	if len(s) != 13 {
+		return "", ErrInvalidLength13
+	}
+	if err := validate13(s); err != nil {
+		return "", err
+	}
+
+	return s, nil
+}
+
+// To13 converts a valid ISBN-10 to its ISBN-13.
+func To13(s string) (string, error) {
+	s, err := Normalize10(s)
+	if err != nil {
+		return "", err
+	}
+
+	return to13(s), nil
+}
+
+// To10 converts a valid 978 ISBN-13 to its ISBN-10.
+func To10(s string) (string, error) {
+	s, err := Normalize13(s)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(s, "978") {
+		return "", ErrNoISBN10
+	}
+
+	body := s[3:12]
+	return body + checkDigit10(body), nil
+}
+
+func clean(s string) string {
+	s = strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(s))
+	return strings.ToUpper(s)
+}
+
+func to13(isbn10 string) string {
+	body := "978" + isbn10[:9]
+	return body + checkDigit13(body)
+}
+
+func validate10(s string) error {
+	for i := 0; i < 9; i++ {
+		if !isDigit(s[i]) {
+			return ErrInvalidChar
+		}
+	}
+	if !isDigit(s[9]) && s[9] != 'X' {
+		return ErrInvalidChar
+	}
+	if checkDigit10(s[:9]) != s[9:] {
+		return ErrInvalidChecksum
+	}
+
+	return nil
+}
+
+func validate13(s string) error {
+	for i := 0; i < 13; i++ {
+		if !isDigit(s[i]) {
+			return ErrInvalidChar
+		}
+	}
+	if checkDigit13(s[:12]) != s[12:] {
+		return ErrInvalidChecksum
+	}
+
+	return nil
+}
+
+// checkDigit10 returns the check digit of the first 9 digits of an ISBN-10:
+// the weighted sum 10..2 plus the check digit is a multiple of 11.
+func checkDigit10(body string) string {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(body[i]-'0') * (10 - i)
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return "X"
+	}
+	return string(rune('0' + check))
+}
+
+// checkDigit13 returns the check digit of the first 12 digits of an ISBN-13:
+// digits are weighted 1 and 3 alternately and the sum is a multiple of 10.
+func checkDigit13(body string) string {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(body[i]-'0') * weight
+	}
+
+	return string(rune('0' + (10-sum%10)%10))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,94 @@
+package isbn
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want10  string
+		want13  string
+		wantErr error
+	}{
+		{
+			name:   "isbn-10",
+			s:      "0306406152",
+			want10: "0306406152",
+			want13: "9780306406157",
+		},
+		{
+			name:   "isbn-10 with hyphens and X check digit",
+			s:      "0-8044-2957-x",
+			want10: "080442957X",
+			want13: "9780804429573",
+		},
+		{
+			name:   "isbn-13",
+			s:      "978-0-306-40615-7",
+			want10: "0306406152",
+			want13: "9780306406157",
+		},
+		{
+			name:   "isbn-13 without isbn-10",
+			s:      "979 10 90636 07 1",
+			want13: "9791090636071",
+		},
+		{
+			name:    "invalid isbn-10 checksum",
+			s:       "0306406153",
+			wantErr: ErrInvalidChecksum,
+		},
+		{
+			name:    "invalid isbn-13 checksum",
+			s:       "9780306406158",
+			wantErr: ErrInvalidChecksum,
+		},
+		{
+			name:    "X inside isbn-10",
+			s:       "03064X6152",
+			wantErr: ErrInvalidChar,
+		},
+		{
+			name:    "X in isbn-13",
+			s:       "978030640615X",
+			wantErr: ErrInvalidChar,
+		},
+		{
+			name:    "invalid length",
+			s:       "978030640615",
+			wantErr: ErrInvalidLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got10, got13, err := Parse(tt.s)
+			if err != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got10 != tt.want10 || got13 != tt.want13 {
+				t.Errorf("Parse() = %q, %q, want %q, %q", got10, got13, tt.want10, tt.want13)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	if got, err := To13("080442957X"); err != nil || got != "9780804429573" {
+		t.Errorf("To13() = %q, %v", got, err)
+	}
+	if got, err := To10("9780804429573"); err != nil || got != "080442957X" {
+		t.Errorf("To10() = %q, %v", got, err)
+	}
+	if _, err := To10("9791090636071"); err != ErrNoISBN10 {
+		t.Errorf("To10() of a 979 isbn error = %v, want %v", err, ErrNoISBN10)
+	}
+	if _, err := To13("9780804429573"); err != ErrInvalidLength10 {
+		t.Errorf("To13() of an isbn-13 error = %v, want %v", err, ErrInvalidLength10)
+	}
+	if _, err := Normalize13("0306406152"); err != ErrInvalidLength13 {
+		t.Errorf("Normalize13() of an isbn-10 error = %v, want %v", err, ErrInvalidLength13)
+	}
+	if got, err := Normalize10(" 0-306-40615-2 "); err != nil || got != "0306406152" {
+		t.Errorf("Normalize10() = %q, %v", got, err)
+	}
+}
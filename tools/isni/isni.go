@@ -0,0 +1,45 @@
+// Package isni validates International Standard Name Identifiers. Spaces and
+// hyphens are ignored; the result is bare digits, with an upper case X check
+// digit.
+package isni
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidLength   = errors.New("invalid length, want 16 digits")
+	ErrInvalidChar     = errors.New("invalid character")
+	ErrInvalidChecksum = errors.New("invalid checksum")
+)
+
+// Normalize validates an ISNI and returns its bare form.
+func Normalize(s string) (string, error) {
+	s = strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(s))
+	if len(s) != 16 {
+		return "", ErrInvalidLength
+	}
+
+	// ISO 7064 MOD 11-2 over the first 15 digits.
+	total := 0
+	for i := 0; i < 15; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return "", ErrInvalidChar
+		}
+		total = (total + int(s[i]-'0')) * 2
+	}
+
+	check := byte('0' + (12-total%11)%11)
+	if check == '0'+10 {
+		check = 'X'
+	}
+	if s[15] != check {
+		if s[15] != 'X' && (s[15] < '0' || s[15] > '9') {
+			return "", ErrInvalidChar
+		}
+		return "", ErrInvalidChecksum
+	}
+
+	return s, nil
+}
@@ -0,0 +1,54 @@
+package isni
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "isni",
+			s:    "0000000121032683",
+			want: "0000000121032683",
+		},
+		{
+			name: "isni with spaces and x check digit",
+			s:    "0000 0001 2281 955x",
+			want: "000000012281955X",
+		},
+		{
+			name:    "invalid checksum",
+			s:       "0000000121032684",
+			wantErr: ErrInvalidChecksum,
+		},
+		{
+			name:    "invalid character",
+			s:       "00000001210326A3",
+			wantErr: ErrInvalidChar,
+		},
+		{
+			name:    "invalid check character",
+			s:       "000000012103268Y",
+			wantErr: ErrInvalidChar,
+		},
+		{
+			name:    "invalid length",
+			s:       "000000012103268",
+			wantErr: ErrInvalidLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.s)
+			if err != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package locale handles the BCP 47 language tags of the localized catalog
+// content and the language negotiated for a request. Tags are kept in their
+// canonical form, e.g. "pt-BR" for "pt_br".
+package locale
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var ErrInvalidTag = errors.New("invalid language tag")
+
+// Normalize validates a BCP 47 language tag and returns its canonical form.
+func Normalize(s string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(s))
+	if err != nil || tag == language.Und {
+		return "", ErrInvalidTag
+	}
+
+	return tag.String(), nil
+}
+
+// ParseAcceptLanguage returns the tags of an Accept-Language header by
+// decreasing quality. The wildcard and the invalid tags are left out, an
+// unparsable header is none.
+func ParseAcceptLanguage(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	// The wildcard is parsed as "mul", multiple languages.
+	list := []string{}
+	for _, tag := range tags {
+		if tag != language.Und && tag.String() != "mul" {
+			list = append(list, tag.String())
+		}
+	}
+
+	return list
+}
+
+// Fallbacks returns tag followed by its less specific forms, as the lookup of
+// RFC 4647: "zh-Hant-TW", "zh-Hant", "zh".
+func Fallbacks(tag string) []string {
+	list := []string{tag}
+	subtags := strings.Split(tag, "-")
+	for n := len(subtags) - 1; n > 0; n-- {
+		// An extension singleton is never left last.
+		if len(subtags[n-1]) == 1 {
+			continue
+		}
+		list = append(list, strings.Join(subtags[:n], "-"))
+	}
+
+	return list
+}
+
+// Chain returns the fallback chain of the tags in order of preference, each
+// followed by its less specific forms: "de-CH", "fr" gives "de-CH", "de",
+// "fr".
+func Chain(tags []string) []string {
+	chain := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		for _, t := range Fallbacks(tag) {
+			if !seen[t] {
+				seen[t] = true
+				chain = append(chain, t)
+			}
+		}
+	}
+
+	return chain
+}
+
+// Lookup returns the first tag of chain that is available. Failing that, it
+// returns the first available tag more specific than a tag of chain, so that
+// "pt" finds "pt-BR".
+func Lookup(chain, available []string) (string, bool) {
+	has := map[string]bool{}
+	for _, tag := range available {
+		has[tag] = true
+	}
+	for _, tag := range chain {
+		if has[tag] {
+			return tag, true
+		}
+	}
+
+	sorted := append([]string{}, available...)
+	sort.Strings(sorted)
+	for _, tag := range chain {
+		for _, a := range sorted {
+			if strings.HasPrefix(a, tag+"-") {
+				return a, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying the fallback chain of the
+// languages requested.
+func NewContext(ctx context.Context, chain []string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, chain)
+}
+
+// FromContext returns the fallback chain carried by ctx, none when no
+// language was requested.
+func FromContext(ctx context.Context) []string {
+	chain, _ := ctx.Value(ctxKey{}).([]string)
+	return chain
+}
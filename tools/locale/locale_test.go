@@ -0,0 +1,190 @@
+package locale
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "language",
+			s:    "fr",
+			want: "fr",
+		},
+		{
+			name: "language with region in any case",
+			s:    "pt_br",
+			want: "pt-BR",
+		},
+		{
+			name: "language with script and region",
+			s:    "zh-hant-tw",
+			want: "zh-Hant-TW",
+		},
+		{
+			name: "deprecated language",
+			s:    "iw",
+			want: "he",
+		},
+		{
+			name:    "unknown language",
+			s:       "xx",
+			wantErr: ErrInvalidTag,
+		},
+		{
+			name:    "undetermined language",
+			s:       "und",
+			wantErr: ErrInvalidTag,
+		},
+		{
+			name:    "empty",
+			s:       "",
+			wantErr: ErrInvalidTag,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.s)
+			if err != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{
+			name:   "sorted by quality",
+			header: "fr;q=0.5, de-CH, en;q=0.8",
+			want:   []string{"de-CH", "en", "fr"},
+		},
+		{
+			name:   "wildcard and refused languages left out",
+			header: "es, *;q=0.1, en;q=0",
+			want:   []string{"es"},
+		},
+		{
+			name:   "empty",
+			header: "",
+			want:   []string{},
+		},
+		{
+			name:   "unparsable",
+			header: "!!!",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "each tag followed by its fallbacks",
+			tags: []string{"de-CH", "fr"},
+			want: []string{"de-CH", "de", "fr"},
+		},
+		{
+			name: "script and region",
+			tags: []string{"zh-Hant-TW"},
+			want: []string{"zh-Hant-TW", "zh-Hant", "zh"},
+		},
+		{
+			name: "no duplicates",
+			tags: []string{"en-GB", "en-US", "en"},
+			want: []string{"en-GB", "en", "en-US"},
+		},
+		{
+			name: "extension singleton never last",
+			tags: []string{"en-a-bbb"},
+			want: []string{"en-a-bbb", "en"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Chain(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name      string
+		chain     []string
+		available []string
+		want      string
+		wantOk    bool
+	}{
+		{
+			name:      "first of the chain available",
+			chain:     []string{"de-CH", "de", "fr"},
+			available: []string{"fr", "de"},
+			want:      "de",
+			wantOk:    true,
+		},
+		{
+			name:      "more specific tag",
+			chain:     []string{"pt"},
+			available: []string{"pt-PT", "pt-BR"},
+			want:      "pt-BR",
+			wantOk:    true,
+		},
+		{
+			name:      "exact tag before a more specific one",
+			chain:     []string{"pt", "es"},
+			available: []string{"pt-BR", "es"},
+			want:      "es",
+			wantOk:    true,
+		},
+		{
+			name:      "none available",
+			chain:     []string{"ja"},
+			available: []string{"en"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Lookup(tt.chain, tt.available)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("Lookup() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != nil {
+		t.Errorf("FromContext() = %v, want none", got)
+	}
+
+	chain := []string{"fr-CA", "fr"}
+	if got := FromContext(NewContext(context.Background(), chain)); !reflect.DeepEqual(got, chain) {
+		t.Errorf("FromContext() = %v, want %v", got, chain)
+	}
+}
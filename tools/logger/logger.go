@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type ctxKey struct{}
+
+// entry holds the request scoped logger. It is stored by pointer so that
+// middlewares running deeper in the chain (e.g. after authentication) can
+// enrich the logger seen by the middlewares wrapping them.
+type entry struct {
+	mu     sync.RWMutex
+	logger *slog.Logger
+}
+
+// New creates a structured logger writing to w with the given level
+// (debug, info, warn, error) and format (json, text).
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "", FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+}
+
+// ParseLevel converts a level name to a slog.Level, defaulting to info.
+func ParseLevel(level string) (slog.Level, error) {
+	var lvl slog.Level
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return lvl, fmt.Errorf("invalid log level %q", level)
+	}
+
+	return lvl, nil
+}
+
+// NewContext returns a copy of ctx carrying l.
+func NewContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &entry{logger: l})
+}
+
+// FromContext returns the logger carried by ctx, or slog.Default() if none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if e, ok := ctx.Value(ctxKey{}).(*entry); ok {
+		e.mu.RLock()
+		defer e.mu.RUnlock()
+		return e.logger
+	}
+
+	return slog.Default()
+}
+
+// With adds attributes to the logger carried by ctx. It is a no-op when ctx
+// carries no logger.
+func With(ctx context.Context, args ...any) {
+	if e, ok := ctx.Value(ctxKey{}).(*entry); ok {
+		e.mu.Lock()
+		e.logger = e.logger.With(args...)
+		e.mu.Unlock()
+	}
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		format  string
+		wantErr bool
+	}{
+		{name: "defaults", level: "", format: ""},
+		{name: "json debug", level: "debug", format: "json"},
+		{name: "text warn", level: "warn", format: "text"},
+		{name: "invalid level", level: "verbose", format: "json", wantErr: true},
+		{name: "invalid format", level: "info", format: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&bytes.Buffer{}, tt.level, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, err := New(buf, "info", "json")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := NewContext(context.Background(), l)
+	With(ctx, "requestId", "req-1")
+	FromContext(ctx).Info("hello")
+
+	line := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("invalid json log line %q: %v", buf.String(), err)
+	}
+
+	if line["requestId"] != "req-1" {
+		t.Errorf("FromContext() requestId = %v, want %v", line["requestId"], "req-1")
+	}
+}
@@ -0,0 +1,51 @@
+package migrate
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[int]*Record
+}
+
+// NewMemoryStore creates a Store keeping the records in memory, for backends
+// without persistent schema such as the in-memory repositories.
+func NewMemoryStore() Store {
+	return &memoryStore{records: map[int]*Record{}}
+}
+
+func (s *memoryStore) Applied(ctx context.Context) ([]*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := []*Record{}
+	for _, record := range s.records {
+		copied := *record
+		list = append(list, &copied)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+
+	return list, nil
+}
+
+func (s *memoryStore) Insert(ctx context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *record
+	s.records[record.Version] = &copied
+
+	return nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, version)
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// Package migrate applies versioned schema migrations. It only orders and
+// records them; the migrations themselves and the Store keeping track of the
+// applied versions are provided by each database backend.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Migration changes the schema from the previous version to Version. Down
+// reverts Up and may be nil when the change cannot be undone.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context) error
+	Down        func(ctx context.Context) error
+}
+
+// Record is an applied migration.
+type Record struct {
+	Version     int
+	Description string
+	AppliedAt   time.Time
+}
+
+// Store keeps track of the applied migrations.
+type Store interface {
+	Applied(ctx context.Context) ([]*Record, error)
+	Insert(ctx context.Context, record *Record) error
+	Delete(ctx context.Context, version int) error
+}
+
+// Status tells whether a migration has been applied.
+type Status struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time
+}
+
+type Migrator struct {
+	store      Store
+	migrations []Migration
+}
+
+// New creates a Migrator for migrations, which must have distinct positive
+// versions. They are applied by ascending version whatever their order.
+func New(store Store, migrations ...Migration) (*Migrator, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("migration %q: version must be positive", m.Description)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("migration version %d registered twice", m.Version)
+		}
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d: Up is missing", m.Version)
+		}
+	}
+
+	return &Migrator{store: store, migrations: sorted}, nil
+}
+
+// Up applies the pending migrations and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := migration.Up(ctx); err != nil {
+			return done, fmt.Errorf("migration %d up: %w", migration.Version, err)
+		}
+		err := m.store.Insert(ctx, &Record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d record: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if migration.Down == nil {
+			return done, fmt.Errorf("migration %d cannot be reverted", migration.Version)
+		}
+		if err := migration.Down(ctx); err != nil {
+			return done, fmt.Errorf("migration %d down: %w", migration.Version, err)
+		}
+		if err := m.store.Delete(ctx, migration.Version); err != nil {
+			return done, fmt.Errorf("migration %d record: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Status lists every registered migration by ascending version.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Status{}
+	for _, migration := range m.migrations {
+		status := &Status{Version: migration.Version, Description: migration.Description}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		list = append(list, status)
+	}
+
+	return list, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]*Record, error) {
+	records, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[int]*Record{}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
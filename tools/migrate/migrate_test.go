@@ -0,0 +1,109 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func versions(migrations []Migration) []int {
+	list := []int{}
+	for _, m := range migrations {
+		list = append(list, m.Version)
+	}
+
+	return list
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	var log []string
+	migration := func(version int, name string) Migration {
+		return Migration{
+			Version:     version,
+			Description: name,
+			Up:          func(ctx context.Context) error { log = append(log, "up "+name); return nil },
+			Down:        func(ctx context.Context) error { log = append(log, "down "+name); return nil },
+		}
+	}
+
+	store := NewMemoryStore()
+	m, err := New(store, migration(2, "b"), migration(1, "a"), migration(3, "c"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	done, err := m.Up(ctx)
+	if err != nil || !reflect.DeepEqual(versions(done), []int{1, 2, 3}) {
+		t.Fatalf("Up() = %v, %v", versions(done), err)
+	}
+
+	done, err = m.Up(ctx)
+	if err != nil || len(done) != 0 {
+		t.Fatalf("second Up() = %v, %v", versions(done), err)
+	}
+
+	done, err = m.Down(ctx, 2)
+	if err != nil || !reflect.DeepEqual(versions(done), []int{3, 2}) {
+		t.Fatalf("Down(2) = %v, %v", versions(done), err)
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status[0].AppliedAt == nil || status[1].AppliedAt != nil || status[2].AppliedAt != nil {
+		t.Errorf("Status() applied = %v %v %v", status[0].AppliedAt, status[1].AppliedAt, status[2].AppliedAt)
+	}
+
+	want := []string{"up a", "up b", "up c", "down c", "down b"}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("ran %v, want %v", log, want)
+	}
+}
+
+func TestMigrator_Up_stopsOnError(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	m, _ := New(store,
+		Migration{Version: 1, Up: func(ctx context.Context) error { return nil }},
+		Migration{Version: 2, Up: func(ctx context.Context) error { return errors.New("error occur") }},
+		Migration{Version: 3, Up: func(ctx context.Context) error { return nil }},
+	)
+
+	done, err := m.Up(ctx)
+	if err == nil || !reflect.DeepEqual(versions(done), []int{1}) {
+		t.Errorf("Up() = %v, %v", versions(done), err)
+	}
+
+	records, _ := store.Applied(ctx)
+	if len(records) != 1 || records[0].Version != 1 {
+		t.Errorf("recorded %v, want version 1 only", records)
+	}
+
+	if _, err := m.Down(ctx, 1); err == nil {
+		t.Errorf("Down() of a migration without Down error = nil")
+	}
+}
+
+func TestNew_invalid(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+
+	tests := []struct {
+		name       string
+		migrations []Migration
+	}{
+		{name: "duplicate version", migrations: []Migration{{Version: 1, Up: up}, {Version: 1, Up: up}}},
+		{name: "zero version", migrations: []Migration{{Version: 0, Up: up}}},
+		{name: "missing up", migrations: []Migration{{Version: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(NewMemoryStore(), tt.migrations...); err == nil {
+				t.Errorf("New() error = nil")
+			}
+		})
+	}
+}
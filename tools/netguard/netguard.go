@@ -0,0 +1,48 @@
+// Package netguard keeps outgoing requests to user supplied URLs, like
+// webhook targets, from reaching the loopback, link-local and private
+// networks of the server.
+package netguard
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+)
+
+var ErrForbiddenAddress = errors.New("address not allowed")
+
+// Public reports whether ip is a globally routable unicast address.
+func Public(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// CheckHost rejects localhost and the IP addresses that are not public. Other
+// host names are checked when they are resolved, by Control.
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !Public(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// Control is a net.Dialer Control function refusing to connect to an address
+// that is not public, whatever the host name resolved to.
+func Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !Public(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		wantErr bool
+	}{
+		{host: "hooks.example.com"},
+		{host: "93.184.216.34"},
+		{host: "2606:2800:220:1:248:1893:25c8:1946"},
+		{host: "localhost", wantErr: true},
+		{host: "api.LOCALHOST.", wantErr: true},
+		{host: "127.0.0.1", wantErr: true},
+		{host: "::1", wantErr: true},
+		{host: "0.0.0.0", wantErr: true},
+		{host: "10.1.2.3", wantErr: true},
+		{host: "172.16.0.1", wantErr: true},
+		{host: "192.168.1.1", wantErr: true},
+		{host: "169.254.169.254", wantErr: true},
+		{host: "fe80::1", wantErr: true},
+		{host: "fd00::1", wantErr: true},
+		{host: "::ffff:127.0.0.1", wantErr: true},
+		{host: "224.0.0.1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if err := CheckHost(tt.host); (err != nil) != tt.wantErr {
+				t.Errorf("CheckHost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestControl(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	dialer := &net.Dialer{Control: Control}
+	_, err := dialer.DialContext(context.Background(), "tcp", srv.Listener.Addr().String())
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("DialContext() to a loopback address error = %v, want %v", err, ErrForbiddenAddress)
+	}
+}
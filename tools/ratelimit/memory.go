@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cleanupInterval is how often idle buckets are dropped from a memoryStore.
+const cleanupInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+type memoryStore struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	now         func() time.Time
+	lastCleanup time.Time
+}
+
+// NewMemoryStore creates a Store keeping buckets in the process memory. It is
+// only suitable for a single replica.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Take(ctx context.Context, key string, limit Limit) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.cleanup(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.Capacity(), last: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = refill(b.tokens, b.last, now, limit)
+	b.last = now
+	b.limit = limit
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return NewResult(allowed, b.tokens, limit), nil
+}
+
+// cleanup drops the buckets that are full again, as they are equivalent to a
+// missing bucket.
+func (s *memoryStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < cleanupInterval {
+		return
+	}
+	s.lastCleanup = now
+
+	for key, b := range s.buckets {
+		if refill(b.tokens, b.last, now, b.limit) >= b.limit.Capacity() {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	now := time.Date(2023, 9, 9, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore().(*memoryStore)
+	s.now = func() time.Time { return now }
+
+	limit := Limit{Requests: 1, Period: time.Second, Burst: 2}
+
+	tests := []struct {
+		name          string
+		advance       time.Duration
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{name: "first request", wantAllowed: true, wantRemaining: 1},
+		{name: "burst request", wantAllowed: true, wantRemaining: 0},
+		{name: "bucket empty", wantAllowed: false, wantRemaining: 0},
+		{name: "bucket refilled", advance: time.Second, wantAllowed: true, wantRemaining: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			got, err := s.Take(context.TODO(), "key", limit)
+			if err != nil {
+				t.Fatalf("memoryStore.Take() error = %v", err)
+			}
+			if got.Allowed != tt.wantAllowed {
+				t.Errorf("memoryStore.Take() allowed = %v, want %v", got.Allowed, tt.wantAllowed)
+			}
+			if got.Remaining != tt.wantRemaining {
+				t.Errorf("memoryStore.Take() remaining = %v, want %v", got.Remaining, tt.wantRemaining)
+			}
+			if !got.Allowed && got.RetryAfter != time.Second {
+				t.Errorf("memoryStore.Take() retryAfter = %v, want %v", got.RetryAfter, time.Second)
+			}
+		})
+	}
+}
+
+func TestPolicy_For(t *testing.T) {
+	admin := Limit{Requests: 100, Period: time.Minute}
+	p := Policy{
+		Default: Limit{Requests: 10, Period: time.Minute},
+		Roles:   map[string]Limit{"admin": admin},
+	}
+
+	if got := p.For("admin"); got != admin {
+		t.Errorf("Policy.For(admin) = %v, want %v", got, admin)
+	}
+
+	if got := p.For(RoleAnonymous); got != p.Default {
+		t.Errorf("Policy.For(anonymous) = %v, want %v", got, p.Default)
+	}
+}
@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+const (
+	RoleAnonymous = "anonymous"
+)
+
+// Limit describes a token bucket refilled with Requests tokens every Period
+// and holding at most Burst tokens. A zero Limit means unlimited.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// Unlimited reports whether l does not restrict requests.
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+// Capacity returns the maximum number of tokens in the bucket.
+func (l Limit) Capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+
+	return float64(l.Requests)
+}
+
+// Rate returns the number of tokens added per second.
+func (l Limit) Rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Policy holds the limits of a route group, optionally overridden per role.
+type Policy struct {
+	Default Limit
+	Roles   map[string]Limit
+}
+
+// For returns the limit applying to role.
+func (p Policy) For(role string) Limit {
+	if l, ok := p.Roles[role]; ok {
+		return l
+	}
+
+	return p.Default
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store keeps the token buckets. Implementations must be safe for concurrent
+// use.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// refill returns the tokens available at now in a bucket that held tokens at
+// last.
+func refill(tokens float64, last, now time.Time, limit Limit) float64 {
+	elapsed := now.Sub(last).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	return math.Min(limit.Capacity(), tokens+elapsed*limit.Rate())
+}
+
+// NewResult builds the Result for a bucket left with tokens after the take.
+func NewResult(allowed bool, tokens float64, limit Limit) *Result {
+	rate := limit.Rate()
+	capacity := limit.Capacity()
+
+	res := &Result{
+		Allowed:   allowed,
+		Limit:     int(capacity),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((capacity - tokens) / rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / rate)
+	}
+
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s)) * time.Second
+}
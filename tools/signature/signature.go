@@ -0,0 +1,55 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderSignature = "X-Bookstore-Signature"
+	HeaderTimestamp = "X-Bookstore-Timestamp"
+
+	prefix = "sha256="
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpiredTimestamp = errors.New("timestamp outside tolerance")
+)
+
+// Sign returns the HMAC-SHA256 signature of body sent at timestamp, as sent
+// in the X-Bookstore-Signature header. The timestamp is part of the signed
+// content so that a captured request cannot be replayed later.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return prefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature and timestamp header pair received with body.
+// Timestamps further than tolerance from now are rejected.
+func Verify(secret, sig, timestamp string, body []byte, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	ts := time.Unix(unix, 0)
+	if d := time.Since(ts); d > tolerance || d < -tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	if !strings.HasPrefix(sig, prefix) || !hmac.Equal([]byte(sig), []byte(Sign(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
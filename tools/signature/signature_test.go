@@ -0,0 +1,36 @@
+package signature
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"book.created"}`)
+	now := time.Now()
+	sig := Sign("secret", now, body)
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		sig       string
+		timestamp string
+		body      []byte
+		wantErr   error
+	}{
+		{name: "valid signature", secret: "secret", sig: sig, timestamp: ts, body: body},
+		{name: "wrong secret", secret: "other", sig: sig, timestamp: ts, body: body, wantErr: ErrInvalidSignature},
+		{name: "tampered body", secret: "secret", sig: sig, timestamp: ts, body: []byte(`{}`), wantErr: ErrInvalidSignature},
+		{name: "old timestamp", secret: "secret", sig: sig, timestamp: strconv.FormatInt(now.Add(-time.Hour).Unix(), 10), body: body, wantErr: ErrExpiredTimestamp},
+		{name: "invalid timestamp", secret: "secret", sig: sig, timestamp: "now", body: body, wantErr: ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.secret, tt.sig, tt.timestamp, tt.body, 5*time.Minute); err != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name       string
+		w, h       int
+		width      int
+		wantW      int
+		wantH      int
+		wantSource bool
+	}{
+		{name: "scale down keeping the aspect ratio", w: 400, h: 600, width: 100, wantW: 100, wantH: 150},
+		{name: "round the height", w: 300, h: 100, width: 200, wantW: 200, wantH: 67},
+		{name: "keep at least one row", w: 1000, h: 1, width: 10, wantW: 10, wantH: 1},
+		{name: "narrower image unchanged", w: 80, h: 120, width: 100, wantW: 80, wantH: 120, wantSource: true},
+		{name: "same width unchanged", w: 100, h: 120, width: 100, wantW: 100, wantH: 120, wantSource: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := image.NewRGBA(image.Rect(0, 0, tt.w, tt.h))
+			got := Scale(src, tt.width)
+			if got.Bounds().Dx() != tt.wantW || got.Bounds().Dy() != tt.wantH {
+				t.Errorf("Scale() = %v, want %dx%d", got.Bounds(), tt.wantW, tt.wantH)
+			}
+			if (got == image.Image(src)) != tt.wantSource {
+				t.Errorf("Scale() returned the source = %v, want %v", got == image.Image(src), tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestScale_averages(t *testing.T) {
+	// Black and white columns average to grey.
+	src := image.NewRGBA(image.Rect(10, 10, 14, 12))
+	for y := 10; y < 12; y++ {
+		for x := 10; x < 14; x++ {
+			c := color.RGBA{A: 255}
+			if x%2 == 0 {
+				c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			src.Set(x, y, c)
+		}
+	}
+
+	got := Scale(src, 2)
+	want := color.RGBA{R: 127, G: 127, B: 127, A: 255}
+	for y := 0; y < 1; y++ {
+		for x := 0; x < 2; x++ {
+			if c := got.At(x, y); c != want {
+				t.Errorf("At(%d, %d) = %v, want %v", x, y, c, want)
+			}
+		}
+	}
+}
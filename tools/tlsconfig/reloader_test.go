@@ -0,0 +1,138 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bookstore.com/config"
+)
+
+func writeCert(t *testing.T, dir, commonName string, modTime time.Time) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{certFile, keyFile} {
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, r *Reloader) string {
+	t.Helper()
+
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return leaf.Subject.CommonName
+}
+
+func TestReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	modTime := time.Now().Add(-time.Minute)
+	certFile, keyFile := writeCert(t, dir, "first", modTime)
+
+	r, err := NewReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	if reloaded, _ := r.Reload(); reloaded {
+		t.Errorf("Reload() reloaded unchanged files")
+	}
+
+	writeCert(t, dir, "second", modTime.Add(time.Second))
+	reloaded, err := r.Reload()
+	if err != nil || !reloaded {
+		t.Fatalf("Reload() = %v, %v, want true, nil", reloaded, err)
+	}
+
+	if got := commonName(t, r); got != "second" {
+		t.Errorf("GetCertificate() common name = %s, want %s", got, "second")
+	}
+
+	os.WriteFile(keyFile, []byte("invalid"), 0600)
+	os.Chtimes(keyFile, modTime.Add(2*time.Second), modTime.Add(2*time.Second))
+	if _, err := r.Reload(); err == nil {
+		t.Errorf("Reload() expected error for invalid key")
+	}
+
+	if got := commonName(t, r); got != "second" {
+		t.Errorf("GetCertificate() common name = %s after failed reload, want %s", got, "second")
+	}
+}
+
+func TestNew(t *testing.T) {
+	certFile, keyFile := writeCert(t, t.TempDir(), "server", time.Now())
+
+	tests := []struct {
+		name       string
+		clientAuth string
+		clientCA   string
+		wantErr    bool
+	}{
+		{name: "server only", clientAuth: ClientAuthNone},
+		{name: "mutual tls", clientAuth: ClientAuthRequire, clientCA: certFile},
+		{name: "mutual tls without ca", clientAuth: ClientAuthRequire, wantErr: true},
+		{name: "invalid client auth", clientAuth: "always", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := New(config.TLS{
+				CertFile:     certFile,
+				KeyFile:      keyFile,
+				ClientCAFile: tt.clientCA,
+				ClientAuth:   tt.clientAuth,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"bookstore.com/config"
+)
+
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+)
+
+// New builds the server TLS configuration. The certificate is served through
+// the returned Reloader so that it can be replaced on disk without a restart.
+// When a client CA is configured, client certificates are verified against it
+// for mutual TLS.
+func New(conf config.TLS) (*tls.Config, *Reloader, error) {
+	reloader, err := NewReloader(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConf := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     []string{"h2", "http/1.1"},
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	clientAuth, err := parseClientAuth(conf.ClientAuth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if clientAuth != tls.NoClientCert {
+		if conf.ClientCAFile == "" {
+			return nil, nil, fmt.Errorf("clientCAFile is required for client authentication")
+		}
+
+		raw, err := os.ReadFile(conf.ClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading client CA: %v", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(raw) {
+			return nil, nil, fmt.Errorf("no certificate found in client CA %s", conf.ClientCAFile)
+		}
+
+		tlsConf.ClientCAs = pool
+		tlsConf.ClientAuth = clientAuth
+	}
+
+	return tlsConf, reloader, nil
+}
+
+func parseClientAuth(s string) (tls.ClientAuthType, error) {
+	switch s {
+	case "", ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthRequest:
+		return tls.VerifyClientCertIfGiven, nil
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("invalid client auth %q", s)
+	}
+}